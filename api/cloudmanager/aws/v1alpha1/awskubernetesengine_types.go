@@ -35,11 +35,22 @@ type AWSKubernetesEngineSpec struct {
 	// Dependencies defines the dependency configurations for the AWS Kubernetes Engine.
 	// +optional
 	Dependencies common.Dependencies `json:"dependencies,omitempty"`
+
+	// ImageMirrors defines the rules rewriting the images of the dependencies, for clusters pulling
+	// images from a mirror registry. When several sources match an image, the longest one is used.
+	// +listType=map
+	// +listMapKey=source
+	// +optional
+	ImageMirrors []common.ImageMirror `json:"imageMirrors,omitempty"`
 }
 
 // AWSKubernetesEngineStatus defines the observed state of AWSKubernetesEngine.
 type AWSKubernetesEngineStatus struct {
 	apicommon.Status `json:",inline"`
+
+	// Dependencies is the observed state of each dependency, by dependency name.
+	// +optional
+	Dependencies map[string]common.DependencyStatus `json:"dependencies,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -49,6 +60,13 @@ type AWSKubernetesEngineStatus struct {
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Ready"
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="Reason"
 // +kubebuilder:printcolumn:name="Deps Available",type=string,JSONPath=`.status.conditions[?(@.type=="DependenciesAvailable")].status`,description="DependenciesAvailable"
+// +kubebuilder:printcolumn:name="Gateway API",type=string,JSONPath=`.status.dependencies.gatewayAPI.version`,description="Installed Gateway API version",priority=1
+// +kubebuilder:printcolumn:name="Cert Manager",type=string,JSONPath=`.status.dependencies.certManager.version`,description="Installed Cert Manager version",priority=1
+// +kubebuilder:printcolumn:name="LWS",type=string,JSONPath=`.status.dependencies.lws.version`,description="Installed LWS version",priority=1
+// +kubebuilder:printcolumn:name="Sail Operator",type=string,JSONPath=`.status.dependencies.sailOperator.version`,description="Installed Sail Operator version",priority=1
+// +kubebuilder:printcolumn:name="Kueue",type=string,JSONPath=`.status.dependencies.kueue.version`,description="Installed Kueue version",priority=1
+// +kubebuilder:printcolumn:name="Prometheus Operator",type=string,JSONPath=`.status.dependencies.prometheusOperator.version`,description="Installed Prometheus Operator version",priority=1
+// +kubebuilder:printcolumn:name="KEDA",type=string,JSONPath=`.status.dependencies.keda.version`,description="Installed KEDA version",priority=1
 
 // AWSKubernetesEngine is the Schema for the awskubernetesengines API.
 // It represents the configuration for an AWS Kubernetes Service cluster.
@@ -64,6 +82,18 @@ func (e *AWSKubernetesEngine) GetDependencies() common.Dependencies {
 	return e.Spec.Dependencies
 }
 
+func (e *AWSKubernetesEngine) GetImageMirrors() []common.ImageMirror {
+	return e.Spec.ImageMirrors
+}
+
+func (e *AWSKubernetesEngine) GetDependenciesStatus() map[string]common.DependencyStatus {
+	return e.Status.Dependencies
+}
+
+func (e *AWSKubernetesEngine) SetDependenciesStatus(status map[string]common.DependencyStatus) {
+	e.Status.Dependencies = status
+}
+
 // +kubebuilder:object:root=true
 
 // AWSKubernetesEngineList contains a list of AWSKubernetesEngine.
@@ -21,6 +21,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -29,7 +30,7 @@ func (in *AzureKubernetesEngine) DeepCopyInto(out *AzureKubernetesEngine) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -86,7 +87,12 @@ func (in *AzureKubernetesEngineList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureKubernetesEngineSpec) DeepCopyInto(out *AzureKubernetesEngineSpec) {
 	*out = *in
-	out.Dependencies = in.Dependencies
+	in.Dependencies.DeepCopyInto(&out.Dependencies)
+	if in.ImageMirrors != nil {
+		in, out := &in.ImageMirrors, &out.ImageMirrors
+		*out = make([]common.ImageMirror, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKubernetesEngineSpec.
@@ -103,6 +109,13 @@ func (in *AzureKubernetesEngineSpec) DeepCopy() *AzureKubernetesEngineSpec {
 func (in *AzureKubernetesEngineStatus) DeepCopyInto(out *AzureKubernetesEngineStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make(map[string]common.DependencyStatus, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AzureKubernetesEngineStatus.
@@ -1,11 +1,15 @@
 package common
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
 	apicommon "github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 )
 
 // ManagementPolicy defines the policy for managing a cloud manager dependency.
-// +kubebuilder:validation:Enum=Managed;Unmanaged
+// +kubebuilder:validation:Enum=Managed;Unmanaged;Auto
 type ManagementPolicy string
 
 const (
@@ -14,6 +18,27 @@ const (
 	// Unmanaged means the operator does not install or manage the dependency.
 	// The user is responsible for ensuring the dependency is available.
 	Unmanaged ManagementPolicy = "Unmanaged"
+	// Auto means the operator looks for an existing installation of the dependency.
+	// A compatible installation is adopted and only monitored, otherwise the
+	// operator installs the dependency as if it were Managed.
+	Auto ManagementPolicy = "Auto"
+)
+
+// UpgradePolicy defines how the version of a cloud manager dependency is selected
+// among the chart versions bundled with the operator.
+// +kubebuilder:validation:Enum=Automatic;Manual;Pinned
+type UpgradePolicy string
+
+const (
+	// UpgradeAutomatic installs the newest bundled version, upgrading the dependency
+	// as soon as an operator release bundles a newer one.
+	UpgradeAutomatic UpgradePolicy = "Automatic"
+	// UpgradeManual keeps the installed version until the user sets a different one.
+	// The newest bundled version is installed when the dependency is not installed yet,
+	// and the reconciliation fails when the installed version is not bundled anymore.
+	UpgradeManual UpgradePolicy = "Manual"
+	// UpgradePinned installs exactly the version set by the user.
+	UpgradePinned UpgradePolicy = "Pinned"
 )
 
 // Default namespaces for cloud manager dependencies.
@@ -22,6 +47,9 @@ const (
 	DefaultNamespaceCertManagerOperand  = "cert-manager"
 	DefaultNamespaceLWSOperator         = "openshift-lws-operator"
 	DefaultNamespaceSailOperator        = "istio-system"
+	DefaultNamespaceKueueOperator       = "openshift-kueue-operator"
+	DefaultNamespacePrometheusOperator  = "prometheus-operator"
+	DefaultNamespaceKEDA                = "openshift-keda"
 )
 
 // Namespace represents a Kubernetes namespace name (RFC 1123 DNS label).
@@ -32,7 +60,10 @@ type Namespace string
 
 // CertManagerConfiguration defines the configuration for the cert-manager operator dependency.
 // +kubebuilder:object:generate=true
-type CertManagerConfiguration struct{}
+type CertManagerConfiguration struct {
+	DeploymentSettings `json:",inline"`
+	ValuesOverride     `json:",inline"`
+}
 
 // LWSConfiguration defines the configuration for the LeaderWorkerSet (LWS) operator dependency.
 // +kubebuilder:object:generate=true
@@ -40,6 +71,9 @@ type LWSConfiguration struct {
 	// Namespace is the namespace where the LWS operator is deployed.
 	// +kubebuilder:default=openshift-lws-operator
 	Namespace Namespace `json:"namespace,omitempty"`
+
+	DeploymentSettings `json:",inline"`
+	ValuesOverride     `json:",inline"`
 }
 
 // SailOperatorConfiguration defines the configuration for the Sail operator (Istio) dependency.
@@ -48,21 +82,140 @@ type SailOperatorConfiguration struct {
 	// Namespace is the namespace where the Sail operator (Istio) is deployed.
 	// +kubebuilder:default=istio-system
 	Namespace Namespace `json:"namespace,omitempty"`
+
+	// MeshConfig defines the mesh-wide settings of Istio, passed to the chart as meshConfig.
+	// +optional
+	MeshConfig *MeshConfig `json:"meshConfig,omitempty"`
+
+	DeploymentSettings `json:",inline"`
+	ValuesOverride     `json:",inline"`
 }
 
 // GatewayAPIConfiguration defines the configuration for the Gateway API dependency.
+// The Gateway API only installs CRDs, hence has no deployment settings.
+// +kubebuilder:object:generate=true
+type GatewayAPIConfiguration struct {
+	ValuesOverride `json:",inline"`
+}
+
+// KueueConfiguration defines the configuration for the Kueue operator dependency.
+// +kubebuilder:object:generate=true
+type KueueConfiguration struct {
+	// Namespace is the namespace where the Kueue operator is deployed.
+	// +kubebuilder:default=openshift-kueue-operator
+	Namespace Namespace `json:"namespace,omitempty"`
+
+	DeploymentSettings `json:",inline"`
+	ValuesOverride     `json:",inline"`
+}
+
+// PrometheusOperatorConfiguration defines the configuration for the Prometheus operator dependency.
+// +kubebuilder:object:generate=true
+type PrometheusOperatorConfiguration struct {
+	// Namespace is the namespace where the Prometheus operator is deployed.
+	// +kubebuilder:default=prometheus-operator
+	Namespace Namespace `json:"namespace,omitempty"`
+
+	DeploymentSettings `json:",inline"`
+	ValuesOverride     `json:",inline"`
+}
+
+// KEDAConfiguration defines the configuration for the KEDA operator dependency.
+// +kubebuilder:object:generate=true
+type KEDAConfiguration struct {
+	// Namespace is the namespace where the KEDA operator is deployed.
+	// +kubebuilder:default=openshift-keda
+	Namespace Namespace `json:"namespace,omitempty"`
+
+	DeploymentSettings `json:",inline"`
+	ValuesOverride     `json:",inline"`
+}
+
+// DeploymentSettings defines the sizing and scheduling of the operator deployments of a
+// dependency. Each field is passed to the chart as the value of the same name.
+// +kubebuilder:object:generate=true
+type DeploymentSettings struct {
+	// Replicas is the number of replicas of the operator deployments.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Resources are the compute resources of the operator containers.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NodeSelector constrains the operator pods to the nodes with matching labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow the operator pods to be scheduled on nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// ValuesOverride defines Helm values merged over the bundled chart values of a dependency.
+// +kubebuilder:object:generate=true
+type ValuesOverride struct {
+	// Values are deep merged over the bundled chart values. They cannot override the values
+	// set by the operator, such as the namespaces and the global cluster settings, and the
+	// typed fields of the configuration take precedence over the same keys. Keys unknown to
+	// the chart are ignored.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Values *runtime.RawExtension `json:"values,omitempty"`
+}
+
+// AccessLogEncoding defines the format of the Istio access logs.
+// +kubebuilder:validation:Enum=TEXT;JSON
+type AccessLogEncoding string
+
+// OutboundTrafficPolicyMode defines how the mesh handles traffic to unknown destinations.
+// +kubebuilder:validation:Enum=ALLOW_ANY;REGISTRY_ONLY
+type OutboundTrafficPolicyMode string
+
+// MeshConfig defines the mesh-wide settings of Istio.
+// +kubebuilder:object:generate=true
+type MeshConfig struct {
+	// AccessLogFile is the file the proxies write access logs to, /dev/stdout for the container output.
+	// Access logs are disabled when empty.
+	// +optional
+	AccessLogFile string `json:"accessLogFile,omitempty"`
+
+	// AccessLogEncoding is the format of the access logs.
+	// +optional
+	AccessLogEncoding AccessLogEncoding `json:"accessLogEncoding,omitempty"`
+
+	// EnableTracing enables the tracing of the requests by the proxies.
+	// +optional
+	EnableTracing *bool `json:"enableTracing,omitempty"`
+
+	// OutboundTrafficPolicy defines how the proxies handle traffic to destinations outside of the mesh.
+	// +optional
+	OutboundTrafficPolicy *OutboundTrafficPolicy `json:"outboundTrafficPolicy,omitempty"`
+}
+
+// OutboundTrafficPolicy defines how the Istio proxies handle outbound traffic.
 // +kubebuilder:object:generate=true
-type GatewayAPIConfiguration struct{}
+type OutboundTrafficPolicy struct {
+	// Mode is ALLOW_ANY to let traffic to unknown destinations through, or REGISTRY_ONLY to block it.
+	// +optional
+	Mode OutboundTrafficPolicyMode `json:"mode,omitempty"`
+}
 
 // CertManagerDependency defines the cert-manager operator dependency.
 // +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
 type CertManagerDependency struct {
 	// ManagementPolicy determines whether the operator manages this dependency.
 	// Managed: the operator installs and reconciles the dependency.
 	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
 	// +kubebuilder:default=Managed
 	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
 
+	VersionPolicy `json:",inline"`
+
 	// Configuration for the cert-manager operator.
 	// +optional
 	Configuration CertManagerConfiguration `json:"configuration,omitempty"`
@@ -70,13 +223,18 @@ type CertManagerDependency struct {
 
 // LWSDependency defines the LeaderWorkerSet operator dependency.
 // +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
 type LWSDependency struct {
 	// ManagementPolicy determines whether the operator manages this dependency.
 	// Managed: the operator installs and reconciles the dependency.
 	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
 	// +kubebuilder:default=Managed
 	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
 
+	VersionPolicy `json:",inline"`
+
 	// Configuration for the LWS operator.
 	// +optional
 	// +kubebuilder:default={}
@@ -95,13 +253,18 @@ func (d *LWSDependency) GetNamespace() string {
 
 // SailOperatorDependency defines the Sail operator (Istio) dependency.
 // +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
 type SailOperatorDependency struct {
 	// ManagementPolicy determines whether the operator manages this dependency.
 	// Managed: the operator installs and reconciles the dependency.
 	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
 	// +kubebuilder:default=Managed
 	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
 
+	VersionPolicy `json:",inline"`
+
 	// Configuration for the Sail operator.
 	// +optional
 	// +kubebuilder:default={}
@@ -120,22 +283,270 @@ func (d *SailOperatorDependency) GetNamespace() string {
 
 // GatewayAPIDependency defines the Gateway API dependency.
 // +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
 type GatewayAPIDependency struct {
 	// ManagementPolicy determines whether the operator manages this dependency.
 	// Managed: the operator installs and reconciles the dependency.
 	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
 	// +kubebuilder:default=Managed
 	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
 
+	VersionPolicy `json:",inline"`
+
 	// Configuration for the Gateway API.
 	// +optional
 	Configuration GatewayAPIConfiguration `json:"configuration,omitempty"`
 }
 
+// KueueDependency defines the Kueue operator dependency.
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
+type KueueDependency struct {
+	// ManagementPolicy determines whether the operator manages this dependency.
+	// Managed: the operator installs and reconciles the dependency.
+	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
+	// Defaults to Unmanaged, the dependency is only installed when opted in.
+	// +kubebuilder:default=Unmanaged
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	VersionPolicy `json:",inline"`
+
+	// Configuration for the Kueue operator.
+	// +optional
+	// +kubebuilder:default={}
+	Configuration KueueConfiguration `json:"configuration,omitempty"`
+}
+
+// GetManagementPolicy returns the management policy of the Kueue operator, falling
+// back to Unmanaged if empty, as engines created before the dependency was
+// added must not start installing it.
+func (d *KueueDependency) GetManagementPolicy() ManagementPolicy {
+	if d.ManagementPolicy == "" {
+		return Unmanaged
+	}
+
+	return d.ManagementPolicy
+}
+
+// GetNamespace returns the namespace where the Kueue operator is deployed,
+// falling back to DefaultNamespaceKueueOperator if empty.
+func (d *KueueDependency) GetNamespace() string {
+	if d.Configuration.Namespace != "" {
+		return string(d.Configuration.Namespace)
+	}
+
+	return DefaultNamespaceKueueOperator
+}
+
+// PrometheusOperatorDependency defines the Prometheus operator dependency.
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
+type PrometheusOperatorDependency struct {
+	// ManagementPolicy determines whether the operator manages this dependency.
+	// Managed: the operator installs and reconciles the dependency.
+	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
+	// Defaults to Unmanaged, the dependency is only installed when opted in.
+	// +kubebuilder:default=Unmanaged
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	VersionPolicy `json:",inline"`
+
+	// Configuration for the Prometheus operator.
+	// +optional
+	// +kubebuilder:default={}
+	Configuration PrometheusOperatorConfiguration `json:"configuration,omitempty"`
+}
+
+// GetManagementPolicy returns the management policy of the Prometheus operator, falling
+// back to Unmanaged if empty, as engines created before the dependency was
+// added must not start installing it.
+func (d *PrometheusOperatorDependency) GetManagementPolicy() ManagementPolicy {
+	if d.ManagementPolicy == "" {
+		return Unmanaged
+	}
+
+	return d.ManagementPolicy
+}
+
+// GetNamespace returns the namespace where the Prometheus operator is deployed,
+// falling back to DefaultNamespacePrometheusOperator if empty.
+func (d *PrometheusOperatorDependency) GetNamespace() string {
+	if d.Configuration.Namespace != "" {
+		return string(d.Configuration.Namespace)
+	}
+
+	return DefaultNamespacePrometheusOperator
+}
+
+// KEDADependency defines the KEDA (Kubernetes Event-driven Autoscaling) operator dependency.
+// +kubebuilder:object:generate=true
+// +kubebuilder:validation:XValidation:rule="!has(self.upgradePolicy) || self.upgradePolicy != 'Pinned' || (has(self.version) && size(self.version) > 0)",message="version is required when upgradePolicy is Pinned"
+type KEDADependency struct {
+	// ManagementPolicy determines whether the operator manages this dependency.
+	// Managed: the operator installs and reconciles the dependency.
+	// Unmanaged: the operator does not manage the dependency; the user is responsible.
+	// Auto: the operator adopts an existing installation and only monitors it, or
+	// installs the dependency when none is found.
+	// Defaults to Unmanaged, the dependency is only installed when opted in.
+	// +kubebuilder:default=Unmanaged
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	VersionPolicy `json:",inline"`
+
+	// Configuration for the KEDA operator.
+	// +optional
+	// +kubebuilder:default={}
+	Configuration KEDAConfiguration `json:"configuration,omitempty"`
+}
+
+// GetManagementPolicy returns the management policy of the KEDA operator, falling
+// back to Unmanaged if empty, as engines created before the dependency was
+// added must not start installing it.
+func (d *KEDADependency) GetManagementPolicy() ManagementPolicy {
+	if d.ManagementPolicy == "" {
+		return Unmanaged
+	}
+
+	return d.ManagementPolicy
+}
+
+// GetNamespace returns the namespace where the KEDA operator is deployed,
+// falling back to DefaultNamespaceKEDA if empty.
+func (d *KEDADependency) GetNamespace() string {
+	if d.Configuration.Namespace != "" {
+		return string(d.Configuration.Namespace)
+	}
+
+	return DefaultNamespaceKEDA
+}
+
+// LoadBalancerType defines how the Services exposing dependencies outside of the cluster are published.
+// +kubebuilder:validation:Enum=LoadBalancer;NodePort;ClusterIP
+type LoadBalancerType string
+
+const (
+	// LoadBalancerTypeLoadBalancer publishes Services through a load balancer provisioned by the cluster.
+	LoadBalancerTypeLoadBalancer LoadBalancerType = "LoadBalancer"
+	// LoadBalancerTypeNodePort publishes Services on a port of every node.
+	LoadBalancerTypeNodePort LoadBalancerType = "NodePort"
+	// LoadBalancerTypeClusterIP does not publish Services outside of the cluster.
+	LoadBalancerTypeClusterIP LoadBalancerType = "ClusterIP"
+)
+
+// ClusterSettings defines cluster-wide settings for providers that cannot infer them from the cloud environment.
+// +kubebuilder:object:generate=true
+type ClusterSettings struct {
+	// StorageClassName is the StorageClass used by the dependencies requesting persistent storage.
+	// When empty, the cluster default StorageClass is used.
+	// +optional
+	StorageClassName string `json:"storageClassName,omitempty"`
+
+	// LoadBalancerType is the type of the Services exposing dependencies outside of the cluster.
+	// +kubebuilder:default=NodePort
+	// +optional
+	LoadBalancerType LoadBalancerType `json:"loadBalancerType,omitempty"`
+}
+
+// ImageMirror defines a rule rewriting the images of the dependencies to pull them from a mirror registry.
+// +kubebuilder:object:generate=true
+type ImageMirror struct {
+	// Source is the prefix of the images pulled from the mirror, a registry or a repository, e.g. quay.io/jetstack.
+	// It matches the images equal to it or followed by a path, a tag or a digest.
+	// +kubebuilder:validation:MinLength=1
+	Source string `json:"source"`
+
+	// Mirror is the prefix replacing Source in the matching images, e.g. registry.example.com/jetstack.
+	// +kubebuilder:validation:MinLength=1
+	Mirror string `json:"mirror"`
+}
+
+// VersionPolicy defines which of the bundled chart versions of a dependency is installed.
+// +kubebuilder:object:generate=true
+type VersionPolicy struct {
+	// Version is the version of the dependency chart to install. It must be one of the
+	// versions bundled with the operator. Required when UpgradePolicy is Pinned; with
+	// the Manual policy it selects the version to upgrade or downgrade to.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// UpgradePolicy determines how the installed version follows the bundled versions.
+	// Automatic: the newest bundled version is installed.
+	// Manual: the installed version is kept until Version is changed.
+	// Pinned: the version set in Version is installed.
+	// +kubebuilder:default=Automatic
+	// +optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
+}
+
+// ChartState is the state of the chart of a cloud manager dependency.
+// +kubebuilder:validation:Enum=Managed;Cleaning;Excluded;External
+type ChartState string
+
+const (
+	// ChartStateManaged means the chart is rendered and deployed.
+	ChartStateManaged ChartState = "Managed"
+	// ChartStateCleaning means the dependency is Unmanaged and its operator is kept
+	// until its operator CR is deleted.
+	ChartStateCleaning ChartState = "Cleaning"
+	// ChartStateExcluded means the chart is not deployed and its resources are removed.
+	ChartStateExcluded ChartState = "Excluded"
+	// ChartStateExternal means an existing installation of the dependency is adopted
+	// by the Auto policy, so the chart is not deployed.
+	ChartStateExternal ChartState = "External"
+)
+
+// DependencyStatus defines the observed state of a cloud manager dependency.
+// +kubebuilder:object:generate=true
+type DependencyStatus struct {
+	// ManagementPolicy is the management policy of the dependency.
+	// +optional
+	ManagementPolicy ManagementPolicy `json:"managementPolicy,omitempty"`
+
+	// ChartState is the state of the chart of the dependency.
+	// +optional
+	ChartState ChartState `json:"chartState,omitempty"`
+
+	// Namespace is the namespace of the operator of the dependency.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Version is the installed version of the dependency, empty when unknown.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// AvailableVersion is the newest version of the dependency bundled with the operator.
+	// +optional
+	AvailableVersion string `json:"availableVersion,omitempty"`
+
+	// Ready is the status of the readiness condition of the dependency.
+	// +optional
+	Ready metav1.ConditionStatus `json:"ready,omitempty"`
+
+	// Reason is the reason of the readiness condition of the dependency.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// LastError is the message of the readiness condition of the dependency while it is not ready.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+}
+
 // KubernetesEngineInstance is implemented by CCM CR types that expose their Dependencies.
 type KubernetesEngineInstance interface {
 	apicommon.PlatformObject
 	GetDependencies() Dependencies
+	// GetImageMirrors returns the rules rewriting the images of the dependencies.
+	GetImageMirrors() []ImageMirror
+	// GetDependenciesStatus returns the observed state of the dependencies, by dependency name.
+	GetDependenciesStatus() map[string]DependencyStatus
+	// SetDependenciesStatus sets the observed state of the dependencies, by dependency name.
+	SetDependenciesStatus(status map[string]DependencyStatus)
 }
 
 // Dependencies defines the dependency configurations for cloud manager operators.
@@ -156,4 +567,16 @@ type Dependencies struct {
 	// GatewayAPI defines the Gateway API dependency.
 	// +optional
 	GatewayAPI GatewayAPIDependency `json:"gatewayAPI,omitempty"`
+
+	// Kueue defines the Kueue operator dependency.
+	// +optional
+	Kueue KueueDependency `json:"kueue,omitempty"`
+
+	// PrometheusOperator defines the Prometheus operator dependency.
+	// +optional
+	PrometheusOperator PrometheusOperatorDependency `json:"prometheusOperator,omitempty"`
+
+	// KEDA defines the KEDA operator dependency.
+	// +optional
+	KEDA KEDADependency `json:"keda,omitempty"`
 }
@@ -20,11 +20,16 @@ limitations under the License.
 
 package common
 
-import ()
+import (
+	"k8s.io/api/core/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertManagerConfiguration) DeepCopyInto(out *CertManagerConfiguration) {
 	*out = *in
+	in.DeploymentSettings.DeepCopyInto(&out.DeploymentSettings)
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfiguration.
@@ -40,7 +45,8 @@ func (in *CertManagerConfiguration) DeepCopy() *CertManagerConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertManagerDependency) DeepCopyInto(out *CertManagerDependency) {
 	*out = *in
-	out.Configuration = in.Configuration
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerDependency.
@@ -53,13 +59,31 @@ func (in *CertManagerDependency) DeepCopy() *CertManagerDependency {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSettings) DeepCopyInto(out *ClusterSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSettings.
+func (in *ClusterSettings) DeepCopy() *ClusterSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Dependencies) DeepCopyInto(out *Dependencies) {
 	*out = *in
-	out.CertManager = in.CertManager
-	out.LWS = in.LWS
-	out.SailOperator = in.SailOperator
-	out.GatewayAPI = in.GatewayAPI
+	in.CertManager.DeepCopyInto(&out.CertManager)
+	in.LWS.DeepCopyInto(&out.LWS)
+	in.SailOperator.DeepCopyInto(&out.SailOperator)
+	in.GatewayAPI.DeepCopyInto(&out.GatewayAPI)
+	in.Kueue.DeepCopyInto(&out.Kueue)
+	in.PrometheusOperator.DeepCopyInto(&out.PrometheusOperator)
+	in.KEDA.DeepCopyInto(&out.KEDA)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Dependencies.
@@ -72,9 +96,64 @@ func (in *Dependencies) DeepCopy() *Dependencies {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DependencyStatus) DeepCopyInto(out *DependencyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DependencyStatus.
+func (in *DependencyStatus) DeepCopy() *DependencyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DependencyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSettings) DeepCopyInto(out *DeploymentSettings) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSettings.
+func (in *DeploymentSettings) DeepCopy() *DeploymentSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayAPIConfiguration) DeepCopyInto(out *GatewayAPIConfiguration) {
 	*out = *in
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPIConfiguration.
@@ -90,7 +169,8 @@ func (in *GatewayAPIConfiguration) DeepCopy() *GatewayAPIConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GatewayAPIDependency) DeepCopyInto(out *GatewayAPIDependency) {
 	*out = *in
-	out.Configuration = in.Configuration
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GatewayAPIDependency.
@@ -103,9 +183,94 @@ func (in *GatewayAPIDependency) DeepCopy() *GatewayAPIDependency {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageMirror) DeepCopyInto(out *ImageMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageMirror.
+func (in *ImageMirror) DeepCopy() *ImageMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KEDAConfiguration) DeepCopyInto(out *KEDAConfiguration) {
+	*out = *in
+	in.DeploymentSettings.DeepCopyInto(&out.DeploymentSettings)
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KEDAConfiguration.
+func (in *KEDAConfiguration) DeepCopy() *KEDAConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KEDAConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KEDADependency) DeepCopyInto(out *KEDADependency) {
+	*out = *in
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KEDADependency.
+func (in *KEDADependency) DeepCopy() *KEDADependency {
+	if in == nil {
+		return nil
+	}
+	out := new(KEDADependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KueueConfiguration) DeepCopyInto(out *KueueConfiguration) {
+	*out = *in
+	in.DeploymentSettings.DeepCopyInto(&out.DeploymentSettings)
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KueueConfiguration.
+func (in *KueueConfiguration) DeepCopy() *KueueConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(KueueConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KueueDependency) DeepCopyInto(out *KueueDependency) {
+	*out = *in
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KueueDependency.
+func (in *KueueDependency) DeepCopy() *KueueDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(KueueDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LWSConfiguration) DeepCopyInto(out *LWSConfiguration) {
 	*out = *in
+	in.DeploymentSettings.DeepCopyInto(&out.DeploymentSettings)
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LWSConfiguration.
@@ -121,7 +286,8 @@ func (in *LWSConfiguration) DeepCopy() *LWSConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LWSDependency) DeepCopyInto(out *LWSDependency) {
 	*out = *in
-	out.Configuration = in.Configuration
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LWSDependency.
@@ -134,9 +300,90 @@ func (in *LWSDependency) DeepCopy() *LWSDependency {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MeshConfig) DeepCopyInto(out *MeshConfig) {
+	*out = *in
+	if in.EnableTracing != nil {
+		in, out := &in.EnableTracing, &out.EnableTracing
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OutboundTrafficPolicy != nil {
+		in, out := &in.OutboundTrafficPolicy, &out.OutboundTrafficPolicy
+		*out = new(OutboundTrafficPolicy)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MeshConfig.
+func (in *MeshConfig) DeepCopy() *MeshConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(MeshConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OutboundTrafficPolicy) DeepCopyInto(out *OutboundTrafficPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OutboundTrafficPolicy.
+func (in *OutboundTrafficPolicy) DeepCopy() *OutboundTrafficPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(OutboundTrafficPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusOperatorConfiguration) DeepCopyInto(out *PrometheusOperatorConfiguration) {
+	*out = *in
+	in.DeploymentSettings.DeepCopyInto(&out.DeploymentSettings)
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOperatorConfiguration.
+func (in *PrometheusOperatorConfiguration) DeepCopy() *PrometheusOperatorConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusOperatorConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrometheusOperatorDependency) DeepCopyInto(out *PrometheusOperatorDependency) {
+	*out = *in
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrometheusOperatorDependency.
+func (in *PrometheusOperatorDependency) DeepCopy() *PrometheusOperatorDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(PrometheusOperatorDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SailOperatorConfiguration) DeepCopyInto(out *SailOperatorConfiguration) {
 	*out = *in
+	if in.MeshConfig != nil {
+		in, out := &in.MeshConfig, &out.MeshConfig
+		*out = new(MeshConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	in.DeploymentSettings.DeepCopyInto(&out.DeploymentSettings)
+	in.ValuesOverride.DeepCopyInto(&out.ValuesOverride)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SailOperatorConfiguration.
@@ -152,7 +399,8 @@ func (in *SailOperatorConfiguration) DeepCopy() *SailOperatorConfiguration {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SailOperatorDependency) DeepCopyInto(out *SailOperatorDependency) {
 	*out = *in
-	out.Configuration = in.Configuration
+	out.VersionPolicy = in.VersionPolicy
+	in.Configuration.DeepCopyInto(&out.Configuration)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SailOperatorDependency.
@@ -164,3 +412,38 @@ func (in *SailOperatorDependency) DeepCopy() *SailOperatorDependency {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValuesOverride) DeepCopyInto(out *ValuesOverride) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValuesOverride.
+func (in *ValuesOverride) DeepCopy() *ValuesOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ValuesOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VersionPolicy) DeepCopyInto(out *VersionPolicy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VersionPolicy.
+func (in *VersionPolicy) DeepCopy() *VersionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VersionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
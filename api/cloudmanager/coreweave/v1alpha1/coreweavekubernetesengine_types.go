@@ -35,11 +35,22 @@ type CoreWeaveKubernetesEngineSpec struct {
 	// Dependencies defines the dependency configurations for the CoreWeave Kubernetes Engine.
 	// +optional
 	Dependencies common.Dependencies `json:"dependencies,omitempty"`
+
+	// ImageMirrors defines the rules rewriting the images of the dependencies, for clusters pulling
+	// images from a mirror registry. When several sources match an image, the longest one is used.
+	// +listType=map
+	// +listMapKey=source
+	// +optional
+	ImageMirrors []common.ImageMirror `json:"imageMirrors,omitempty"`
 }
 
 // CoreWeaveKubernetesEngineStatus defines the observed state of CoreWeaveKubernetesEngine.
 type CoreWeaveKubernetesEngineStatus struct {
 	apicommon.Status `json:",inline"`
+
+	// Dependencies is the observed state of each dependency, by dependency name.
+	// +optional
+	Dependencies map[string]common.DependencyStatus `json:"dependencies,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -49,6 +60,13 @@ type CoreWeaveKubernetesEngineStatus struct {
 // +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Ready"
 // +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="Reason"
 // +kubebuilder:printcolumn:name="Deps Available",type=string,JSONPath=`.status.conditions[?(@.type=="DependenciesAvailable")].status`,description="DependenciesAvailable"
+// +kubebuilder:printcolumn:name="Gateway API",type=string,JSONPath=`.status.dependencies.gatewayAPI.version`,description="Installed Gateway API version",priority=1
+// +kubebuilder:printcolumn:name="Cert Manager",type=string,JSONPath=`.status.dependencies.certManager.version`,description="Installed Cert Manager version",priority=1
+// +kubebuilder:printcolumn:name="LWS",type=string,JSONPath=`.status.dependencies.lws.version`,description="Installed LWS version",priority=1
+// +kubebuilder:printcolumn:name="Sail Operator",type=string,JSONPath=`.status.dependencies.sailOperator.version`,description="Installed Sail Operator version",priority=1
+// +kubebuilder:printcolumn:name="Kueue",type=string,JSONPath=`.status.dependencies.kueue.version`,description="Installed Kueue version",priority=1
+// +kubebuilder:printcolumn:name="Prometheus Operator",type=string,JSONPath=`.status.dependencies.prometheusOperator.version`,description="Installed Prometheus Operator version",priority=1
+// +kubebuilder:printcolumn:name="KEDA",type=string,JSONPath=`.status.dependencies.keda.version`,description="Installed KEDA version",priority=1
 
 // CoreWeaveKubernetesEngine is the Schema for the CoreWeaveKubernetesEngines API.
 // It represents the configuration for a CoreWeave Kubernetes Engine cluster.
@@ -64,6 +82,18 @@ func (e *CoreWeaveKubernetesEngine) GetDependencies() common.Dependencies {
 	return e.Spec.Dependencies
 }
 
+func (e *CoreWeaveKubernetesEngine) GetImageMirrors() []common.ImageMirror {
+	return e.Spec.ImageMirrors
+}
+
+func (e *CoreWeaveKubernetesEngine) GetDependenciesStatus() map[string]common.DependencyStatus {
+	return e.Status.Dependencies
+}
+
+func (e *CoreWeaveKubernetesEngine) SetDependenciesStatus(status map[string]common.DependencyStatus) {
+	e.Status.Dependencies = status
+}
+
 // +kubebuilder:object:root=true
 
 // CoreWeaveKubernetesEngineList contains a list of CoreWeaveKubernetesEngine.
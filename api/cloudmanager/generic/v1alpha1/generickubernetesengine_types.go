@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	apicommon "github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	GenericKubernetesEngineKind         = "GenericKubernetesEngine"
+	GenericKubernetesEngineInstanceName = "default-generickubernetesengine"
+)
+
+// Check that the component implements common.KubernetesEngineInstance.
+var _ common.KubernetesEngineInstance = (*GenericKubernetesEngine)(nil)
+
+// GenericKubernetesEngineSpec defines the desired state of GenericKubernetesEngine.
+type GenericKubernetesEngineSpec struct {
+	// Dependencies defines the dependency configurations for the generic Kubernetes cluster.
+	// +optional
+	Dependencies common.Dependencies `json:"dependencies,omitempty"`
+
+	// Cluster defines the cluster-wide settings the dependencies are deployed with,
+	// since a generic cluster does not provide cloud-specific defaults.
+	// +optional
+	Cluster common.ClusterSettings `json:"cluster,omitempty"`
+
+	// ImageMirrors defines the rules rewriting the images of the dependencies, for clusters pulling
+	// images from a mirror registry. When several sources match an image, the longest one is used.
+	// +listType=map
+	// +listMapKey=source
+	// +optional
+	ImageMirrors []common.ImageMirror `json:"imageMirrors,omitempty"`
+}
+
+// GenericKubernetesEngineStatus defines the observed state of GenericKubernetesEngine.
+type GenericKubernetesEngineStatus struct {
+	apicommon.Status `json:",inline"`
+
+	// Dependencies is the observed state of each dependency, by dependency name.
+	// +optional
+	Dependencies map[string]common.DependencyStatus `json:"dependencies,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:validation:XValidation:rule="self.metadata.name == 'default-generickubernetesengine'",message="GenericKubernetesEngine name must be default-generickubernetesengine"
+// +kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].status`,description="Ready"
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Ready")].reason`,description="Reason"
+// +kubebuilder:printcolumn:name="Deps Available",type=string,JSONPath=`.status.conditions[?(@.type=="DependenciesAvailable")].status`,description="DependenciesAvailable"
+// +kubebuilder:printcolumn:name="Gateway API",type=string,JSONPath=`.status.dependencies.gatewayAPI.version`,description="Installed Gateway API version",priority=1
+// +kubebuilder:printcolumn:name="Cert Manager",type=string,JSONPath=`.status.dependencies.certManager.version`,description="Installed Cert Manager version",priority=1
+// +kubebuilder:printcolumn:name="LWS",type=string,JSONPath=`.status.dependencies.lws.version`,description="Installed LWS version",priority=1
+// +kubebuilder:printcolumn:name="Sail Operator",type=string,JSONPath=`.status.dependencies.sailOperator.version`,description="Installed Sail Operator version",priority=1
+// +kubebuilder:printcolumn:name="Kueue",type=string,JSONPath=`.status.dependencies.kueue.version`,description="Installed Kueue version",priority=1
+// +kubebuilder:printcolumn:name="Prometheus Operator",type=string,JSONPath=`.status.dependencies.prometheusOperator.version`,description="Installed Prometheus Operator version",priority=1
+// +kubebuilder:printcolumn:name="KEDA",type=string,JSONPath=`.status.dependencies.keda.version`,description="Installed KEDA version",priority=1
+
+// GenericKubernetesEngine is the Schema for the GenericKubernetesEngines API.
+// It represents the configuration for a conformant Kubernetes cluster without
+// cloud-specific integrations, such as kubeadm, k3s or on-premises clusters.
+type GenericKubernetesEngine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GenericKubernetesEngineSpec   `json:"spec,omitempty"`
+	Status GenericKubernetesEngineStatus `json:"status,omitempty"`
+}
+
+func (e *GenericKubernetesEngine) GetDependencies() common.Dependencies {
+	return e.Spec.Dependencies
+}
+
+func (e *GenericKubernetesEngine) GetImageMirrors() []common.ImageMirror {
+	return e.Spec.ImageMirrors
+}
+
+func (e *GenericKubernetesEngine) GetDependenciesStatus() map[string]common.DependencyStatus {
+	return e.Status.Dependencies
+}
+
+func (e *GenericKubernetesEngine) SetDependenciesStatus(status map[string]common.DependencyStatus) {
+	e.Status.Dependencies = status
+}
+
+// +kubebuilder:object:root=true
+
+// GenericKubernetesEngineList contains a list of GenericKubernetesEngine.
+type GenericKubernetesEngineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GenericKubernetesEngine `json:"items"`
+}
+
+func (s *GenericKubernetesEngine) GetConditions() []apicommon.Condition {
+	return s.Status.GetConditions()
+}
+
+func (s *GenericKubernetesEngine) GetStatus() *apicommon.Status {
+	return &s.Status.Status
+}
+
+func (c *GenericKubernetesEngine) SetConditions(conditions []apicommon.Condition) {
+	c.Status.SetConditions(conditions)
+}
+
+func init() {
+	SchemeBuilder.Register(&GenericKubernetesEngine{}, &GenericKubernetesEngineList{})
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+)
+
+const (
+	UpgradeGateKind = "UpgradeGate"
+)
+
+// UpgradeGateSeverity defines whether a gate blocks provisioning.
+// +kubebuilder:validation:Enum=Blocking;Warning
+type UpgradeGateSeverity string
+
+const (
+	// UpgradeGateSeverityBlocking gates stop provisioning until cleared.
+	UpgradeGateSeverityBlocking UpgradeGateSeverity = "Blocking"
+	// UpgradeGateSeverityWarning gates are reported but never stop provisioning.
+	UpgradeGateSeverityWarning UpgradeGateSeverity = "Warning"
+)
+
+var _ common.PlatformObject = (*UpgradeGate)(nil)
+
+// UpgradeGateSpec defines the desired state of UpgradeGate.
+// +kubebuilder:validation:XValidation:rule="!has(self.preflight) || !has(self.acknowledgment)",message="gates with a preflight check are cleared automatically and cannot be acknowledged"
+type UpgradeGateSpec struct {
+	// Versions is the semver range of operator versions the gate applies to,
+	// e.g. ">=3.0.0 <3.1.0".
+	// +kubebuilder:validation:MinLength=1
+	Versions string `json:"versions"`
+
+	// Severity defines whether the gate blocks provisioning until cleared.
+	// +kubebuilder:default=Blocking
+	// +optional
+	Severity UpgradeGateSeverity `json:"severity,omitempty"`
+
+	// Message describes to the admin what has to be verified before upgrading.
+	// +kubebuilder:validation:MinLength=1
+	Message string `json:"message"`
+
+	// Preflight is an automated check clearing the gate once it passes. Gates
+	// without a preflight check require a manual acknowledgment.
+	// +optional
+	Preflight *UpgradeGatePreflight `json:"preflight,omitempty"`
+
+	// Acknowledgment records the admin who cleared the gate. It is validated
+	// at admission time.
+	// +optional
+	Acknowledgment *UpgradeGateAcknowledgment `json:"acknowledgment,omitempty"`
+}
+
+// UpgradeGatePreflight is an automated check, either a CEL expression or a
+// check registered by the operator.
+// +kubebuilder:validation:XValidation:rule="has(self.cel) != has(self.check)",message="exactly one of cel or check must be set"
+type UpgradeGatePreflight struct {
+	// CEL is a CEL expression evaluated against a cluster resource.
+	// +optional
+	CEL *UpgradeGateCELCheck `json:"cel,omitempty"`
+
+	// Check is the name of a preflight check registered by the operator.
+	// +optional
+	Check string `json:"check,omitempty"`
+}
+
+// UpgradeGateCELCheck is a CEL expression evaluated against a cluster
+// resource, bound to the "object" variable. The variable is null if the
+// resource does not exist.
+type UpgradeGateCELCheck struct {
+	// Resource is the resource the expression is evaluated against.
+	Resource UpgradeGateResourceReference `json:"resource"`
+
+	// Expression is a CEL expression that must evaluate to true for the
+	// preflight check to pass, e.g. "object == null || object.spec.replicas > 1".
+	// +kubebuilder:validation:MinLength=1
+	Expression string `json:"expression"`
+}
+
+// UpgradeGateResourceReference identifies a cluster resource.
+type UpgradeGateResourceReference struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// UpgradeGateAcknowledgment records who acknowledged a gate and when.
+type UpgradeGateAcknowledgment struct {
+	// AcknowledgedBy is the user who acknowledged the gate, it must match
+	// the user performing the request.
+	// +kubebuilder:validation:MinLength=1
+	AcknowledgedBy string `json:"acknowledgedBy"`
+
+	// AcknowledgedAt is the time the gate was acknowledged.
+	AcknowledgedAt metav1.Time `json:"acknowledgedAt"`
+}
+
+// UpgradeGateStatus defines the observed state of UpgradeGate.
+type UpgradeGateStatus struct {
+	common.Status `json:",inline"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Versions",type=string,JSONPath=`.spec.versions`,description="Versions"
+// +kubebuilder:printcolumn:name="Severity",type=string,JSONPath=`.spec.severity`,description="Severity"
+// +kubebuilder:printcolumn:name="Cleared",type=string,JSONPath=`.status.conditions[?(@.type=="Cleared")].status`,description="Cleared"
+// +kubebuilder:printcolumn:name="Reason",type=string,JSONPath=`.status.conditions[?(@.type=="Cleared")].reason`,description="Reason"
+
+// UpgradeGate is the Schema for the upgradegates API. It defines a
+// precondition that has to be cleared, either by an automated preflight check
+// or by an admin acknowledgment, before the operator provisions a release.
+type UpgradeGate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UpgradeGateSpec   `json:"spec,omitempty"`
+	Status UpgradeGateStatus `json:"status,omitempty"`
+}
+
+func (g *UpgradeGate) GetStatus() *common.Status {
+	return &g.Status.Status
+}
+
+func (g *UpgradeGate) GetConditions() []common.Condition {
+	return g.Status.GetConditions()
+}
+
+func (g *UpgradeGate) SetConditions(conditions []common.Condition) {
+	g.Status.SetConditions(conditions)
+}
+
+// IsBlocking returns true if the gate stops provisioning until cleared.
+func (g *UpgradeGate) IsBlocking() bool {
+	return g.Spec.Severity != UpgradeGateSeverityWarning
+}
+
+//+kubebuilder:object:root=true
+
+// UpgradeGateList contains a list of UpgradeGate.
+type UpgradeGateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UpgradeGate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&UpgradeGate{}, &UpgradeGateList{})
+}
@@ -135,3 +135,170 @@ func (in *PlatformStatus) DeepCopy() *PlatformStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGate) DeepCopyInto(out *UpgradeGate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGate.
+func (in *UpgradeGate) DeepCopy() *UpgradeGate {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpgradeGate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGateAcknowledgment) DeepCopyInto(out *UpgradeGateAcknowledgment) {
+	*out = *in
+	in.AcknowledgedAt.DeepCopyInto(&out.AcknowledgedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGateAcknowledgment.
+func (in *UpgradeGateAcknowledgment) DeepCopy() *UpgradeGateAcknowledgment {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGateAcknowledgment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGateCELCheck) DeepCopyInto(out *UpgradeGateCELCheck) {
+	*out = *in
+	out.Resource = in.Resource
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGateCELCheck.
+func (in *UpgradeGateCELCheck) DeepCopy() *UpgradeGateCELCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGateCELCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGateList) DeepCopyInto(out *UpgradeGateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UpgradeGate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGateList.
+func (in *UpgradeGateList) DeepCopy() *UpgradeGateList {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UpgradeGateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGatePreflight) DeepCopyInto(out *UpgradeGatePreflight) {
+	*out = *in
+	if in.CEL != nil {
+		in, out := &in.CEL, &out.CEL
+		*out = new(UpgradeGateCELCheck)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGatePreflight.
+func (in *UpgradeGatePreflight) DeepCopy() *UpgradeGatePreflight {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGatePreflight)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGateResourceReference) DeepCopyInto(out *UpgradeGateResourceReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGateResourceReference.
+func (in *UpgradeGateResourceReference) DeepCopy() *UpgradeGateResourceReference {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGateResourceReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGateSpec) DeepCopyInto(out *UpgradeGateSpec) {
+	*out = *in
+	if in.Preflight != nil {
+		in, out := &in.Preflight, &out.Preflight
+		*out = new(UpgradeGatePreflight)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Acknowledgment != nil {
+		in, out := &in.Acknowledgment, &out.Acknowledgment
+		*out = new(UpgradeGateAcknowledgment)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGateSpec.
+func (in *UpgradeGateSpec) DeepCopy() *UpgradeGateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpgradeGateStatus) DeepCopyInto(out *UpgradeGateStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeGateStatus.
+func (in *UpgradeGateStatus) DeepCopy() *UpgradeGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UpgradeGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
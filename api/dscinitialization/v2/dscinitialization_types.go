@@ -43,6 +43,35 @@ type TrustedCABundleSpec struct {
 	CustomCABundle string `json:"customCABundle"`
 }
 
+// MaintenancePolicy defines the windows during which the operator is allowed
+// to apply disruptive changes. Outside of the windows such changes are deferred
+// and reported with the PendingMaintenance condition, while non-disruptive
+// changes are still applied immediately.
+type MaintenancePolicy struct {
+	// Windows is the list of maintenance windows. When empty, disruptive changes
+	// are applied immediately.
+	// +optional
+	// +listType=atomic
+	// +kubebuilder:validation:MaxItems=16
+	Windows []MaintenanceWindow `json:"windows,omitempty"`
+	// TimeZone is the IANA name of the time zone the window schedules are
+	// evaluated in, i.e. "Europe/Rome". Defaults to UTC.
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// MaintenanceWindow is a recurring period of time starting at the times
+// matched by a cron schedule.
+type MaintenanceWindow struct {
+	// Schedule is a standard five fields cron expression (minute, hour, day of
+	// month, month, day of week) matching the start of the window, i.e.
+	// "0 2 * * SAT" for every Saturday at 2 AM.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+	// Duration is how long the window stays open after each start, i.e. "4h".
+	Duration metav1.Duration `json:"duration"`
+}
+
 // DSCInitializationStatus defines the observed state of DSCInitialization.
 type DSCInitializationStatus struct {
 	// Phase describes the Phase of DSCInitializationStatus
@@ -42,4 +42,8 @@ type DSCInitializationSpec struct {
 	// This is not recommended to be used in production environment.
 	// +optional
 	DevFlags *DevFlags `json:"devFlags,omitempty"`
+	// Maintenance restricts when disruptive changes, i.e. changes rolling out the
+	// pods of a workload, are applied to the resources managed by the operator.
+	// +optional
+	Maintenance *MaintenancePolicy `json:"maintenance,omitempty"`
 }
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	corev1 "k8s.io/api/core/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -59,6 +60,14 @@ type Metrics struct {
 	// +kubebuilder:validation:XValidation:rule="!('otlp/tempo' in self)",message="exporter name 'otlp/tempo' is reserved and cannot be used"
 	// +kubebuilder:validation:XValidation:rule="size(self) <= 10",message="maximum 10 exporters allowed"
 	Exporters map[string]runtime.RawExtension `json:"exporters,omitempty"`
+	// RemoteWrite defines the endpoints the metrics are sent to with the Prometheus remote write
+	// protocol, e.g. Thanos Receive, Mimir or Cortex, for long-term storage.
+	// Secrets referenced by the endpoints are read from the monitoring namespace.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=10
+	RemoteWrite []RemoteWrite `json:"remoteWrite,omitempty"`
 }
 
 // MetricsStorage defines the storage configuration for the monitoring service
@@ -69,11 +78,140 @@ type MetricsStorage struct {
 	Retention string `json:"retention,omitempty"`
 }
 
+// RemoteWrite defines a Prometheus remote write endpoint.
+// +kubebuilder:validation:XValidation:rule="!(has(self.basicAuth) && has(self.bearerTokenSecret))",message="basicAuth and bearerTokenSecret are mutually exclusive"
+type RemoteWrite struct {
+	// Name of the endpoint, used as the remote_name label of the Prometheus remote storage metrics.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// URL of the endpoint, e.g. https://thanos-receive.example.com/api/v1/receive.
+	// +kubebuilder:validation:Pattern="^https?://"
+	URL string `json:"url"`
+	// RemoteTimeout is the timeout of the requests to the endpoint, e.g. "30s".
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	RemoteTimeout string `json:"remoteTimeout,omitempty"`
+	// Headers are custom HTTP headers sent with the requests, e.g. THANOS-TENANT or X-Scope-OrgID.
+	// +optional
+	// +kubebuilder:validation:MaxProperties=10
+	Headers map[string]string `json:"headers,omitempty"`
+	// BasicAuth authenticates to the endpoint with a username and a password.
+	// +optional
+	BasicAuth *RemoteWriteBasicAuth `json:"basicAuth,omitempty"`
+	// BearerTokenSecret selects the key of a Secret holding a bearer token to authenticate to the endpoint.
+	// +optional
+	BearerTokenSecret *corev1.SecretKeySelector `json:"bearerTokenSecret,omitempty"`
+	// TLS configures the TLS connection to the endpoint.
+	// +optional
+	TLS *RemoteWriteTLS `json:"tls,omitempty"`
+	// WriteRelabelConfigs relabels and filters the series before they are sent.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	WriteRelabelConfigs []WriteRelabelConfig `json:"writeRelabelConfigs,omitempty"`
+	// QueueConfig tunes the queue of the samples sent to the endpoint.
+	// +optional
+	QueueConfig *RemoteWriteQueueConfig `json:"queueConfig,omitempty"`
+}
+
+// RemoteWriteBasicAuth defines the basic authentication credentials of a remote write endpoint.
+type RemoteWriteBasicAuth struct {
+	// UsernameSecret selects the key of a Secret holding the username.
+	UsernameSecret corev1.SecretKeySelector `json:"usernameSecret"`
+	// PasswordSecret selects the key of a Secret holding the password.
+	PasswordSecret corev1.SecretKeySelector `json:"passwordSecret"`
+}
+
+// RemoteWriteTLS defines the TLS configuration of a remote write endpoint.
+// +kubebuilder:validation:XValidation:rule="has(self.certSecret) == has(self.keySecret)",message="certSecret and keySecret must be set together"
+type RemoteWriteTLS struct {
+	// CASecret selects the key of a Secret holding the CA certificate used to verify the endpoint.
+	// +optional
+	CASecret *corev1.SecretKeySelector `json:"caSecret,omitempty"`
+	// CertSecret selects the key of a Secret holding the client certificate.
+	// +optional
+	CertSecret *corev1.SecretKeySelector `json:"certSecret,omitempty"`
+	// KeySecret selects the key of a Secret holding the client private key.
+	// +optional
+	KeySecret *corev1.SecretKeySelector `json:"keySecret,omitempty"`
+	// ServerName is used to verify the hostname of the endpoint certificate.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify disables the verification of the endpoint certificate.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// WriteRelabelConfig defines a relabeling step applied to the series before they are sent.
+type WriteRelabelConfig struct {
+	// SourceLabels selects the labels whose values are concatenated and matched against the regex.
+	// +optional
+	SourceLabels []string `json:"sourceLabels,omitempty"`
+	// Separator between the concatenated source label values. Defaults to ";".
+	// +optional
+	Separator string `json:"separator,omitempty"`
+	// Regex matched against the concatenated source label values. Defaults to "(.*)".
+	// +optional
+	Regex string `json:"regex,omitempty"`
+	// TargetLabel is the label the result is written to, for the replace, hashmod,
+	// lowercase and uppercase actions.
+	// +optional
+	TargetLabel string `json:"targetLabel,omitempty"`
+	// Replacement is the value written to the target label. Defaults to "$1".
+	// +optional
+	Replacement *string `json:"replacement,omitempty"`
+	// Action to perform. Defaults to replace.
+	// +optional
+	// +kubebuilder:validation:Enum=replace;keep;drop;keepequal;dropequal;hashmod;labelmap;labeldrop;labelkeep;lowercase;uppercase
+	Action string `json:"action,omitempty"`
+}
+
+// RemoteWriteQueueConfig tunes the queue of the samples sent to a remote write endpoint.
+type RemoteWriteQueueConfig struct {
+	// Capacity is the number of samples buffered per shard.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	Capacity int32 `json:"capacity,omitempty"`
+	// MinShards is the minimum number of shards, i.e. of concurrent requests.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MinShards int32 `json:"minShards,omitempty"`
+	// MaxShards is the maximum number of shards, i.e. of concurrent requests.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxShards int32 `json:"maxShards,omitempty"`
+	// MaxSamplesPerSend is the maximum number of samples per request.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSamplesPerSend int32 `json:"maxSamplesPerSend,omitempty"`
+	// BatchSendDeadline is the maximum time a sample waits in a shard before being sent, e.g. "5s".
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	BatchSendDeadline string `json:"batchSendDeadline,omitempty"`
+	// MinBackoff is the initial retry delay, doubled on every retry, e.g. "30ms".
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	MinBackoff string `json:"minBackoff,omitempty"`
+	// MaxBackoff is the maximum retry delay, e.g. "5s".
+	// +optional
+	// +kubebuilder:validation:Pattern="^(0|(([0-9]+)h)?(([0-9]+)m)?(([0-9]+)s)?(([0-9]+)ms)?)$"
+	MaxBackoff string `json:"maxBackoff,omitempty"`
+	// RetryOnRateLimit retries the requests rejected with HTTP 429.
+	// +optional
+	RetryOnRateLimit bool `json:"retryOnRateLimit,omitempty"`
+}
+
 // MonitoringStatus defines the observed state of Monitoring
 type MonitoringStatus struct {
 	common.Status `json:",inline"`
 
 	URL string `json:"url,omitempty"`
+
+	// SLOs reports the compliance of the service level objectives.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	SLOs []SLOStatus `json:"slos,omitempty"`
 }
 
 // Traces enables and defines the configuration for traces collection
@@ -139,8 +277,298 @@ type TracesStorage struct {
 	Retention metav1.Duration `json:"retention,omitempty"`
 }
 
+// Logs enables and defines the configuration for the collection of the platform pod logs
+// +kubebuilder:validation:XValidation:rule="has(self.loki) || has(self.otlp) || (has(self.exporters) && size(self.exporters) > 0)",message="At least one of loki, otlp or exporters must be configured"
+type Logs struct {
+	// Namespaces lists additional namespaces whose pod logs are collected.
+	// The pod logs of the applications and operator namespaces are always collected.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:items:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:items:MaxLength=63
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Components restricts the collection to the pods of the given components, e.g. "dashboard".
+	// If not set, the logs of all the pods of the collected namespaces are collected.
+	// +optional
+	// +listType=set
+	// +kubebuilder:validation:MaxItems=50
+	Components []string `json:"components,omitempty"`
+	// Retention is the maximum age of the pod log files collected when the collector starts without
+	// read offsets (e.g., "24h"). Older files, such as the logs of pods that terminated before the
+	// collector was deployed, are skipped. It is not a retention of the collected logs: how long the
+	// logs are kept is configured in the logs backend.
+	// +optional
+	Retention metav1.Duration `json:"retention,omitempty"`
+	// Loki sends the logs to the OTLP endpoint of Loki
+	// +optional
+	Loki *LokiLogsExporter `json:"loki,omitempty"`
+	// OTLP sends the logs to an OTLP gRPC endpoint
+	// +optional
+	OTLP *OTLPLogsExporter `json:"otlp,omitempty"`
+	// Exporters defines custom log exporters for sending logs to external observability tools.
+	// Each key represents the exporter name, and the value contains the exporter configuration.
+	// The configuration follows the OpenTelemetry Collector exporter format.
+	// Reserved names 'otlphttp/loki' and 'otlp/logs' cannot be used as they conflict with built-in exporters.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="!('otlphttp/loki' in self)",message="exporter name 'otlphttp/loki' is reserved and cannot be used"
+	// +kubebuilder:validation:XValidation:rule="!('otlp/logs' in self)",message="exporter name 'otlp/logs' is reserved and cannot be used"
+	// +kubebuilder:validation:XValidation:rule="size(self) <= 10",message="maximum 10 exporters allowed"
+	Exporters map[string]runtime.RawExtension `json:"exporters,omitempty"`
+}
+
+// LokiLogsExporter defines the Loki instance the logs are sent to
+type LokiLogsExporter struct {
+	// URL of Loki, e.g. "https://loki-gateway.loki.svc:8080". The logs are sent to its /otlp endpoint.
+	// +kubebuilder:validation:Pattern="^https?://[^\\s]+$"
+	URL string `json:"url"`
+	// TenantID is sent in the X-Scope-OrgID header, for multi-tenant Loki instances
+	// +optional
+	TenantID string `json:"tenantID,omitempty"`
+}
+
+// OTLPLogsExporter defines the OTLP endpoint the logs are sent to
+type OTLPLogsExporter struct {
+	// Endpoint of the OTLP gRPC receiver, as host:port
+	// +kubebuilder:validation:Pattern="^[^\\s/]+:[0-9]+$"
+	Endpoint string `json:"endpoint"`
+	// Insecure disables TLS for the connection to the endpoint
+	// +optional
+	Insecure bool `json:"insecure,omitempty"`
+}
+
+// Alert severities used by the alerting rules shipped with the platform.
+const (
+	AlertSeverityCritical = "critical"
+	AlertSeverityWarning  = "warning"
+	AlertSeverityInfo     = "info"
+)
+
 // Alerting configuration for Prometheus
 type Alerting struct {
+	// Receivers defines where alert notifications are sent.
+	// Secrets referenced by the receivers are read from the monitoring namespace.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=20
+	// +kubebuilder:validation:XValidation:rule="self.all(r, r.name != 'null')",message="receiver name 'null' is reserved and cannot be used"
+	Receivers []AlertReceiver `json:"receivers,omitempty"`
+	// DefaultReceiver is the name of the receiver of the alerts no route matches.
+	// If not set, those alerts are not sent anywhere.
+	// +optional
+	DefaultReceiver string `json:"defaultReceiver,omitempty"`
+	// Routes defines which receiver an alert is sent to, by component and severity.
+	// Routes are evaluated in order and the first matching one wins, unless it sets continue.
+	// +optional
+	// +kubebuilder:validation:MaxItems=50
+	Routes []AlertRoute `json:"routes,omitempty"`
+	// InhibitRules mutes the notifications of alerts while a more severe alert is firing.
+	// +optional
+	// +kubebuilder:validation:MaxItems=20
+	InhibitRules []AlertInhibitRule `json:"inhibitRules,omitempty"`
+	// Rules overrides the alerting rules shipped with the platform, by alert name.
+	// +optional
+	// +listType=map
+	// +listMapKey=alert
+	// +kubebuilder:validation:MaxItems=100
+	Rules []AlertRuleOverride `json:"rules,omitempty"`
+	// SLOs defines service level objectives, for which recording rules and multi-window,
+	// multi-burn-rate alerts are generated. Their compliance is reported in the status.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=50
+	SLOs []ServiceLevelObjective `json:"slos,omitempty"`
+}
+
+// AlertReceiver defines a destination for alert notifications.
+// +kubebuilder:validation:XValidation:rule="[has(self.webhook), has(self.email), has(self.pagerDuty)].filter(x, x).size() == 1",message="exactly one of webhook, email or pagerDuty must be set"
+type AlertReceiver struct {
+	// Name of the receiver, referenced by routes and defaultReceiver.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Webhook sends notifications to a generic webhook endpoint.
+	// +optional
+	Webhook *WebhookReceiver `json:"webhook,omitempty"`
+	// Email sends notifications by email.
+	// +optional
+	Email *EmailReceiver `json:"email,omitempty"`
+	// PagerDuty sends notifications to the PagerDuty Events API v2, or a compatible endpoint.
+	// +optional
+	PagerDuty *PagerDutyReceiver `json:"pagerDuty,omitempty"`
+}
+
+// WebhookReceiver defines a generic webhook receiver.
+type WebhookReceiver struct {
+	// URLSecret selects the key of a Secret holding the webhook URL, which often embeds a token.
+	URLSecret corev1.SecretKeySelector `json:"urlSecret"`
+	// SendResolved notifies the receiver when the alerts are resolved.
+	// +optional
+	SendResolved bool `json:"sendResolved,omitempty"`
+}
+
+// EmailReceiver defines an email receiver.
+type EmailReceiver struct {
+	// To is the email address the notifications are sent to.
+	// +kubebuilder:validation:MinLength=1
+	To string `json:"to"`
+	// From is the sender address of the notifications.
+	// +kubebuilder:validation:MinLength=1
+	From string `json:"from"`
+	// Smarthost is the SMTP server used to send the notifications, as host:port.
+	// +kubebuilder:validation:Pattern="^[a-zA-Z0-9.-]+:[0-9]+$"
+	Smarthost string `json:"smarthost"`
+	// AuthUsername is the username used to authenticate to the SMTP server.
+	// +optional
+	AuthUsername string `json:"authUsername,omitempty"`
+	// AuthPasswordSecret selects the key of a Secret holding the SMTP password.
+	// +optional
+	AuthPasswordSecret *corev1.SecretKeySelector `json:"authPasswordSecret,omitempty"`
+	// SendResolved notifies the receiver when the alerts are resolved.
+	// +optional
+	SendResolved bool `json:"sendResolved,omitempty"`
+}
+
+// PagerDutyReceiver defines a PagerDuty receiver.
+type PagerDutyReceiver struct {
+	// RoutingKeySecret selects the key of a Secret holding the integration routing key.
+	RoutingKeySecret corev1.SecretKeySelector `json:"routingKeySecret"`
+	// URL of the events endpoint. Defaults to the PagerDuty Events API v2.
+	// +optional
+	// +kubebuilder:validation:Pattern="^https://"
+	URL string `json:"url,omitempty"`
+	// SendResolved notifies the receiver when the alerts are resolved.
+	// +optional
+	SendResolved bool `json:"sendResolved,omitempty"`
+}
+
+// AlertRoute sends the matching alerts to a receiver. An empty matcher matches all alerts.
+type AlertRoute struct {
+	// Receiver is the name of the receiver the matching alerts are sent to.
+	// +kubebuilder:validation:MinLength=1
+	Receiver string `json:"receiver"`
+	// Components restricts the route to the alerts of the given components, e.g. "kserve".
+	// The alerts of the operator itself belong to the "operator" component.
+	// +optional
+	Components []string `json:"components,omitempty"`
+	// Severities restricts the route to the alerts of the given severities.
+	// +optional
+	// +kubebuilder:validation:items:Enum=critical;warning;info
+	Severities []string `json:"severities,omitempty"`
+	// Continue evaluates the following routes after this one matched.
+	// +optional
+	Continue bool `json:"continue,omitempty"`
+}
+
+// AlertInhibitRule mutes the target alerts while a source alert is firing.
+type AlertInhibitRule struct {
+	// SourceSeverity is the severity of the alerts that mute the target ones.
+	// +kubebuilder:validation:Enum=critical;warning;info
+	SourceSeverity string `json:"sourceSeverity"`
+	// TargetSeverity is the severity of the muted alerts.
+	// +kubebuilder:validation:Enum=critical;warning;info
+	TargetSeverity string `json:"targetSeverity"`
+	// Equal lists the labels that must have the same value in the source and target alerts.
+	// Defaults to the alert name and namespace.
+	// +optional
+	Equal []string `json:"equal,omitempty"`
+}
+
+// AlertRuleOverride overrides an alerting rule shipped with the platform.
+type AlertRuleOverride struct {
+	// Alert is the name of the alert the override applies to.
+	// +kubebuilder:validation:MinLength=1
+	Alert string `json:"alert"`
+	// Enabled set to false removes the alerting rule.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// Threshold replaces the numeric threshold the rule expression ends with, e.g. the 3 of "... > 3".
+	// Rules whose expression does not end with a comparison to a number cannot be overridden.
+	// +optional
+	// +kubebuilder:validation:Pattern="^-?[0-9]+(\\.[0-9]+)?$"
+	Threshold *string `json:"threshold,omitempty"`
+}
+
+// ServiceLevelObjective defines the objective of a service level indicator over a compliance window.
+type ServiceLevelObjective struct {
+	// Name of the SLO, used as the slo label of the generated series and alerts.
+	// +kubebuilder:validation:Pattern="^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+	// Component the SLO belongs to, e.g. "dashboard", or "operator" for the operator itself.
+	// +kubebuilder:validation:MinLength=1
+	Component string `json:"component"`
+	// Objective is the percentage of good events to meet over the window, e.g. "99.9".
+	// +kubebuilder:validation:Pattern="^[0-9]{1,2}(\\.[0-9]+)?$"
+	Objective string `json:"objective"`
+	// Window is the compliance window of the SLO, in days.
+	// +optional
+	// +kubebuilder:default="30d"
+	// +kubebuilder:validation:Pattern="^[1-9][0-9]?d$"
+	Window string `json:"window,omitempty"`
+	// Indicator defines how the good and bad events are measured.
+	Indicator SLOIndicator `json:"indicator"`
+}
+
+// SLOIndicator defines a service level indicator.
+// +kubebuilder:validation:XValidation:rule="has(self.ratio) != has(self.latency)",message="exactly one of ratio or latency must be set"
+type SLOIndicator struct {
+	// Ratio measures the ratio of failed events to all events, from two counters.
+	// +optional
+	Ratio *SLORatioIndicator `json:"ratio,omitempty"`
+	// Latency measures the ratio of events slower than a threshold, from a histogram.
+	// +optional
+	Latency *SLOLatencyIndicator `json:"latency,omitempty"`
+}
+
+// SLORatioIndicator defines an indicator from a counter of failed events and a counter of all events.
+type SLORatioIndicator struct {
+	// Errors selects the counter of the failed events,
+	// e.g. haproxy_backend_http_responses_total{route="data-science-gateway",code=~"5.."}.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Errors string `json:"errors"`
+	// Total selects the counter of all the events,
+	// e.g. haproxy_backend_http_responses_total{route="data-science-gateway"}.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=1024
+	Total string `json:"total"`
+}
+
+// SLOLatencyIndicator defines an indicator from a latency histogram.
+type SLOLatencyIndicator struct {
+	// Histogram is the name of the histogram metric, without the _bucket suffix.
+	// +kubebuilder:validation:Pattern="^[a-zA-Z_:][a-zA-Z0-9_:]*$"
+	Histogram string `json:"histogram"`
+	// Selector restricts the histogram series with label matchers, e.g. namespace="kserve".
+	// +optional
+	// +kubebuilder:validation:MaxLength=1024
+	Selector string `json:"selector,omitempty"`
+	// Threshold is the latency upper bound of a good event, in the unit of the histogram.
+	// It must match the le label of one of the histogram buckets, e.g. "0.5".
+	// +kubebuilder:validation:Pattern="^[0-9]+(\\.[0-9]+)?$"
+	Threshold string `json:"threshold"`
+}
+
+// SLOStatus reports the compliance of a service level objective.
+type SLOStatus struct {
+	// Name of the SLO.
+	Name string `json:"name"`
+	// Component the SLO belongs to.
+	Component string `json:"component"`
+	// Compliance is the percentage of good events over the window.
+	// +optional
+	Compliance string `json:"compliance,omitempty"`
+	// ErrorBudgetRemaining is the percentage of the error budget left over the window.
+	// +optional
+	ErrorBudgetRemaining string `json:"errorBudgetRemaining,omitempty"`
+	// Compliant is True when the objective is met, and Unknown when it could not be evaluated.
+	Compliant metav1.ConditionStatus `json:"compliant"`
+	// Message explains why the compliance could not be evaluated.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -35,6 +35,8 @@ type MonitoringCommonSpec struct {
 	Traces *Traces `json:"traces,omitempty"`
 	// Alerting configuration for Prometheus
 	Alerting *Alerting `json:"alerting,omitempty"`
+	// Logs collection of the platform pod logs with OpenTelemetry
+	Logs *Logs `json:"logs,omitempty"`
 	// CollectorReplicas specifies the number of replicas in opentelemetry-collector. If not set, it defaults
 	// to 1 on single-node clusters and 2 on multi-node clusters.
 	CollectorReplicas int32 `json:"collectorReplicas,omitempty"`
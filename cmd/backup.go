@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/backup"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+type backupOptions struct {
+	kubeconfig        string
+	operatorNamespace string
+	file              string
+}
+
+type restoreOptions struct {
+	kubeconfig string
+	file       string
+	overwrite  bool
+	dryRun     bool
+}
+
+func newBackupCommand() *cobra.Command {
+	opts := backupOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Export the platform configuration to an archive",
+		Long: "Export the DataScienceCluster, the DSCInitialization, the platform services, the hardware profiles, " +
+			"the upgrade gates and the component resources, together with the user-editable ConfigMaps read by the operator, " +
+			"to a gzipped tar archive that can be restored with the restore command.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runBackup(cmd, opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to the in-cluster or KUBECONFIG configuration.")
+	f.StringVar(&opts.operatorNamespace, "operator-namespace", os.Getenv("OPERATOR_NAMESPACE"), "The namespace where the operator is deployed.")
+	f.StringVarP(&opts.file, "file", "f", "", "Path of the archive to write.")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runBackup(cmd *cobra.Command, opts backupOptions) error {
+	ctx := cmd.Context()
+
+	if opts.operatorNamespace == "" {
+		return errors.New("operator namespace is required (set via --operator-namespace flag or OPERATOR_NAMESPACE env var)")
+	}
+
+	cli, err := newCommandClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	release, err := cluster.GetDeployedRelease(ctx, cli)
+	if err != nil {
+		return fmt.Errorf("failed to get the deployed release: %w", err)
+	}
+
+	out, err := os.Create(opts.file)
+	if err != nil {
+		return fmt.Errorf("failed to create backup archive: %w", err)
+	}
+	defer out.Close()
+
+	backupOpts := backup.BackupOptions{OperatorNamespace: opts.operatorNamespace}
+	if release.Name != "" {
+		backupOpts.OperatorVersion = release.Version.String()
+	}
+
+	manifest, err := backup.Backup(ctx, cli, out, backupOpts)
+	if err != nil {
+		return err
+	}
+
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
+	for _, e := range manifest.Objects {
+		fmt.Fprintf(cmd.OutOrStdout(), "backed up %s\n", e)
+	}
+
+	return nil
+}
+
+func newRestoreCommand() *cobra.Command {
+	opts := restoreOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore the platform configuration from an archive",
+		Long: "Create the objects of an archive written by the backup command, converting the ones of legacy API versions " +
+			"to the current version. Existing objects are left untouched unless --overwrite is set.",
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runRestore(cmd, opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to the in-cluster or KUBECONFIG configuration.")
+	f.StringVarP(&opts.file, "file", "f", "", "Path of the archive to restore.")
+	f.BoolVar(&opts.overwrite, "overwrite", false, "Replace the existing objects with the ones of the archive.")
+	f.BoolVar(&opts.dryRun, "dry-run", false, "Only report what would be restored.")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runRestore(cmd *cobra.Command, opts restoreOptions) error {
+	ctx := cmd.Context()
+
+	in, err := os.Open(opts.file)
+	if err != nil {
+		return fmt.Errorf("failed to open backup archive: %w", err)
+	}
+	defer in.Close()
+
+	cli, err := newCommandClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	if opts.dryRun {
+		cli = client.NewDryRunClient(cli)
+	}
+
+	result, err := backup.Restore(ctx, cli, in, backup.RestoreOptions{Overwrite: opts.overwrite})
+
+	out := cmd.OutOrStdout()
+	if result != nil {
+		for _, ref := range result.Created {
+			fmt.Fprintf(out, "created %s\n", ref)
+		}
+		for _, ref := range result.Updated {
+			fmt.Fprintf(out, "updated %s\n", ref)
+		}
+		for _, ref := range result.Skipped {
+			fmt.Fprintf(out, "skipped %s (already exists)\n", ref)
+		}
+	}
+
+	return err
+}
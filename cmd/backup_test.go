@@ -0,0 +1,39 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCommandDispatchesBackupAndRestore(t *testing.T) {
+	t.Parallel()
+
+	root := newRootCommand()
+
+	for _, name := range []string{"backup", "restore"} {
+		cmd, _, err := root.Find([]string{name, "--file", "backup.tar.gz"})
+		require.NoError(t, err)
+		assert.Equal(t, name, cmd.Name())
+	}
+}
+
+func TestBackupAndRestoreRejectInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.tar.gz")
+
+	backup := newBackupCommand()
+	backup.SetArgs([]string{"--file", missing, "--operator-namespace", ""})
+	backup.SetOut(t.Output())
+	backup.SetErr(t.Output())
+	require.ErrorContains(t, backup.Execute(), "operator namespace is required")
+
+	restore := newRestoreCommand()
+	restore.SetArgs([]string{"--file", missing})
+	restore.SetOut(t.Output())
+	restore.SetErr(t.Output())
+	require.ErrorContains(t, restore.Execute(), "failed to open backup archive")
+}
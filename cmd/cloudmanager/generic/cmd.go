@@ -0,0 +1,28 @@
+package generic
+
+import (
+	"github.com/spf13/cobra"
+
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/generic/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/app"
+	genericctrl "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/generic"
+)
+
+// NewCmd returns the cobra command for the Generic cloud manager.
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "generic",
+		Short: "Run the Generic cloud manager",
+		Long:  "Start the cloud manager operator for conformant Kubernetes clusters without cloud-specific integrations.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return app.Run(cmd, app.Provider{
+				Name:             "generic",
+				AddToScheme:      ccmv1alpha1.AddToScheme,
+				LeaderElectionID: "generic.cloudmanager.opendatahub.io",
+				NewReconciler:    genericctrl.NewReconciler,
+			})
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,28 @@
+package gke
+
+import (
+	"github.com/spf13/cobra"
+
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/gke/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/app"
+	gkectrl "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/gke"
+)
+
+// NewCmd returns the cobra command for the GKE cloud manager.
+func NewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gke",
+		Short: "Run the GKE cloud manager",
+		Long:  "Start the cloud manager operator for Google Kubernetes Engine clusters.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return app.Run(cmd, app.Provider{
+				Name:             "gke",
+				AddToScheme:      ccmv1alpha1.AddToScheme,
+				LeaderElectionID: "gke.cloudmanager.opendatahub.io",
+				NewReconciler:    gkectrl.NewReconciler,
+			})
+		},
+	}
+
+	return cmd
+}
@@ -5,11 +5,15 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/aws"
 	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/azure"
 	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/coreweave"
+	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/generic"
+	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/cloudmanager/gke"
 )
 
 func main() {
 	app.AddCommand(azure.NewCmd())
 	app.AddCommand(coreweave.NewCmd())
 	app.AddCommand(aws.NewCmd())
+	app.AddCommand(gke.NewCmd())
+	app.AddCommand(generic.NewCmd())
 	app.Execute()
 }
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/cmd/component-codegen/cmd/generator"
+)
+
+var providerData generator.ProviderData
+
+var cloudManagerProviderCmd = &cobra.Command{
+	Use:   "cloudmanager-provider [provider-name]",
+	Short: "Generates boilerplate folders/files for a new cloud manager provider",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		logger := newLogger()
+		providerData.Provider = args[0]
+		if err := generator.GenerateCloudManagerProvider(logger, providerData); err != nil {
+			logger.Errorf("Failed to generate cloud manager provider: %v", err)
+		}
+	},
+}
+
+func init() { //nolint:gochecknoinits
+	cloudManagerProviderCmd.Flags().StringVar(&providerData.Kind, "kind-prefix", "",
+		"prefix of the KubernetesEngine kind, e.g. GKE for GKEKubernetesEngine (defaults to the capitalized provider name)")
+	cloudManagerProviderCmd.Flags().StringVar(&providerData.Description, "description", "",
+		"human readable name of the managed clusters, e.g. Google Kubernetes Engine")
+	rootCmd.AddCommand(cloudManagerProviderCmd)
+}
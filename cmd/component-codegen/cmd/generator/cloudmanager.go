@@ -0,0 +1,261 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	cloudManagerTemplatesDir = templatesDir + "/cloudmanager"
+	cloudManagerMainPath     = "cmd/cloudmanager/main.go"
+	gvkFilePath              = "pkg/cluster/gvk/gvk.go"
+	testSchemePath           = "pkg/utils/test/scheme/scheme.go"
+	e2eProvidersPath         = "tests/e2e/cloudmanager/provider_test.go"
+	e2eWorkflowPath          = ".github/workflows/test-cloudmanager-e2e.yaml"
+	makefilePath             = "Makefile"
+
+	// providerPlaceholder is the path element of the templates replaced with the provider name.
+	providerPlaceholder = "provider"
+	modulePath          = "github.com/opendatahub-io/opendatahub-operator/v2"
+)
+
+var providerNameRegexp = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// ProviderData holds the values the cloud manager provider templates are rendered with.
+type ProviderData struct {
+	// Provider is the lowercase provider name, used for packages, paths and the cloud manager subcommand.
+	Provider string
+	// Kind is the prefix of the KubernetesEngine kind, such as GKE for GKEKubernetesEngine.
+	Kind string
+	// Description is the human readable name of the clusters managed by the provider.
+	Description string
+}
+
+// GenerateCloudManagerProvider scaffolds a new cloud manager provider reconciled by the
+// shared KubernetesEngine reconciler, and registers it in the cloud manager binary,
+// the build and the tests.
+func GenerateCloudManagerProvider(logger *logrus.Logger, data ProviderData) error {
+	if !providerNameRegexp.MatchString(data.Provider) {
+		return fmt.Errorf("invalid provider name %q: must match %s", data.Provider, providerNameRegexp)
+	}
+
+	if data.Kind == "" {
+		data.Kind = strings.ToUpper(data.Provider[:1]) + data.Provider[1:]
+	}
+	if data.Description == "" {
+		data.Description = data.Kind + " Kubernetes"
+	}
+
+	err := filepath.WalkDir(cloudManagerTemplatesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(cloudManagerTemplatesDir, path)
+		if err != nil {
+			return err
+		}
+
+		return generateProviderFile(logger, data, path, providerOutputPath(rel, data.Provider))
+	})
+	if err != nil {
+		return err
+	}
+
+	return registerCloudManagerProvider(logger, data)
+}
+
+// providerOutputPath maps a template path to the path of the generated file, replacing the
+// provider placeholder in directory names and file name prefixes.
+func providerOutputPath(rel string, provider string) string {
+	parts := strings.Split(filepath.ToSlash(strings.TrimSuffix(rel, ".tmpl")), "/")
+	for i, p := range parts {
+		if p == providerPlaceholder || strings.HasPrefix(p, providerPlaceholder+"kubernetesengine") {
+			parts[i] = provider + strings.TrimPrefix(p, providerPlaceholder)
+		}
+	}
+
+	return filepath.Join(parts...)
+}
+
+func generateProviderFile(logger *logrus.Logger, data ProviderData, templatePath string, op string) error {
+	if fileExists(op) {
+		logger.Warnf("File already exists: %s", op)
+		return nil
+	}
+
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return fmt.Errorf("error reading template: %w", err)
+	}
+
+	// The templates contain Go composite literals, hence the custom delimiters.
+	tmpl, err := template.New(filepath.Base(templatePath)).Delims("[[", "]]").Parse(string(content))
+	if err != nil {
+		return fmt.Errorf("error parsing template %s: %w", templatePath, err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return fmt.Errorf("error executing template %s: %w", templatePath, err)
+	}
+
+	generated := buf.Bytes()
+	if strings.HasSuffix(op, ".go") {
+		generated, err = format.Source(generated)
+		if err != nil {
+			return fmt.Errorf("error formatting %s: %w", op, err)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(op), os.ModePerm); err != nil {
+		return fmt.Errorf("error creating directory: %w", err)
+	}
+	if err := os.WriteFile(op, generated, FilePerm); err != nil {
+		return fmt.Errorf("error writing file: %w", err)
+	}
+
+	logger.Infof("Generated %s", op)
+	return nil
+}
+
+// fileEdit inserts text in a file after the last match of a regular expression.
+type fileEdit struct {
+	after *regexp.Regexp
+	text  string
+}
+
+func registerCloudManagerProvider(logger *logrus.Logger, data ProviderData) error {
+	p := data.Provider
+	kind := data.Kind + "KubernetesEngine"
+	alias := "ccm" + data.Kind + "V1alpha1"
+	apiImport := fmt.Sprintf("%s/api/cloudmanager/%s/v1alpha1", modulePath, p)
+
+	files := []struct {
+		path  string
+		guard string
+		edits []fileEdit
+	}{
+		{
+			path:  cloudManagerMainPath,
+			guard: fmt.Sprintf("%s.NewCmd()", p),
+			edits: []fileEdit{
+				{regexp.MustCompile(`(?m)^\t"` + modulePath + `/cmd/cloudmanager/app"\n`), fmt.Sprintf("\t%q\n", modulePath+"/cmd/cloudmanager/"+p)},
+				{regexp.MustCompile(`(?m)^\tapp\.AddCommand\(.*\)\n`), fmt.Sprintf("\tapp.AddCommand(%s.NewCmd())\n", p)},
+			},
+		},
+		{
+			path:  gvkFilePath,
+			guard: kind + " = schema.GroupVersionKind",
+			edits: []fileEdit{
+				{regexp.MustCompile(`(?m)^\t\w+KubernetesEngine = schema\.GroupVersionKind\{\n(?:\t\t.*\n)*\t\}\n`), fmt.Sprintf(
+					"\n\t%s = schema.GroupVersionKind{\n\t\tGroup:   \"infrastructure.opendatahub.io\",\n\t\tVersion: \"v1alpha1\",\n\t\tKind:    %q,\n\t}\n", kind, kind)},
+			},
+		},
+		{
+			path:  testSchemePath,
+			guard: apiImport,
+			edits: []fileEdit{
+				{regexp.MustCompile(`(?m)^\tccm\w+ "` + modulePath + `/api/cloudmanager/.*"\n`), fmt.Sprintf("\t%s %q\n", alias, apiImport)},
+				{regexp.MustCompile(`(?m)^\t\tccm\w+\.AddToScheme,\n`), fmt.Sprintf("\t\t%s.AddToScheme,\n", alias)},
+			},
+		},
+		{
+			path:  e2eProvidersPath,
+			guard: apiImport,
+			edits: []fileEdit{
+				{regexp.MustCompile(`(?m)^\t\w+ "` + modulePath + `/api/cloudmanager/.*"\n`), fmt.Sprintf("\t%sv1alpha1 %q\n", p, apiImport)},
+				{regexp.MustCompile(`(?m)^\t\tInstanceName: +\w+\.\w+KubernetesEngineInstanceName,\n\t\},\n`), fmt.Sprintf(
+					"\t%q: {\n\t\tName:         %q,\n\t\tGVK:          gvk.%s,\n\t\tInstanceName: %sv1alpha1.%sInstanceName,\n\t},\n", p, p, kind, p, kind)},
+			},
+		},
+	}
+
+	for _, f := range files {
+		if err := editFile(logger, f.path, f.guard, f.edits); err != nil {
+			return err
+		}
+	}
+
+	if err := appendToList(logger, makefilePath, regexp.MustCompile(`(?m)^(CCM_PROVIDERS :=.*?)$`), " "+p, p); err != nil {
+		return err
+	}
+
+	return appendToList(logger, e2eWorkflowPath, regexp.MustCompile(`(?m)^(\s+provider: \[.*?)\]$`), ", "+p+"]", p)
+}
+
+// editFile applies the edits to the file, unless it already contains guard.
+func editFile(logger *logrus.Logger, path string, guard string, edits []fileEdit) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	if bytes.Contains(content, []byte(guard)) {
+		logger.Warnf("Provider already registered in %s", path)
+		return nil
+	}
+
+	for _, e := range edits {
+		matches := e.after.FindAllIndex(content, -1)
+		if len(matches) == 0 {
+			return fmt.Errorf("error updating %s: no match for %s", path, e.after)
+		}
+
+		end := matches[len(matches)-1][1]
+		content = append(content[:end:end], append([]byte(e.text), content[end:]...)...)
+	}
+
+	content, err = format.Source(content)
+	if err != nil {
+		return fmt.Errorf("error formatting %s: %w", path, err)
+	}
+
+	if err := os.WriteFile(path, content, FilePerm); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	logger.Infof("Updated %s", path)
+	return nil
+}
+
+// appendToList appends text to the list matched by the first group of re, unless the
+// list already contains item.
+func appendToList(logger *logrus.Logger, path string, re *regexp.Regexp, text string, item string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", path, err)
+	}
+
+	m := re.FindSubmatchIndex(content)
+	if m == nil {
+		return fmt.Errorf("error updating %s: no match for %s", path, re)
+	}
+
+	list := content[m[2]:m[3]]
+	if regexp.MustCompile(`[\s,\[]` + regexp.QuoteMeta(item) + `\b`).Match(list) {
+		logger.Warnf("Provider already registered in %s", path)
+		return nil
+	}
+
+	updated := make([]byte, 0, len(content)+len(text))
+	updated = append(updated, content[:m[3]]...)
+	updated = append(updated, text...)
+	updated = append(updated, content[m[1]:]...)
+
+	if err := os.WriteFile(path, updated, FilePerm); err != nil {
+		return fmt.Errorf("error writing %s: %w", path, err)
+	}
+
+	logger.Infof("Updated %s", path)
+	return nil
+}
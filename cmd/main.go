@@ -42,6 +42,7 @@ import (
 	ofapiv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 	ofapiv2 "github.com/operator-framework/api/pkg/operators/v2"
 	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	appsv1 "k8s.io/api/apps/v1"
@@ -110,6 +111,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/bootstrap"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/dag"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/logger"
@@ -289,7 +291,36 @@ func registerModules() {
 	}
 }
 
-func main() { //nolint:funlen,maintidx,gocyclo
+func main() {
+	if err := newRootCommand().Execute(); err != nil {
+		if errors.Is(err, errUpgradeBlocked) {
+			os.Exit(exitUpgradeBlocked)
+		}
+		os.Exit(1)
+	}
+}
+
+func newRootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "manager",
+		Short: "Open Data Hub operator",
+		// The manager flags are registered on the global flag set and parsed
+		// by operatorconfig.LoadConfig, so cobra must pass them through as is.
+		DisableFlagParsing: true,
+		Args:               cobra.ArbitraryArgs,
+		Run: func(*cobra.Command, []string) {
+			runManager()
+		},
+	}
+
+	root.AddCommand(newUpgradePreflightCommand())
+	root.AddCommand(newBackupCommand())
+	root.AddCommand(newRestoreCommand())
+
+	return root
+}
+
+func runManager() { //nolint:funlen,maintidx,gocyclo
 	// Setup Viper
 	viper.SetEnvPrefix("ODH_MANAGER")
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
@@ -390,6 +421,11 @@ func main() { //nolint:funlen,maintidx,gocyclo
 		os.Exit(1)
 	}
 
+	if _, _, err := deploy.ParseConflictPolicies(flags.GetDeployConflictPolicies()); err != nil {
+		setupLog.Error(err, "invalid DEPLOY_CONFLICT_POLICIES configuration")
+		os.Exit(1)
+	}
+
 	if err := initServices(ctx, platform); err != nil {
 		setupLog.Error(err, "unable to init services")
 		os.Exit(1)
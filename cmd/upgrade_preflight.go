@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/blang/semver/v4"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+)
+
+const (
+	preflightOutputTable = "table"
+	preflightOutputJSON  = "json"
+
+	// exitUpgradeBlocked is the exit code of upgrade-preflight when the report
+	// has blocking findings, so that CI can tell them apart from a failed run.
+	exitUpgradeBlocked = 2
+)
+
+var errUpgradeBlocked = errors.New("upgrade blocked by preflight findings")
+
+type upgradePreflightOptions struct {
+	kubeconfig        string
+	operatorNamespace string
+	targetVersion     string
+	output            string
+	chartManifests    []string
+	manifestsPath     string
+}
+
+func newUpgradePreflightCommand() *cobra.Command {
+	opts := upgradePreflightOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "upgrade-preflight",
+		Short: "Report whether an upgrade to the target version would be blocked",
+		Long: "Evaluate, without changing anything in the cluster, the upgrade gates, the pending migrations, " +
+			"the deprecated resources and the conversion webhooks an upgrade to the target version depends on. " +
+			fmt.Sprintf("Exits with %d if the upgrade would be blocked.", exitUpgradeBlocked),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runUpgradePreflight(cmd, opts)
+		},
+	}
+
+	f := cmd.Flags()
+	f.StringVar(&opts.kubeconfig, "kubeconfig", "", "Path to the kubeconfig file, defaults to the in-cluster or KUBECONFIG configuration.")
+	f.StringVar(&opts.operatorNamespace, "operator-namespace", os.Getenv("OPERATOR_NAMESPACE"), "The namespace where the operator is deployed.")
+	f.StringVar(&opts.targetVersion, "target-version", "", "The release the cluster would be upgraded to.")
+	f.StringVarP(&opts.output, "output", "o", preflightOutputTable, "Output format, one of 'table' or 'json'.")
+	f.StringSliceVar(&opts.chartManifests, "chart-manifests", nil, "Rendered module chart manifests of the target release to extract upgrade gates from.")
+	f.StringVar(&opts.manifestsPath, "default-manifests-path", "", "The base directory of the manifests, used by the migrations' preconditions.")
+
+	_ = cmd.MarkFlagRequired("target-version")
+
+	return cmd
+}
+
+func runUpgradePreflight(cmd *cobra.Command, opts upgradePreflightOptions) error {
+	ctx := cmd.Context()
+
+	if opts.output != preflightOutputTable && opts.output != preflightOutputJSON {
+		return fmt.Errorf("invalid output format %q", opts.output)
+	}
+
+	if opts.operatorNamespace == "" {
+		return errors.New("operator namespace is required (set via --operator-namespace flag or OPERATOR_NAMESPACE env var)")
+	}
+
+	target, err := semver.ParseTolerant(opts.targetVersion)
+	if err != nil {
+		return fmt.Errorf("invalid target version %q: %w", opts.targetVersion, err)
+	}
+
+	manifests := make([][]byte, 0, len(opts.chartManifests))
+	for _, path := range opts.chartManifests {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read chart manifests: %w", err)
+		}
+		manifests = append(manifests, data)
+	}
+
+	chartGates, err := upgrade.ChartGatesFromManifests(ctx, manifests...)
+	if err != nil {
+		return err
+	}
+
+	cli, err := newCommandClient(opts.kubeconfig)
+	if err != nil {
+		return err
+	}
+
+	// the preflight only reads, the dry-run client makes sure of it.
+	report, err := upgrade.Preflight(ctx, client.NewDryRunClient(cli), upgrade.PreflightOptions{
+		OperatorNamespace: opts.operatorNamespace,
+		TargetVersion:     target,
+		ChartGates:        chartGates,
+		ManifestsBasePath: opts.manifestsPath,
+	})
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if opts.output == preflightOutputJSON {
+		err = report.WriteJSON(out)
+	} else {
+		err = report.WriteTable(out)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if report.Blocked() {
+		return errUpgradeBlocked
+	}
+
+	return nil
+}
+
+// newCommandClient returns a client for the subcommands, configured from the
+// given kubeconfig or, if empty, from the in-cluster or KUBECONFIG
+// configuration.
+func newCommandClient(kubeconfig string) (client.Client, error) {
+	var cfg *rest.Config
+	var err error
+
+	if kubeconfig != "" {
+		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		cfg, err = ctrl.GetConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	cli, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return cli, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRootCommandDispatchesUpgradePreflight(t *testing.T) {
+	t.Parallel()
+
+	root := newRootCommand()
+
+	cmd, _, err := root.Find([]string{"upgrade-preflight", "--target-version", "3.1.0"})
+	require.NoError(t, err)
+	assert.Equal(t, "upgrade-preflight", cmd.Name())
+
+	// manager flags are left to the global flag set
+	cmd, _, err = root.Find([]string{"--leader-elect", "--metrics-bind-address", ":8080"})
+	require.NoError(t, err)
+	assert.Equal(t, root, cmd)
+}
+
+func TestUpgradePreflightRejectsInvalidOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		args []string
+		err  string
+	}{
+		"output":    {[]string{"--target-version", "3.1.0", "--operator-namespace", "ns", "-o", "yaml"}, `invalid output format "yaml"`},
+		"namespace": {[]string{"--target-version", "3.1.0", "--operator-namespace", ""}, "operator namespace is required"},
+		"version":   {[]string{"--target-version", "next", "--operator-namespace", "ns"}, `invalid target version "next"`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cmd := newUpgradePreflightCommand()
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(t.Output())
+			cmd.SetErr(t.Output())
+
+			require.ErrorContains(t, cmd.Execute(), tt.err)
+		})
+	}
+}
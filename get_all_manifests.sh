@@ -61,6 +61,7 @@ declare -A RHOAI_COMPONENT_MANIFESTS=(
 # in the same format as manifests: "repo-org:repo-name:ref-name:source-folder"
 # key is the target folder under charts/
 # CCM_CHARTS: charts deployed by the CloudManager controller (dependencies)
+#   additional versions of a dependency chart are bundled side by side under a <chart>@<version> key
 # COMPONENT_CHARTS: charts deployed by individual component controllers
 
 # ODH CloudManager Charts
@@ -69,6 +70,9 @@ declare -A ODH_CCM_CHARTS=(
     ["lws-operator"]="opendatahub-io:odh-gitops:main@16827cc08236068d7ed1c6ff450eb28accda3ce1:charts/dependencies/lws-operator"
     ["sail-operator"]="opendatahub-io:odh-gitops:main@16827cc08236068d7ed1c6ff450eb28accda3ce1:charts/dependencies/sail-operator"
     ["gateway-api"]="opendatahub-io:odh-gitops:main@16827cc08236068d7ed1c6ff450eb28accda3ce1:charts/dependencies/gateway-api"
+    ["kueue-operator"]="opendatahub-io:odh-gitops:main@16827cc08236068d7ed1c6ff450eb28accda3ce1:charts/dependencies/kueue-operator"
+    ["prometheus-operator"]="opendatahub-io:odh-gitops:main@16827cc08236068d7ed1c6ff450eb28accda3ce1:charts/dependencies/prometheus-operator"
+    ["keda"]="opendatahub-io:odh-gitops:main@16827cc08236068d7ed1c6ff450eb28accda3ce1:charts/dependencies/keda"
 )
 
 # ODH Component Charts
@@ -82,6 +86,9 @@ declare -A RHOAI_CCM_CHARTS=(
     ["lws-operator"]="red-hat-data-services:odh-gitops:rhoai-3.5@93fdfa3008901903026849c9d33cba78ee86db82:charts/dependencies/lws-operator"
     ["sail-operator"]="red-hat-data-services:odh-gitops:rhoai-3.5@93fdfa3008901903026849c9d33cba78ee86db82:charts/dependencies/sail-operator"
     ["gateway-api"]="red-hat-data-services:odh-gitops:rhoai-3.5@93fdfa3008901903026849c9d33cba78ee86db82:charts/dependencies/gateway-api"
+    ["kueue-operator"]="red-hat-data-services:odh-gitops:rhoai-3.5@93fdfa3008901903026849c9d33cba78ee86db82:charts/dependencies/kueue-operator"
+    ["prometheus-operator"]="red-hat-data-services:odh-gitops:rhoai-3.5@93fdfa3008901903026849c9d33cba78ee86db82:charts/dependencies/prometheus-operator"
+    ["keda"]="red-hat-data-services:odh-gitops:rhoai-3.5@93fdfa3008901903026849c9d33cba78ee86db82:charts/dependencies/keda"
 )
 
 # RHOAI Component Charts
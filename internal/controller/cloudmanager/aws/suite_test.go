@@ -3,12 +3,12 @@ package aws_test
 import (
 	"testing"
 
-	ccmtest "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/cloudmanager"
-	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/testf"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/aws"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine/enginetest"
 )
 
-var tc *testf.TestContext
+var suite = enginetest.New(aws.KubernetesEngine())
 
 func TestMain(m *testing.M) {
-	ccmtest.RunTestMain(m, &tc, awsCfg)
+	suite.RunTestMain(m)
 }
@@ -3,12 +3,12 @@ package azure_test
 import (
 	"testing"
 
-	ccmtest "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/cloudmanager"
-	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/testf"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/azure"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine/enginetest"
 )
 
-var tc *testf.TestContext
+var suite = enginetest.New(azure.KubernetesEngine())
 
 func TestMain(m *testing.M) {
-	ccmtest.RunTestMain(m, &tc, azureCfg)
+	suite.RunTestMain(m)
 }
@@ -2,8 +2,12 @@ package common
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"path/filepath"
+	"slices"
 
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
 	helm "github.com/k8s-manifest-kit/renderer-helm/pkg"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -12,6 +16,7 @@ import (
 
 	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
 
@@ -21,61 +26,89 @@ const (
 	chartManaged  chartState = iota // render + deploy all resources
 	chartCleaning                   // Phase 1: render + deploy operator resources, filter CR
 	chartExcluded                   // Phase 2: skip entirely
+	chartExternal                   // Auto: existing installation adopted, skip entirely and monitor it
 )
 
+// apiState returns the chart state reported in the status of the dependency.
+func (s chartState) apiState() ccmcommon.ChartState {
+	switch s {
+	case chartCleaning:
+		return ccmcommon.ChartStateCleaning
+	case chartExcluded:
+		return ccmcommon.ChartStateExcluded
+	case chartExternal:
+		return ccmcommon.ChartStateExternal
+	default:
+		return ccmcommon.ChartStateManaged
+	}
+}
+
 // chartDef describes a single Helm chart together with its monitoring
-// metadata and a function that computes its state based on management
-// policy and cluster state.
+// metadata, functions returning the management policy, the version policy
+// and the values override of its dependency, and how an existing
+// installation of the dependency is detected by the Auto policy.
 type chartDef struct {
-	stateFn    func(ctx context.Context, cli client.Client, d ccmcommon.Dependencies) (chartState, error)
+	policyFn   func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy
+	versionFn  func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy
+	valuesFn   func(d ccmcommon.Dependencies) (map[string]any, error)
 	chart      types.HelmChartInfo
 	monitor    monitorConfig
 	operatorCR *types.OperatorCR
+	detect     detectConfig
+	// skipTwoPhaseCleanup removes the chart of an Unmanaged dependency right
+	// away, instead of waiting for the operator CR to be deleted first.
+	skipTwoPhaseCleanup bool
 }
 
 // monitorConfig holds per-dependency monitoring metadata embedded in chartDef.
 // Operator CR info (GVK, name, namespace) comes from chart.OperatorCR.
 type monitorConfig struct {
+	// Name is the name of the dependency in the Dependencies API.
+	Name           string
 	ConditionType  string
 	HasDeployments bool
 	Namespace      string
 }
 
-// makeStateFn returns a stateFn that checks the management policy and,
-// for Unmanaged dependencies with an OperatorCR, keeps the chart while
+// chartStateFor computes the state of a chart from the management policy
+// and, for Unmanaged dependencies with an OperatorCR, keeps the chart while
 // the CR exists on cluster (Phase 1).
-func makeStateFn(
-	policyFn func(ccmcommon.Dependencies) ccmcommon.ManagementPolicy,
-	operatorCR *types.OperatorCR,
-) func(ctx context.Context, cli client.Client, d ccmcommon.Dependencies) (chartState, error) {
-	return func(ctx context.Context, cli client.Client, d ccmcommon.Dependencies) (chartState, error) {
-		if policyFn(d) != ccmcommon.Unmanaged {
-			return chartManaged, nil
-		}
+func chartStateFor(ctx context.Context, cli client.Client, def chartDef, policy ccmcommon.ManagementPolicy) (chartState, error) {
+	if policy != ccmcommon.Unmanaged {
+		return chartManaged, nil
+	}
 
-		if operatorCR != nil {
-			exists, err := operatorCRExists(ctx, cli, operatorCR)
-			if err != nil {
-				// Stay managed on transient errors — safe default that avoids premature Phase 2 cleanup.
-				return chartManaged, err
-			}
-			if exists {
-				return chartCleaning, nil
-			}
+	if def.operatorCR != nil && !def.skipTwoPhaseCleanup {
+		exists, err := operatorCRExists(ctx, cli, def.operatorCR)
+		if err != nil {
+			// Stay managed on transient errors — safe default that avoids premature Phase 2 cleanup.
+			return chartManaged, err
+		}
+		if exists {
+			return chartCleaning, nil
 		}
-
-		return chartExcluded, nil
 	}
+
+	return chartExcluded, nil
 }
 
 // allChartDefs is the single source of truth for all charts and their target
 // namespaces. BuildHelmCharts derives from this list.
 func allChartDefs(deps ccmcommon.Dependencies, chartsPath string) []chartDef {
+	prometheusCR := PrometheusOperatorCR(deps.PrometheusOperator.GetNamespace())
+	kedaCR := KEDAOperatorCR(deps.KEDA.GetNamespace())
+
 	return []chartDef{
 		{
-			stateFn: makeStateFn(func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
 				return d.GatewayAPI.ManagementPolicy
-			}, nil),
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.GatewayAPI.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				return valuesOverride(d.GatewayAPI.Configuration.ValuesOverride)
+			},
 			chart: types.HelmChartInfo{
 				Source: helm.Source{
 					Chart:       filepath.Join(chartsPath, "gateway-api"),
@@ -84,17 +117,31 @@ func allChartDefs(deps ccmcommon.Dependencies, chartsPath string) []chartDef {
 				},
 			},
 			monitor: monitorConfig{
+				Name:           "gatewayAPI",
 				ConditionType:  status.ConditionGatewayAPIReady,
 				HasDeployments: false,
 			},
+			detect: detectConfig{
+				CRDs:       []string{"gateways.gateway.networking.k8s.io", "httproutes.gateway.networking.k8s.io"},
+				MinVersion: "v1.0.0",
+			},
 		},
 		{
 			// FIXME(CM-1019): cert-manager-operator recreates CertManager/cluster after
-			// deletion, blocking Phase 1→Phase 2 cleanup. operatorCR is set to nil to
-			// skip the two-phase mechanism; cleanup relies on GC via generation mismatch.
-			stateFn: makeStateFn(func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+			// deletion, blocking Phase 1→Phase 2 cleanup. skipTwoPhaseCleanup skips the
+			// two-phase mechanism; cleanup relies on GC via generation mismatch.
+			skipTwoPhaseCleanup: true,
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
 				return d.CertManager.ManagementPolicy
-			}, nil),
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.CertManager.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				c := d.CertManager.Configuration
+
+				return valuesOverride(c.ValuesOverride, c.DeploymentSettings)
+			},
 			chart: types.HelmChartInfo{
 				Source: helm.Source{
 					Chart:       filepath.Join(chartsPath, "cert-manager-operator"),
@@ -108,15 +155,30 @@ func allChartDefs(deps ccmcommon.Dependencies, chartsPath string) []chartDef {
 			},
 			operatorCR: &CertManagerOperatorCR,
 			monitor: monitorConfig{
+				Name:           "certManager",
 				ConditionType:  status.ConditionCertManagerReady,
 				HasDeployments: true,
 				Namespace:      ccmcommon.DefaultNamespaceCertManagerOperator,
 			},
+			detect: detectConfig{
+				CRDs:       []string{"certificates.cert-manager.io", "issuers.cert-manager.io"},
+				Webhooks:   []string{"cert-manager-webhook"},
+				Deployment: map[string]string{appNameLabel: "cert-manager"},
+				MinVersion: "v1.13.0",
+			},
 		},
 		{
-			stateFn: makeStateFn(func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
 				return d.LWS.ManagementPolicy
-			}, &LWSOperatorCR),
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.LWS.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				c := d.LWS.Configuration
+
+				return valuesOverride(c.ValuesOverride, c.DeploymentSettings)
+			},
 			operatorCR: &LWSOperatorCR,
 			chart: types.HelmChartInfo{
 				Source: helm.Source{
@@ -129,15 +191,31 @@ func allChartDefs(deps ccmcommon.Dependencies, chartsPath string) []chartDef {
 				PreApply: []types.HookFn{SkipCRDIfPresent(ServiceMonitorCRDName)},
 			},
 			monitor: monitorConfig{
+				Name:           "lws",
 				ConditionType:  status.ConditionLWSReady,
 				HasDeployments: true,
 				Namespace:      deps.LWS.GetNamespace(),
 			},
+			detect: detectConfig{
+				CRDs:       []string{"leaderworkersets.leaderworkerset.x-k8s.io"},
+				Deployment: map[string]string{appNameLabel: "lws"},
+				MinVersion: "v0.5.0",
+			},
 		},
 		{
-			stateFn: makeStateFn(func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
 				return d.SailOperator.ManagementPolicy
-			}, &SailOperatorCR),
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.SailOperator.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				c := d.SailOperator.Configuration
+
+				return valuesOverride(c.ValuesOverride, c.DeploymentSettings, struct {
+					MeshConfig *ccmcommon.MeshConfig `json:"meshConfig,omitempty"`
+				}{c.MeshConfig})
+			},
 			chart: types.HelmChartInfo{
 				Source: helm.Source{
 					Chart:       filepath.Join(chartsPath, "sail-operator"),
@@ -152,10 +230,120 @@ func allChartDefs(deps ccmcommon.Dependencies, chartsPath string) []chartDef {
 			},
 			operatorCR: &SailOperatorCR,
 			monitor: monitorConfig{
+				Name:           "sailOperator",
 				ConditionType:  status.ConditionSailOperatorReady,
 				HasDeployments: true,
 				Namespace:      deps.SailOperator.GetNamespace(),
 			},
+			detect: detectConfig{
+				CRDs:     []string{"istios.sailoperator.io", "virtualservices.networking.istio.io"},
+				Webhooks: []string{"istio-sidecar-injector"},
+			},
+		},
+		{
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+				return d.Kueue.GetManagementPolicy()
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.Kueue.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				c := d.Kueue.Configuration
+
+				return valuesOverride(c.ValuesOverride, c.DeploymentSettings)
+			},
+			chart: types.HelmChartInfo{
+				Source: helm.Source{
+					Chart:       filepath.Join(chartsPath, "kueue-operator"),
+					ReleaseName: "kueue-operator",
+					Values: helm.Values(map[string]any{
+						"namespace": deps.Kueue.GetNamespace(),
+					}),
+				},
+				PreApply: []types.HookFn{},
+			},
+			operatorCR: &KueueOperatorCR,
+			monitor: monitorConfig{
+				Name:           "kueue",
+				ConditionType:  status.ConditionKueueReady,
+				HasDeployments: true,
+				Namespace:      deps.Kueue.GetNamespace(),
+			},
+			detect: detectConfig{
+				CRDs:       []string{"clusterqueues.kueue.x-k8s.io"},
+				Deployment: map[string]string{appNameLabel: "kueue"},
+				MinVersion: "v0.10.0",
+			},
+		},
+		{
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+				return d.PrometheusOperator.GetManagementPolicy()
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.PrometheusOperator.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				c := d.PrometheusOperator.Configuration
+
+				return valuesOverride(c.ValuesOverride, c.DeploymentSettings)
+			},
+			chart: types.HelmChartInfo{
+				Source: helm.Source{
+					Chart:       filepath.Join(chartsPath, "prometheus-operator"),
+					ReleaseName: "prometheus-operator",
+					Values: helm.Values(map[string]any{
+						"namespace": deps.PrometheusOperator.GetNamespace(),
+					}),
+				},
+				PreApply: []types.HookFn{},
+			},
+			operatorCR: &prometheusCR,
+			monitor: monitorConfig{
+				Name:           "prometheusOperator",
+				ConditionType:  status.ConditionPrometheusOperatorReady,
+				HasDeployments: true,
+				Namespace:      deps.PrometheusOperator.GetNamespace(),
+			},
+			detect: detectConfig{
+				CRDs:       []string{"prometheuses.monitoring.coreos.com"},
+				Deployment: map[string]string{appNameLabel: "prometheus-operator"},
+				MinVersion: "v0.70.0",
+			},
+		},
+		{
+			policyFn: func(d ccmcommon.Dependencies) ccmcommon.ManagementPolicy {
+				return d.KEDA.GetManagementPolicy()
+			},
+			versionFn: func(d ccmcommon.Dependencies) ccmcommon.VersionPolicy {
+				return d.KEDA.VersionPolicy
+			},
+			valuesFn: func(d ccmcommon.Dependencies) (map[string]any, error) {
+				c := d.KEDA.Configuration
+
+				return valuesOverride(c.ValuesOverride, c.DeploymentSettings)
+			},
+			chart: types.HelmChartInfo{
+				Source: helm.Source{
+					Chart:       filepath.Join(chartsPath, "keda"),
+					ReleaseName: "keda",
+					Values: helm.Values(map[string]any{
+						"namespace": deps.KEDA.GetNamespace(),
+					}),
+				},
+				PreApply: []types.HookFn{},
+			},
+			operatorCR: &kedaCR,
+			monitor: monitorConfig{
+				Name:           "keda",
+				ConditionType:  status.ConditionKEDAReady,
+				HasDeployments: true,
+				Namespace:      deps.KEDA.GetNamespace(),
+			},
+			detect: detectConfig{
+				CRDs:       []string{"scaledobjects.keda.sh"},
+				Deployment: map[string]string{appNameLabel: "keda-operator"},
+				MinVersion: "v2.12.0",
+			},
 		},
 	}
 }
@@ -163,12 +351,23 @@ func allChartDefs(deps ccmcommon.Dependencies, chartsPath string) []chartDef {
 // DependencyMonitorConfig holds per-dependency monitoring metadata.
 // Operator CR info is derived from chartDef.operatorCR.
 type DependencyMonitorConfig struct {
+	// Name is the name of the dependency in the Dependencies API.
+	Name           string
 	ReleaseName    string
 	ConditionType  string
 	HasDeployments bool
 	Policy         ccmcommon.ManagementPolicy
 	Namespace      string
 	OperatorCR     *types.OperatorCR
+	// ChartState is the state of the chart of the dependency.
+	ChartState ccmcommon.ChartState
+	// Version is the chart version rendered for the dependency, empty when unknown.
+	Version string
+	// AvailableVersion is the newest bundled chart version.
+	AvailableVersion string
+	// External is the existing installation adopted by the Auto policy, nil
+	// when the dependency is installed by the cloud manager.
+	External *ExternalInstallation
 }
 
 // BuildResult holds the output of BuildHelmCharts: the charts to render,
@@ -182,22 +381,96 @@ type BuildResult struct {
 	MonitorConfigs []DependencyMonitorConfig
 }
 
+// BuildOption configures BuildHelmCharts.
+type BuildOption func(*buildOptions)
+
+type buildOptions struct {
+	reader client.Reader
+}
+
+// WithAPIReader sets the reader used to detect existing installations of the
+// dependencies with the Auto policy. Their resources are not labeled by the
+// cloud manager, hence not in the cache of the manager: an uncached reader
+// is required. It defaults to the client given to BuildHelmCharts.
+func WithAPIReader(reader client.Reader) BuildOption {
+	return func(o *buildOptions) {
+		o.reader = reader
+	}
+}
+
 // BuildHelmCharts returns the charts to render, CRs to filter, and monitoring
-// configs in a single pass. Each chart's stateFn is called exactly once.
-func BuildHelmCharts(ctx context.Context, cli client.Client, deps ccmcommon.Dependencies, chartsPath string) (BuildResult, error) {
+// configs in a single pass. The state of each chart is computed exactly once,
+// and each chart is rendered from the bundled version selected by the version
+// policy of its dependency.
+func BuildHelmCharts(
+	ctx context.Context,
+	cli client.Client,
+	deps ccmcommon.Dependencies,
+	chartsPath string,
+	opts ...BuildOption,
+) (BuildResult, error) {
+	o := buildOptions{reader: cli}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	var result BuildResult
 
 	for _, def := range allChartDefs(deps, chartsPath) {
-		state, err := def.stateFn(ctx, cli, deps)
+		policy := def.policyFn(deps)
+
+		var external *ExternalInstallation
+
+		if policy == ccmcommon.Auto {
+			var err error
+
+			external, err = detectExternalInstallation(ctx, o.reader, def.detect)
+			if err != nil {
+				return BuildResult{}, fmt.Errorf("failed to detect an existing installation of %s: %w", def.chart.ReleaseName, err)
+			}
+		}
+
+		state := chartExternal
+		if external == nil {
+			var err error
+
+			state, err = chartStateFor(ctx, cli, def, policy)
+			if err != nil {
+				return BuildResult{}, err
+			}
+		}
+
+		version, err := selectChartVersion(ctx, cli, chartsPath, def, def.versionFn(deps))
 		if err != nil {
-			return BuildResult{}, err
+			if state == chartManaged {
+				return BuildResult{}, err
+			}
+
+			// Charts being removed are rendered at the default version, which is
+			// enough to find the resources to delete.
+			version = chartVersion{path: def.chart.Chart}
 		}
 
-		policy := ccmcommon.Unmanaged
+		def.chart.Chart = version.path
+		if version.version != "" {
+			def.chart.PostRenderers = append(slices.Clone(def.chart.PostRenderers),
+				annotateChartVersion(def.chart.ReleaseName, version.version))
+		}
+
+		values, err := def.valuesFn(deps)
+		if err == nil {
+			def.chart, err = applyValuesOverride(ctx, def.chart, values)
+		}
+		if err != nil && state == chartManaged {
+			return BuildResult{}, fmt.Errorf("invalid configuration of %s: %w", def.chart.ReleaseName, err)
+		}
+		// otherwise, charts being removed are rendered with the bundled values
+
+		monitorPolicy := ccmcommon.Unmanaged
 
 		switch state {
 		case chartManaged:
-			policy = ccmcommon.Managed
+			monitorPolicy = ccmcommon.Managed
 			result.Charts = append(result.Charts, def.chart)
 		case chartCleaning:
 			result.Charts = append(result.Charts, def.chart)
@@ -208,21 +481,247 @@ func BuildHelmCharts(ctx context.Context, cli client.Client, deps ccmcommon.Depe
 			if def.operatorCR != nil {
 				result.CleanupCharts = append(result.CleanupCharts, def.chart)
 			}
+		case chartExternal:
+			// the existing installation is neither deployed nor cleaned up
+		}
+
+		if policy == ccmcommon.Auto {
+			monitorPolicy = ccmcommon.Auto
 		}
 
-		result.MonitorConfigs = append(result.MonitorConfigs, DependencyMonitorConfig{
-			ReleaseName:    def.chart.ReleaseName,
-			ConditionType:  def.monitor.ConditionType,
-			HasDeployments: def.monitor.HasDeployments,
-			Policy:         policy,
-			Namespace:      def.monitor.Namespace,
-			OperatorCR:     def.operatorCR,
-		})
+		cfg := DependencyMonitorConfig{
+			Name:             def.monitor.Name,
+			ReleaseName:      def.chart.ReleaseName,
+			ConditionType:    def.monitor.ConditionType,
+			HasDeployments:   def.monitor.HasDeployments,
+			Policy:           monitorPolicy,
+			Namespace:        def.monitor.Namespace,
+			OperatorCR:       def.operatorCR,
+			ChartState:       state.apiState(),
+			Version:          version.version,
+			AvailableVersion: version.available,
+			External:         external,
+		}
+		if external != nil {
+			cfg.Version = external.Version
+			cfg.AvailableVersion = ""
+		}
+
+		result.MonitorConfigs = append(result.MonitorConfigs, cfg)
 	}
 
 	return result, nil
 }
 
+// ClusterSettingsPostRenderer returns a post renderer applying the given
+// cluster settings to the rendered resources, since the charts do not share
+// any value for them: the StorageClass is set on the PersistentVolumeClaims
+// and on the volume claim templates of the StatefulSets, and the Service type
+// on the Services exposed outside of the cluster, that is of type NodePort or
+// LoadBalancer. Empty settings leave the resources as rendered, in which case
+// nil is returned.
+func ClusterSettingsPostRenderer(settings ccmcommon.ClusterSettings) engineTypes.PostRenderer {
+	if settings.StorageClassName == "" && settings.LoadBalancerType == "" {
+		return nil
+	}
+
+	pvc := gvk.PersistentVolumeClaim.GroupKind()
+	statefulSet := gvk.StatefulSet.GroupKind()
+	service := gvk.Service.GroupKind()
+
+	return func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		for i := range objects {
+			var err error
+
+			switch objects[i].GroupVersionKind().GroupKind() {
+			case pvc:
+				err = setStorageClassName(objects[i].Object, settings.StorageClassName, "spec")
+			case statefulSet:
+				err = setVolumeClaimTemplatesStorageClassName(objects[i].Object, settings.StorageClassName)
+			case service:
+				err = setServiceType(objects[i].Object, settings.LoadBalancerType)
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply the cluster settings to %s %s: %w",
+					objects[i].GetKind(), objects[i].GetName(), err)
+			}
+		}
+
+		return objects, nil
+	}
+}
+
+func setStorageClassName(obj map[string]any, storageClassName string, fields ...string) error {
+	if storageClassName == "" {
+		return nil
+	}
+
+	return unstructured.SetNestedField(obj, storageClassName, append(fields, "storageClassName")...)
+}
+
+func setVolumeClaimTemplatesStorageClassName(obj map[string]any, storageClassName string) error {
+	if storageClassName == "" {
+		return nil
+	}
+
+	templates, found, err := unstructured.NestedSlice(obj, "spec", "volumeClaimTemplates")
+	if err != nil || !found {
+		return err
+	}
+
+	for i := range templates {
+		template, ok := templates[i].(map[string]any)
+		if !ok {
+			return fmt.Errorf("unexpected volume claim template %T", templates[i])
+		}
+
+		if err := setStorageClassName(template, storageClassName, "spec"); err != nil {
+			return err
+		}
+	}
+
+	return unstructured.SetNestedSlice(obj, templates, "spec", "volumeClaimTemplates")
+}
+
+// loadBalancerServiceFields are the Service fields only allowed on the
+// Services of type LoadBalancer.
+var loadBalancerServiceFields = []string{
+	"allocateLoadBalancerNodePorts", "healthCheckNodePort", "loadBalancerClass", "loadBalancerIP", "loadBalancerSourceRanges",
+}
+
+func setServiceType(obj map[string]any, serviceType ccmcommon.LoadBalancerType) error {
+	if serviceType == "" {
+		return nil
+	}
+
+	current, _, err := unstructured.NestedString(obj, "spec", "type")
+	if err != nil {
+		return err
+	}
+
+	if current != string(ccmcommon.LoadBalancerTypeNodePort) && current != string(ccmcommon.LoadBalancerTypeLoadBalancer) {
+		return nil
+	}
+
+	if serviceType != ccmcommon.LoadBalancerTypeLoadBalancer {
+		for _, field := range loadBalancerServiceFields {
+			unstructured.RemoveNestedField(obj, "spec", field)
+		}
+	}
+
+	if serviceType == ccmcommon.LoadBalancerTypeClusterIP {
+		// node ports and the external traffic policy only apply to the
+		// Services published outside of the cluster
+		unstructured.RemoveNestedField(obj, "spec", "externalTrafficPolicy")
+
+		ports, found, err := unstructured.NestedSlice(obj, "spec", "ports")
+		if err != nil {
+			return err
+		}
+
+		for i := range ports {
+			if port, ok := ports[i].(map[string]any); ok {
+				delete(port, "nodePort")
+			}
+		}
+
+		if found {
+			if err := unstructured.SetNestedSlice(obj, ports, "spec", "ports"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return unstructured.SetNestedField(obj, string(serviceType), "spec", "type")
+}
+
+// WithPostRenderers returns a copy of charts where renderers are appended to
+// the post renderers of the chart with the given release name, or of every
+// chart when releaseName is empty.
+func WithPostRenderers(
+	charts []types.HelmChartInfo,
+	releaseName string,
+	renderers ...engineTypes.PostRenderer,
+) []types.HelmChartInfo {
+	if len(renderers) == 0 {
+		return charts
+	}
+
+	result := make([]types.HelmChartInfo, len(charts))
+
+	for i, chart := range charts {
+		if releaseName == "" || chart.ReleaseName == releaseName {
+			chart.PostRenderers = append(slices.Clone(chart.PostRenderers), renderers...)
+		}
+
+		result[i] = chart
+	}
+
+	return result
+}
+
+// WithValues returns a copy of charts where values are deep merged over the
+// values of the chart with the given release name, or of every chart when
+// releaseName is empty. Nested maps are merged, any other value replaces the
+// one of the chart.
+func WithValues(charts []types.HelmChartInfo, releaseName string, values map[string]any) []types.HelmChartInfo {
+	if len(values) == 0 {
+		return charts
+	}
+
+	result := make([]types.HelmChartInfo, len(charts))
+
+	for i, chart := range charts {
+		if releaseName != "" && chart.ReleaseName != releaseName {
+			result[i] = chart
+			continue
+		}
+
+		valuesFn := chart.Values
+
+		chart.Values = func(ctx context.Context) (engineTypes.Values, error) {
+			var base map[string]any
+
+			if valuesFn != nil {
+				v, err := valuesFn(ctx)
+				if err != nil {
+					return nil, err
+				}
+
+				base = v
+			}
+
+			return mergeValues(base, values), nil
+		}
+
+		result[i] = chart
+	}
+
+	return result
+}
+
+// mergeValues deep merges overrides over base without modifying either of them.
+func mergeValues(base map[string]any, overrides map[string]any) map[string]any {
+	result := maps.Clone(base)
+	if result == nil {
+		result = make(map[string]any, len(overrides))
+	}
+
+	for k, v := range overrides {
+		ov, ok := v.(map[string]any)
+		if !ok {
+			result[k] = v
+			continue
+		}
+
+		bv, _ := result[k].(map[string]any)
+		result[k] = mergeValues(bv, ov)
+	}
+
+	return result
+}
+
 func operatorCRExists(ctx context.Context, cli client.Client, cr *types.OperatorCR) (bool, error) {
 	obj := &unstructured.Unstructured{}
 	obj.SetGroupVersionKind(cr.GVK)
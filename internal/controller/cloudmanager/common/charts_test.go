@@ -7,6 +7,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	helm "github.com/k8s-manifest-kit/renderer-helm/pkg"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -14,6 +16,7 @@ import (
 
 	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
 
 	. "github.com/onsi/gomega"
@@ -23,10 +26,13 @@ const testChartsPath = "/test/charts"
 
 func getAllUnmanagedDependencies() ccmcommon.Dependencies {
 	return ccmcommon.Dependencies{
-		GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-		CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
-		LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-		SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+		KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 	}
 }
 
@@ -48,12 +54,20 @@ func TestBuildHelmCharts(t *testing.T) {
 		"cert-manager-operator",
 		"lws-operator",
 		"sail-operator",
+		"kueue-operator",
+		"prometheus-operator",
+		"keda",
 	}
 
 	t.Run("returns all charts in order when all managed", func(t *testing.T) {
 		g := NewWithT(t)
 
-		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		deps := ccmcommon.Dependencies{}
+		deps.Kueue.ManagementPolicy = ccmcommon.Managed
+		deps.PrometheusOperator.ManagementPolicy = ccmcommon.Managed
+		deps.KEDA.ManagementPolicy = ccmcommon.Managed
+
+		result, err := BuildHelmCharts(ctx, cli, deps, testChartsPath)
 		g.Expect(err).NotTo(HaveOccurred())
 
 		g.Expect(result.Charts).To(HaveLen(len(expectedReleaseNames)))
@@ -64,6 +78,23 @@ func TestBuildHelmCharts(t *testing.T) {
 		g.Expect(result.FilterCRs).To(BeEmpty())
 	})
 
+	t.Run("excludes opt-in dependencies without a management policy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		names := make([]string, 0, len(result.Charts))
+		for _, c := range result.Charts {
+			names = append(names, c.ReleaseName)
+		}
+		g.Expect(names).To(Equal([]string{"gateway-api", "cert-manager-operator", "lws-operator", "sail-operator"}))
+
+		for _, cfg := range result.MonitorConfigs[4:] {
+			g.Expect(cfg.Policy).To(Equal(ccmcommon.Unmanaged))
+		}
+	})
+
 	t.Run("excludes unmanaged charts and preserves order", func(t *testing.T) {
 		g := NewWithT(t)
 
@@ -76,7 +107,7 @@ func TestBuildHelmCharts(t *testing.T) {
 		g.Expect(result.Charts).To(HaveLen(1))
 		g.Expect(result.Charts[0].ReleaseName).To(Equal("lws-operator"))
 		g.Expect(result.FilterCRs).To(BeEmpty())
-		g.Expect(result.CleanupCharts).To(HaveLen(2))
+		g.Expect(result.CleanupCharts).To(HaveLen(5))
 	})
 
 	t.Run("returns empty slice when all unmanaged and no CRs on cluster", func(t *testing.T) {
@@ -89,10 +120,13 @@ func TestBuildHelmCharts(t *testing.T) {
 
 		g.Expect(result.Charts).To(BeEmpty())
 		g.Expect(result.FilterCRs).To(BeEmpty())
-		g.Expect(result.CleanupCharts).To(HaveLen(3))
+		g.Expect(result.CleanupCharts).To(HaveLen(6))
 		g.Expect(result.CleanupCharts[0].ReleaseName).To(Equal("cert-manager-operator"))
 		g.Expect(result.CleanupCharts[1].ReleaseName).To(Equal("lws-operator"))
 		g.Expect(result.CleanupCharts[2].ReleaseName).To(Equal("sail-operator"))
+		g.Expect(result.CleanupCharts[3].ReleaseName).To(Equal("kueue-operator"))
+		g.Expect(result.CleanupCharts[4].ReleaseName).To(Equal("prometheus-operator"))
+		g.Expect(result.CleanupCharts[5].ReleaseName).To(Equal("keda"))
 	})
 
 	t.Run("monitor configs include policy derived from state", func(t *testing.T) {
@@ -104,11 +138,21 @@ func TestBuildHelmCharts(t *testing.T) {
 		result, err := BuildHelmCharts(ctx, cli, deps, testChartsPath)
 		g.Expect(err).NotTo(HaveOccurred())
 
-		g.Expect(result.MonitorConfigs).To(HaveLen(4))
+		g.Expect(result.MonitorConfigs).To(HaveLen(7))
 		g.Expect(result.MonitorConfigs[0].Policy).To(Equal(ccmcommon.Managed))
-		g.Expect(result.MonitorConfigs[1].Policy).To(Equal(ccmcommon.Unmanaged))
-		g.Expect(result.MonitorConfigs[2].Policy).To(Equal(ccmcommon.Unmanaged))
-		g.Expect(result.MonitorConfigs[3].Policy).To(Equal(ccmcommon.Unmanaged))
+		g.Expect(result.MonitorConfigs[0].ChartState).To(Equal(ccmcommon.ChartStateManaged))
+		for _, cfg := range result.MonitorConfigs[1:] {
+			g.Expect(cfg.Policy).To(Equal(ccmcommon.Unmanaged))
+			g.Expect(cfg.ChartState).To(Equal(ccmcommon.ChartStateExcluded))
+		}
+
+		names := make([]string, 0, len(result.MonitorConfigs))
+		for _, cfg := range result.MonitorConfigs {
+			names = append(names, cfg.Name)
+		}
+		g.Expect(names).To(Equal([]string{
+			"gatewayAPI", "certManager", "lws", "sailOperator", "kueue", "prometheusOperator", "keda",
+		}))
 	})
 
 	t.Run("uses custom namespaces in chart values", func(t *testing.T) {
@@ -125,12 +169,30 @@ func TestBuildHelmCharts(t *testing.T) {
 					Namespace: "custom-sail-ns",
 				},
 			},
+			Kueue: ccmcommon.KueueDependency{
+				ManagementPolicy: ccmcommon.Managed,
+				Configuration: ccmcommon.KueueConfiguration{
+					Namespace: "custom-kueue-ns",
+				},
+			},
+			PrometheusOperator: ccmcommon.PrometheusOperatorDependency{
+				ManagementPolicy: ccmcommon.Managed,
+				Configuration: ccmcommon.PrometheusOperatorConfiguration{
+					Namespace: "custom-prometheus-ns",
+				},
+			},
+			KEDA: ccmcommon.KEDADependency{
+				ManagementPolicy: ccmcommon.Managed,
+				Configuration: ccmcommon.KEDAConfiguration{
+					Namespace: "custom-keda-ns",
+				},
+			},
 		}
 
 		result, err := BuildHelmCharts(ctx, cli, deps, testChartsPath)
 		g.Expect(err).NotTo(HaveOccurred())
 
-		g.Expect(result.Charts).To(HaveLen(4))
+		g.Expect(result.Charts).To(HaveLen(7))
 
 		certManagerChart := result.Charts[1]
 		g.Expect(certManagerChart.ReleaseName).To(Equal("cert-manager-operator"))
@@ -150,6 +212,16 @@ func TestBuildHelmCharts(t *testing.T) {
 		values, err = sailChart.Values(ctx)
 		g.Expect(err).NotTo(HaveOccurred())
 		g.Expect(values).To(HaveKeyWithValue("namespace", "custom-sail-ns"))
+
+		for i, ns := range map[int]string{4: "custom-kueue-ns", 5: "custom-prometheus-ns", 6: "custom-keda-ns"} {
+			values, err = result.Charts[i].Values(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(values).To(HaveKeyWithValue("namespace", ns))
+			g.Expect(result.MonitorConfigs[i].Namespace).To(Equal(ns))
+		}
+
+		g.Expect(result.MonitorConfigs[5].OperatorCR.Namespace).To(Equal("custom-prometheus-ns"))
+		g.Expect(result.MonitorConfigs[6].OperatorCR.Namespace).To(Equal("custom-keda-ns"))
 	})
 }
 
@@ -161,6 +233,7 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 			name        string
 			crGVK       schema.GroupVersionKind
 			crName      string
+			crNamespace string
 			releaseName string
 		}{
 			{
@@ -175,6 +248,26 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 				crName:      "cluster",
 				releaseName: "lws-operator",
 			},
+			{
+				name:        "Kueue",
+				crGVK:       gvk.KueueConfigV1,
+				crName:      "cluster",
+				releaseName: "kueue-operator",
+			},
+			{
+				name:        "Prometheus operator",
+				crGVK:       gvk.CoreosPrometheus,
+				crName:      "prometheus",
+				crNamespace: ccmcommon.DefaultNamespacePrometheusOperator,
+				releaseName: "prometheus-operator",
+			},
+			{
+				name:        "KEDA",
+				crGVK:       gvk.KedaControllerV1Alpha1,
+				crName:      "keda",
+				crNamespace: ccmcommon.DefaultNamespaceKEDA,
+				releaseName: "keda",
+			},
 		}
 
 		for _, tc := range tests {
@@ -184,6 +277,7 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 				cr := &unstructured.Unstructured{}
 				cr.SetGroupVersionKind(tc.crGVK)
 				cr.SetName(tc.crName)
+				cr.SetNamespace(tc.crNamespace)
 
 				cli := newFakeClient(t, fakeclient.WithObjects(cr))
 
@@ -196,6 +290,11 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 				g.Expect(result.FilterCRs).To(HaveLen(1))
 				g.Expect(result.FilterCRs[0].GVK).To(Equal(tc.crGVK))
 				g.Expect(result.FilterCRs[0].Name).To(Equal(tc.crName))
+				g.Expect(result.FilterCRs[0].Namespace).To(Equal(tc.crNamespace))
+				g.Expect(result.MonitorConfigs).To(ContainElement(And(
+					HaveField("ReleaseName", tc.releaseName),
+					HaveField("ChartState", ccmcommon.ChartStateCleaning),
+				)))
 			})
 		}
 	})
@@ -216,7 +315,8 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 		g.Expect(result.Charts[1].ReleaseName).To(Equal("lws-operator"))
 		g.Expect(result.Charts[2].ReleaseName).To(Equal("sail-operator"))
 		g.Expect(result.FilterCRs).To(BeEmpty())
-		g.Expect(result.CleanupCharts).To(BeEmpty())
+		// the opt-in dependencies default to Unmanaged
+		g.Expect(result.CleanupCharts).To(HaveLen(3))
 	})
 
 	t.Run("multiple deps unmanaged with CRs keeps charts with operatorCR", func(t *testing.T) {
@@ -258,7 +358,7 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 
 		g.Expect(result.Charts).To(BeEmpty())
 		g.Expect(result.FilterCRs).To(BeEmpty())
-		g.Expect(result.CleanupCharts).To(HaveLen(3))
+		g.Expect(result.CleanupCharts).To(HaveLen(6))
 	})
 
 	t.Run("transient Get error propagates instead of forcing Phase 2", func(t *testing.T) {
@@ -290,22 +390,255 @@ func TestBuildHelmChartsPhase1(t *testing.T) {
 		cli := newFakeClient(t, fakeclient.WithObjects(istioCR))
 
 		deps := ccmcommon.Dependencies{
-			GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Managed},
-			CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
-			LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-			SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+			GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Managed},
+			CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+			LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+			SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+			Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Managed},
+			PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+			KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Managed},
 		}
 
 		result, err := BuildHelmCharts(ctx, cli, deps, testChartsPath)
 		g.Expect(err).NotTo(HaveOccurred())
 
-		g.Expect(result.Charts).To(HaveLen(3))
+		g.Expect(result.Charts).To(HaveLen(5))
 		g.Expect(result.Charts[0].ReleaseName).To(Equal("gateway-api"))
 		g.Expect(result.Charts[1].ReleaseName).To(Equal("cert-manager-operator"))
 		g.Expect(result.Charts[2].ReleaseName).To(Equal("sail-operator"))
+		g.Expect(result.Charts[3].ReleaseName).To(Equal("kueue-operator"))
+		g.Expect(result.Charts[4].ReleaseName).To(Equal("keda"))
 		g.Expect(result.FilterCRs).To(HaveLen(1))
 		g.Expect(result.FilterCRs[0].GVK).To(Equal(gvk.Istio))
-		g.Expect(result.CleanupCharts).To(HaveLen(1))
+		g.Expect(result.CleanupCharts).To(HaveLen(2))
 		g.Expect(result.CleanupCharts[0].ReleaseName).To(Equal("lws-operator"))
+		g.Expect(result.CleanupCharts[1].ReleaseName).To(Equal("prometheus-operator"))
+	})
+}
+
+func TestClusterSettingsPostRenderer(t *testing.T) {
+	ctx := context.Background()
+
+	newObject := func(k schema.GroupVersionKind, name string, spec map[string]any) unstructured.Unstructured {
+		obj := unstructured.Unstructured{Object: map[string]any{"spec": spec}}
+		obj.SetGroupVersionKind(k)
+		obj.SetName(name)
+
+		return obj
+	}
+
+	newObjects := func() []unstructured.Unstructured {
+		return []unstructured.Unstructured{
+			newObject(gvk.PersistentVolumeClaim, "data", map[string]any{"storageClassName": "gp2"}),
+			newObject(gvk.StatefulSet, "db", map[string]any{
+				"volumeClaimTemplates": []any{
+					map[string]any{"metadata": map[string]any{"name": "a"}, "spec": map[string]any{}},
+					map[string]any{"metadata": map[string]any{"name": "b"}, "spec": map[string]any{"storageClassName": "gp2"}},
+				},
+			}),
+			newObject(gvk.Service, "public", map[string]any{
+				"type":                          "LoadBalancer",
+				"allocateLoadBalancerNodePorts": true,
+				"externalTrafficPolicy":         "Local",
+				"ports":                         []any{map[string]any{"port": int64(443), "nodePort": int64(30443)}},
+			}),
+			newObject(gvk.Service, "webhook", map[string]any{"type": "ClusterIP"}),
+			newObject(gvk.Service, "metrics", map[string]any{}),
+			newObject(gvk.Deployment, "operator", map[string]any{}),
+		}
+	}
+
+	render := func(g *WithT, settings ccmcommon.ClusterSettings) []unstructured.Unstructured {
+		renderer := ClusterSettingsPostRenderer(settings)
+		g.Expect(renderer).NotTo(BeNil())
+
+		objects, err := renderer(ctx, newObjects())
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(objects).To(HaveLen(6))
+
+		return objects
+	}
+
+	field := func(g *WithT, obj unstructured.Unstructured, fields ...string) any {
+		v, _, err := unstructured.NestedFieldNoCopy(obj.Object, fields...)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return v
+	}
+
+	t.Run("empty settings do not render anything", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(ClusterSettingsPostRenderer(ccmcommon.ClusterSettings{})).To(BeNil())
+	})
+
+	t.Run("the storage class is set on the claims", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := render(g, ccmcommon.ClusterSettings{StorageClassName: "local-path"})
+
+		g.Expect(field(g, objects[0], "spec", "storageClassName")).To(Equal("local-path"))
+
+		templates := field(g, objects[1], "spec", "volumeClaimTemplates")
+		g.Expect(templates).To(HaveExactElements(
+			HaveKeyWithValue("spec", HaveKeyWithValue("storageClassName", "local-path")),
+			HaveKeyWithValue("spec", HaveKeyWithValue("storageClassName", "local-path")),
+		))
+
+		// Services are left as rendered
+		g.Expect(field(g, objects[2], "spec", "type")).To(Equal("LoadBalancer"))
+	})
+
+	t.Run("the type of the published services is replaced", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := render(g, ccmcommon.ClusterSettings{LoadBalancerType: ccmcommon.LoadBalancerTypeNodePort})
+
+		g.Expect(field(g, objects[2], "spec", "type")).To(Equal("NodePort"))
+		g.Expect(field(g, objects[2], "spec", "allocateLoadBalancerNodePorts")).To(BeNil())
+		g.Expect(field(g, objects[2], "spec", "externalTrafficPolicy")).To(Equal("Local"))
+		g.Expect(field(g, objects[2], "spec", "ports")).To(HaveExactElements(HaveKeyWithValue("nodePort", int64(30443))))
+
+		g.Expect(field(g, objects[3], "spec", "type")).To(Equal("ClusterIP"))
+		g.Expect(field(g, objects[4], "spec", "type")).To(BeNil())
+
+		// claims are left as rendered
+		g.Expect(field(g, objects[0], "spec", "storageClassName")).To(Equal("gp2"))
+	})
+
+	t.Run("services are not published with ClusterIP", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objects := render(g, ccmcommon.ClusterSettings{LoadBalancerType: ccmcommon.LoadBalancerTypeClusterIP})
+
+		g.Expect(field(g, objects[2], "spec", "type")).To(Equal("ClusterIP"))
+		g.Expect(field(g, objects[2], "spec", "externalTrafficPolicy")).To(BeNil())
+		g.Expect(field(g, objects[2], "spec", "ports")).To(HaveExactElements(Not(HaveKey("nodePort"))))
+	})
+}
+
+func TestWithPostRenderers(t *testing.T) {
+	ctx := context.Background()
+	cli := newFakeClient(t)
+
+	renderer := func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		return objects, nil
+	}
+
+	t.Run("returns charts unchanged without renderers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(WithPostRenderers(result.Charts, "")).To(Equal(result.Charts))
+	})
+
+	t.Run("appends renderers to the selected chart only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		charts := WithPostRenderers(result.Charts, "lws-operator", renderer)
+		g.Expect(charts).To(HaveLen(len(result.Charts)))
+
+		for i, chart := range charts {
+			if chart.ReleaseName == "lws-operator" {
+				g.Expect(chart.PostRenderers).To(HaveLen(len(result.Charts[i].PostRenderers) + 1))
+			} else {
+				g.Expect(chart.PostRenderers).To(HaveLen(len(result.Charts[i].PostRenderers)))
+			}
+		}
+	})
+}
+
+func TestWithValues(t *testing.T) {
+	ctx := context.Background()
+	cli := newFakeClient(t)
+
+	t.Run("returns charts unchanged when values are empty", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		charts := WithValues(result.Charts, "", nil)
+		g.Expect(charts).To(Equal(result.Charts))
+	})
+
+	t.Run("merges values into every chart preserving chart values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		charts := WithValues(result.Charts, "", map[string]any{
+			"global": map[string]any{"storageClassName": "local-path"},
+		})
+		g.Expect(charts).To(HaveLen(len(result.Charts)))
+
+		for _, chart := range charts {
+			values, err := chart.Values(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(values).To(HaveKeyWithValue("global", map[string]any{"storageClassName": "local-path"}))
+		}
+
+		lws := charts[2]
+		g.Expect(lws.ReleaseName).To(Equal("lws-operator"))
+
+		values, err := lws.Values(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(values).To(HaveKeyWithValue("namespace", ccmcommon.DefaultNamespaceLWSOperator))
+
+		// the original chart values are not modified
+		original, err := result.Charts[2].Values(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(original).NotTo(HaveKey("global"))
+	})
+
+	t.Run("merges values into the chart with the given release name only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, cli, ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		charts := WithValues(result.Charts, "sail-operator", map[string]any{"namespace": "custom-istio"})
+
+		for _, chart := range charts {
+			values, err := chart.Values(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+
+			if chart.ReleaseName == "sail-operator" {
+				g.Expect(values).To(HaveKeyWithValue("namespace", "custom-istio"))
+			} else {
+				g.Expect(values).NotTo(HaveKeyWithValue("namespace", "custom-istio"))
+			}
+		}
+	})
+
+	t.Run("deep merges nested values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		chart := types.HelmChartInfo{}
+		chart.Values = helm.Values(map[string]any{
+			"global":   map[string]any{"imagePullSecrets": []string{"pull-secret"}},
+			"replicas": 1,
+		})
+
+		charts := WithValues([]types.HelmChartInfo{chart}, "", map[string]any{
+			"global":   map[string]any{"storageClassName": "fast"},
+			"replicas": 2,
+		})
+
+		values, err := charts[0].Values(ctx)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(values).To(Equal(engineTypes.Values{
+			"global": map[string]any{
+				"imagePullSecrets": []string{"pull-secret"},
+				"storageClassName": "fast",
+			},
+			"replicas": 2,
+		}))
 	})
 }
@@ -0,0 +1,153 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// appNameLabel is the recommended label carrying the name of an application.
+	appNameLabel = "app.kubernetes.io/name"
+	// versionLabel is the recommended label carrying the version of an application.
+	versionLabel = "app.kubernetes.io/version"
+	// gatewayAPIBundleVersion is the annotation carrying the release of the Gateway API CRDs.
+	gatewayAPIBundleVersion = "gateway.networking.k8s.io/bundle-version"
+)
+
+// detectConfig describes how an existing installation of a dependency,
+// not deployed by the cloud manager, is found on the cluster.
+type detectConfig struct {
+	// CRDs are the names of the CRDs installed by the dependency.
+	CRDs []string
+	// Webhooks are the names of the validating or mutating webhook
+	// configurations installed by the dependency.
+	Webhooks []string
+	// Deployment selects the operator deployments of the dependency, in any namespace.
+	Deployment map[string]string
+	// MinVersion is the oldest version of the dependency that can be adopted.
+	MinVersion string
+}
+
+// ExternalInstallation is an installation of a dependency found on the
+// cluster and not deployed by the cloud manager.
+type ExternalInstallation struct {
+	// Version is the detected version, empty when not advertised.
+	Version string
+	// Resources lists the resources revealing the installation, as kind/name.
+	Resources []string
+	// Deployments are the operator deployments of the installation.
+	Deployments []k8stypes.NamespacedName
+	// Incompatible explains why the installation cannot be adopted, empty
+	// when it can.
+	Incompatible string
+}
+
+// detectExternalInstallation looks for an installation of a dependency
+// matching cfg. It returns nil when the dependency is not installed, or when
+// it was installed by the cloud manager, as recorded by the part-of label on
+// its resources.
+func detectExternalInstallation(ctx context.Context, cli client.Reader, cfg detectConfig) (*ExternalInstallation, error) {
+	found := &ExternalInstallation{}
+	var versions []string
+
+	for _, name := range cfg.CRDs {
+		crd := &extv1.CustomResourceDefinition{}
+		if err := cli.Get(ctx, client.ObjectKey{Name: name}, crd); err != nil {
+			if k8serr.IsNotFound(err) {
+				continue
+			}
+
+			return nil, fmt.Errorf("failed to get CRD %s: %w", name, err)
+		}
+
+		if _, ok := crd.GetLabels()[labels.InfrastructurePartOf]; ok {
+			return nil, nil
+		}
+
+		found.Resources = append(found.Resources, "CustomResourceDefinition/"+name)
+		versions = append(versions, crd.GetLabels()[versionLabel], crd.GetAnnotations()[gatewayAPIBundleVersion])
+	}
+
+	for _, name := range cfg.Webhooks {
+		webhooks := map[string]client.Object{
+			"ValidatingWebhookConfiguration": &admissionregistrationv1.ValidatingWebhookConfiguration{},
+			"MutatingWebhookConfiguration":   &admissionregistrationv1.MutatingWebhookConfiguration{},
+		}
+
+		for _, kind := range slices.Sorted(maps.Keys(webhooks)) {
+			webhook := webhooks[kind]
+			if err := cli.Get(ctx, client.ObjectKey{Name: name}, webhook); err != nil {
+				if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
+					continue
+				}
+
+				return nil, fmt.Errorf("failed to get webhook configuration %s: %w", name, err)
+			}
+
+			if _, ok := webhook.GetLabels()[labels.InfrastructurePartOf]; ok {
+				return nil, nil
+			}
+
+			found.Resources = append(found.Resources, kind+"/"+name)
+		}
+	}
+
+	if len(cfg.Deployment) > 0 {
+		list := &appsv1.DeploymentList{}
+		if err := cli.List(ctx, list, client.MatchingLabels(cfg.Deployment)); err != nil {
+			return nil, fmt.Errorf("failed to list deployments matching %s: %w", k8slabels.Set(cfg.Deployment), err)
+		}
+
+		for _, d := range list.Items {
+			if _, ok := d.GetLabels()[labels.InfrastructurePartOf]; ok {
+				return nil, nil
+			}
+
+			found.Resources = append(found.Resources, "Deployment/"+d.Namespace+"/"+d.Name)
+			found.Deployments = append(found.Deployments, k8stypes.NamespacedName{Namespace: d.Namespace, Name: d.Name})
+			versions = append(versions, d.GetLabels()[versionLabel])
+		}
+	}
+
+	if len(found.Resources) == 0 {
+		return nil, nil
+	}
+
+	found.Version = oldestVersion(versions)
+
+	if cfg.MinVersion != "" && found.Version != "" && compareVersions(found.Version, cfg.MinVersion) < 0 {
+		found.Incompatible = fmt.Sprintf("version %s is older than the minimum supported version %s", found.Version, cfg.MinVersion)
+	}
+
+	return found, nil
+}
+
+// oldestVersion returns the oldest of the non empty versions, as the one
+// bounding the compatibility of a partially upgraded installation.
+func oldestVersion(versions []string) string {
+	set := make(map[string]struct{}, len(versions))
+	for _, v := range versions {
+		if v != "" {
+			set[v] = struct{}{}
+		}
+	}
+
+	if len(set) == 0 {
+		return ""
+	}
+
+	return slices.MinFunc(slices.Collect(maps.Keys(set)), compareVersions)
+}
@@ -0,0 +1,206 @@
+//nolint:testpackage // testing unexported methods
+package common
+
+import (
+	"context"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func newCRD(name string, crdLabels map[string]string) *extv1.CustomResourceDefinition {
+	return &extv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: crdLabels},
+	}
+}
+
+func newOperatorDeployment(name string, namespace string, deploymentLabels map[string]string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: deploymentLabels},
+	}
+}
+
+func TestDetectExternalInstallation(t *testing.T) {
+	ctx := context.Background()
+
+	cfg := detectConfig{
+		CRDs:       []string{"certificates.cert-manager.io", "issuers.cert-manager.io"},
+		Webhooks:   []string{"cert-manager-webhook"},
+		Deployment: map[string]string{appNameLabel: "cert-manager"},
+		MinVersion: "v1.13.0",
+	}
+
+	tests := []struct {
+		name     string
+		objects  []client.Object
+		expected *ExternalInstallation
+	}{
+		{
+			name:     "nothing installed",
+			expected: nil,
+		},
+		{
+			name: "installation revealed by CRDs, webhooks and deployments",
+			objects: []client.Object{
+				newCRD("certificates.cert-manager.io", map[string]string{versionLabel: "v1.15.2"}),
+				newCRD("issuers.cert-manager.io", map[string]string{versionLabel: "v1.15.2"}),
+				&admissionregistrationv1.ValidatingWebhookConfiguration{
+					ObjectMeta: metav1.ObjectMeta{Name: "cert-manager-webhook"},
+				},
+				newOperatorDeployment("cert-manager", "cert-manager", map[string]string{
+					appNameLabel: "cert-manager",
+					versionLabel: "v1.15.2",
+				}),
+			},
+			expected: &ExternalInstallation{
+				Version: "v1.15.2",
+				Resources: []string{
+					"CustomResourceDefinition/certificates.cert-manager.io",
+					"CustomResourceDefinition/issuers.cert-manager.io",
+					"ValidatingWebhookConfiguration/cert-manager-webhook",
+					"Deployment/cert-manager/cert-manager",
+				},
+				Deployments: []k8stypes.NamespacedName{{Namespace: "cert-manager", Name: "cert-manager"}},
+			},
+		},
+		{
+			name: "partially upgraded installation reports the oldest version",
+			objects: []client.Object{
+				newCRD("certificates.cert-manager.io", map[string]string{versionLabel: "v1.16.0"}),
+				newOperatorDeployment("cert-manager", "cert-manager", map[string]string{
+					appNameLabel: "cert-manager",
+					versionLabel: "v1.14.0",
+				}),
+			},
+			expected: &ExternalInstallation{
+				Version: "v1.14.0",
+				Resources: []string{
+					"CustomResourceDefinition/certificates.cert-manager.io",
+					"Deployment/cert-manager/cert-manager",
+				},
+				Deployments: []k8stypes.NamespacedName{{Namespace: "cert-manager", Name: "cert-manager"}},
+			},
+		},
+		{
+			name: "installation older than the minimum version is incompatible",
+			objects: []client.Object{
+				newCRD("certificates.cert-manager.io", map[string]string{versionLabel: "v1.12.3"}),
+			},
+			expected: &ExternalInstallation{
+				Version:      "v1.12.3",
+				Resources:    []string{"CustomResourceDefinition/certificates.cert-manager.io"},
+				Incompatible: "version v1.12.3 is older than the minimum supported version v1.13.0",
+			},
+		},
+		{
+			name: "installation of the cloud manager is not external",
+			objects: []client.Object{
+				newCRD("certificates.cert-manager.io", map[string]string{labels.InfrastructurePartOf: "awskubernetesengine"}),
+				newOperatorDeployment("cert-manager", "cert-manager", map[string]string{appNameLabel: "cert-manager"}),
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			found, err := detectExternalInstallation(ctx, newFakeClient(t, fakeclient.WithObjects(tt.objects...)), cfg)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(found).To(Equal(tt.expected))
+		})
+	}
+
+	t.Run("gateway API CRDs report the bundle version", func(t *testing.T) {
+		g := NewWithT(t)
+
+		crd := newCRD("gateways.gateway.networking.k8s.io", nil)
+		crd.Annotations = map[string]string{gatewayAPIBundleVersion: "v1.2.1"}
+
+		found, err := detectExternalInstallation(ctx, newFakeClient(t, fakeclient.WithObjects(crd)), detectConfig{
+			CRDs:       []string{"gateways.gateway.networking.k8s.io"},
+			MinVersion: "v1.0.0",
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).NotTo(BeNil())
+		g.Expect(found.Version).To(Equal("v1.2.1"))
+		g.Expect(found.Incompatible).To(BeEmpty())
+	})
+}
+
+func TestBuildHelmChartsAuto(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("auto dependency without an existing installation is installed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := getAllUnmanagedDependencies()
+		deps.LWS.ManagementPolicy = ccmcommon.Auto
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts).To(HaveLen(1))
+		g.Expect(result.Charts[0].ReleaseName).To(Equal("lws-operator"))
+
+		cfg := result.MonitorConfigs[2]
+		g.Expect(cfg.Policy).To(Equal(ccmcommon.Auto))
+		g.Expect(cfg.External).To(BeNil())
+	})
+
+	t.Run("auto dependency with an existing installation is adopted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := getAllUnmanagedDependencies()
+		deps.LWS.ManagementPolicy = ccmcommon.Auto
+
+		cli := newFakeClient(t, fakeclient.WithObjects(
+			newCRD("leaderworkersets.leaderworkerset.x-k8s.io", map[string]string{versionLabel: "v0.6.1"}),
+		))
+
+		result, err := BuildHelmCharts(ctx, cli, deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts).To(BeEmpty())
+		g.Expect(result.CleanupCharts).NotTo(ContainElement(HaveField("ReleaseName", "lws-operator")))
+		g.Expect(result.FilterCRs).To(BeEmpty())
+
+		cfg := result.MonitorConfigs[2]
+		g.Expect(cfg.Policy).To(Equal(ccmcommon.Auto))
+		g.Expect(cfg.Version).To(Equal("v0.6.1"))
+		g.Expect(cfg.ChartState).To(Equal(ccmcommon.ChartStateExternal))
+		g.Expect(cfg.External).To(Equal(&ExternalInstallation{
+			Version:   "v0.6.1",
+			Resources: []string{"CustomResourceDefinition/leaderworkersets.leaderworkerset.x-k8s.io"},
+		}))
+	})
+
+	t.Run("auto dependency installed by the cloud manager stays installed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := getAllUnmanagedDependencies()
+		deps.LWS.ManagementPolicy = ccmcommon.Auto
+
+		cli := newFakeClient(t, fakeclient.WithObjects(
+			newCRD("leaderworkersets.leaderworkerset.x-k8s.io", map[string]string{labels.InfrastructurePartOf: "awskubernetesengine"}),
+		))
+
+		result, err := BuildHelmCharts(ctx, cli, deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts).To(HaveLen(1))
+		g.Expect(result.MonitorConfigs[2].External).To(BeNil())
+	})
+}
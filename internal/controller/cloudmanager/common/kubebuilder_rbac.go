@@ -47,6 +47,18 @@ package common
 // lws-operator
 // +kubebuilder:rbac:groups="operator.openshift.io",resources=leaderworkersetoperators,verbs=get;list;watch;create;patch;update;delete
 
+// kueue-operator
+// +kubebuilder:rbac:groups="kueue.openshift.io",resources=kueues,verbs=get;list;watch;create;patch;update;delete
+
+// prometheus-operator
+// +kubebuilder:rbac:groups="monitoring.coreos.com",resources=prometheuses,verbs=get;list;watch;create;patch;update;delete
+
+// keda
+// +kubebuilder:rbac:groups="keda.sh",resources=kedacontrollers,verbs=get;list;watch;create;patch;update;delete
+
+// Image mirror rules of disconnected OpenShift clusters
+// +kubebuilder:rbac:groups="config.openshift.io",resources=imagedigestmirrorsets,verbs=get;list;watch
+
 // Webhook annotations for sail-operator workaround (OSSM-12397)
 // TODO(OSSM-12397): Remove once the sail-operator ships a fix.
 // +kubebuilder:rbac:groups="admissionregistration.k8s.io",resources=mutatingwebhookconfigurations,verbs=get;list;watch;patch
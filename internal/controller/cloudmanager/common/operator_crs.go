@@ -20,4 +20,30 @@ var (
 		GVK:  gvk.Istio,
 		Name: "default",
 	}
+
+	KueueOperatorCR = types.OperatorCR{
+		GVK:  gvk.KueueConfigV1,
+		Name: "cluster",
+	}
 )
+
+// PrometheusOperatorCR returns the Prometheus instance deployed by the
+// prometheus-operator chart in the given namespace.
+func PrometheusOperatorCR(namespace string) types.OperatorCR {
+	return types.OperatorCR{
+		GVK:       gvk.CoreosPrometheus,
+		Name:      "prometheus",
+		Namespace: namespace,
+	}
+}
+
+// KEDAOperatorCR returns the KedaController deployed by the keda chart. The
+// KEDA operator only reconciles a KedaController named keda living in its
+// own namespace.
+func KEDAOperatorCR(namespace string) types.OperatorCR {
+	return types.OperatorCR{
+		GVK:       gvk.KedaControllerV1Alpha1,
+		Name:      "keda",
+		Namespace: namespace,
+	}
+}
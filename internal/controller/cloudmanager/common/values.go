@@ -0,0 +1,135 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+// valuesOverride returns the values overriding the bundled chart values of a
+// dependency: the free-form values of the configuration, with the values of
+// its typed fields merged over them.
+func valuesOverride(override ccmcommon.ValuesOverride, typed ...any) (map[string]any, error) {
+	values := map[string]any{}
+
+	if override.Values != nil && len(override.Values.Raw) > 0 {
+		if err := json.Unmarshal(override.Values.Raw, &values); err != nil {
+			return nil, fmt.Errorf("values must be an object: %w", err)
+		}
+	}
+
+	for _, t := range typed {
+		v, err := toValues(t)
+		if err != nil {
+			return nil, err
+		}
+
+		values = mergeValues(values, v)
+	}
+
+	return values, nil
+}
+
+// toValues converts a typed configuration to chart values through its JSON
+// representation, so that the values match the documented API fields.
+func toValues(in any) (map[string]any, error) {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode values: %w", err)
+	}
+
+	values := map[string]any{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to decode values: %w", err)
+	}
+
+	return values, nil
+}
+
+// applyValuesOverride deep merges values over the bundled values of chart.
+// The values set by the operator, that is the ones of the chart definition,
+// cannot be overridden. When any value is
+// overridden, the pods of the chart deployments are annotated with the hash
+// of the override, so that they are rolled out when it changes even if it
+// only affects other resources, such as configuration maps.
+func applyValuesOverride(ctx context.Context, chart types.HelmChartInfo, values map[string]any) (types.HelmChartInfo, error) {
+	if len(values) == 0 {
+		return chart, nil
+	}
+
+	var reserved []string
+
+	if chart.Values != nil {
+		base, err := chart.Values(ctx)
+		if err != nil {
+			return chart, err
+		}
+
+		for k := range base {
+			reserved = append(reserved, k)
+		}
+	}
+
+	for _, k := range slices.Sorted(maps.Keys(values)) {
+		if slices.Contains(reserved, k) {
+			return chart, fmt.Errorf("value %s is set by the operator and cannot be overridden", k)
+		}
+	}
+
+	hash, err := valuesHash(values)
+	if err != nil {
+		return chart, err
+	}
+
+	chart = WithValues([]types.HelmChartInfo{chart}, "", values)[0]
+	chart.PostRenderers = append(slices.Clone(chart.PostRenderers), annotateValuesHash(hash))
+
+	return chart, nil
+}
+
+func valuesHash(values map[string]any) (string, error) {
+	// maps are encoded with sorted keys, so the hash is deterministic
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode values: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// annotateValuesHash returns a post renderer recording the hash of the values
+// override on the pod template of every Deployment of a chart.
+func annotateValuesHash(hash string) engineTypes.PostRenderer {
+	deployment := schema.GroupKind{Group: gvk.Deployment.Group, Kind: gvk.Deployment.Kind}
+
+	return func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		for i := range objects {
+			if objects[i].GroupVersionKind().GroupKind() != deployment {
+				continue
+			}
+
+			err := unstructured.SetNestedField(objects[i].Object, hash,
+				"spec", "template", "metadata", "annotations", annotations.DependencyValuesHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to annotate deployment %s: %w", objects[i].GetName(), err)
+			}
+		}
+
+		return objects, nil
+	}
+}
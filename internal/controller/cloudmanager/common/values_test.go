@@ -0,0 +1,168 @@
+//nolint:testpackage // testing unexported methods
+package common
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+
+	. "github.com/onsi/gomega"
+)
+
+func chartValues(g *WithT, chart types.HelmChartInfo) map[string]any {
+	values, err := chart.Values(context.Background())
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return values
+}
+
+func renderDeployment(g *WithT, chart types.HelmChartInfo) unstructured.Unstructured {
+	deployment := unstructured.Unstructured{}
+	deployment.SetAPIVersion("apps/v1")
+	deployment.SetKind("Deployment")
+	deployment.SetName("operator")
+
+	objects := []unstructured.Unstructured{deployment}
+	for _, pr := range chart.PostRenderers {
+		var err error
+
+		objects, err = pr(context.Background(), objects)
+		g.Expect(err).NotTo(HaveOccurred())
+	}
+
+	return objects[0]
+}
+
+func TestBuildHelmChartsValuesOverride(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("typed fields and values are merged over the bundled values", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.Configuration = ccmcommon.LWSConfiguration{
+			DeploymentSettings: ccmcommon.DeploymentSettings{
+				Replicas: ptr.To[int32](2),
+				Resources: &corev1.ResourceRequirements{
+					Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				},
+				NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+				Tolerations: []corev1.Toleration{{
+					Key:      "node-role.kubernetes.io/infra",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+			},
+			ValuesOverride: ccmcommon.ValuesOverride{
+				Values: &runtime.RawExtension{Raw: []byte(`{"replicas": 5, "logLevel": "debug"}`)},
+			},
+		}
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(chartValues(g, result.Charts[2])).To(Equal(map[string]any{
+			"namespace": ccmcommon.DefaultNamespaceLWSOperator,
+			"replicas":  float64(2),
+			"logLevel":  "debug",
+			"resources": map[string]any{
+				"limits": map[string]any{"memory": "512Mi"},
+			},
+			"nodeSelector": map[string]any{"node-role.kubernetes.io/infra": ""},
+			"tolerations": []any{map[string]any{
+				"key":      "node-role.kubernetes.io/infra",
+				"operator": "Exists",
+				"effect":   "NoSchedule",
+			}},
+		}))
+	})
+
+	t.Run("sail operator mesh config is passed as meshConfig", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.SailOperator.Configuration.MeshConfig = &ccmcommon.MeshConfig{
+			AccessLogFile:         "/dev/stdout",
+			AccessLogEncoding:     "JSON",
+			OutboundTrafficPolicy: &ccmcommon.OutboundTrafficPolicy{Mode: "REGISTRY_ONLY"},
+		}
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(chartValues(g, result.Charts[3])).To(HaveKeyWithValue("meshConfig", map[string]any{
+			"accessLogFile":         "/dev/stdout",
+			"accessLogEncoding":     "JSON",
+			"outboundTrafficPolicy": map[string]any{"mode": "REGISTRY_ONLY"},
+		}))
+	})
+
+	t.Run("deployments are annotated with the hash of the override", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.KEDA.ManagementPolicy = ccmcommon.Managed
+		deps.KEDA.Configuration.Replicas = ptr.To[int32](2)
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		deployment := renderDeployment(g, result.Charts[4])
+		hash, found, err := unstructured.NestedString(deployment.Object,
+			"spec", "template", "metadata", "annotations", annotations.DependencyValuesHash)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+
+		deps.KEDA.Configuration.Replicas = ptr.To[int32](3)
+
+		result, err = BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		deployment = renderDeployment(g, result.Charts[4])
+		g.Expect(deployment.Object).To(HaveKeyWithValue("spec", HaveKeyWithValue("template",
+			HaveKeyWithValue("metadata", HaveKeyWithValue("annotations",
+				HaveKeyWithValue(annotations.DependencyValuesHash, Not(Equal(hash))))))))
+	})
+
+	t.Run("charts without override are left untouched", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), ccmcommon.Dependencies{}, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		for _, chart := range result.Charts {
+			g.Expect(chart.PostRenderers).To(BeEmpty(), "chart %s", chart.ReleaseName)
+		}
+	})
+
+	t.Run("values set by the operator cannot be overridden", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.Kueue.ManagementPolicy = ccmcommon.Managed
+		deps.Kueue.Configuration.Values = &runtime.RawExtension{Raw: []byte(`{"namespace": "other"}`)}
+
+		_, err := BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).To(MatchError(
+			"invalid configuration of kueue-operator: value namespace is set by the operator and cannot be overridden"))
+	})
+
+	t.Run("invalid values of an unmanaged dependency do not fail the build", func(t *testing.T) {
+		g := NewWithT(t)
+
+		deps := getAllUnmanagedDependencies()
+		deps.Kueue.Configuration.Values = &runtime.RawExtension{Raw: []byte(`{"namespace": "other"}`)}
+
+		_, err := BuildHelmCharts(ctx, newFakeClient(t), deps, testChartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+	})
+}
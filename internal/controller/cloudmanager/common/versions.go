@@ -0,0 +1,256 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	appsv1 "k8s.io/api/apps/v1"
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// BundledVersionSeparator separates the chart name from the version in the
+// directory of an additional bundled chart version, e.g. lws-operator@1.0.0.
+const BundledVersionSeparator = "@"
+
+// BundledChart is a version of a dependency chart bundled with the operator.
+type BundledChart struct {
+	Version string
+	Path    string
+}
+
+// BundledChartVersions returns the bundled versions of the chart named name,
+// newest first. The default version is bundled in <chartsPath>/<name>, any
+// additional version side by side in <chartsPath>/<name>@<version>. The
+// version of each chart is read from its Chart.yaml; directories without a
+// Chart.yaml are ignored.
+func BundledChartVersions(chartsPath string, name string) ([]BundledChart, error) {
+	dirs, err := filepath.Glob(filepath.Join(chartsPath, name+BundledVersionSeparator+"*"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bundled versions of %s: %w", name, err)
+	}
+
+	dirs = slices.Insert(dirs, 0, filepath.Join(chartsPath, name))
+
+	bundled := make([]BundledChart, 0, len(dirs))
+
+	for _, dir := range dirs {
+		version, err := readChartVersion(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		// the default chart wins over an additional directory of the same version
+		if slices.ContainsFunc(bundled, func(c BundledChart) bool { return c.Version == version }) {
+			continue
+		}
+
+		bundled = append(bundled, BundledChart{Version: version, Path: dir})
+	}
+
+	slices.SortStableFunc(bundled, func(a, b BundledChart) int {
+		return compareVersions(b.Version, a.Version)
+	})
+
+	return bundled, nil
+}
+
+func readChartVersion(dir string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(dir, "Chart.yaml"))
+	if err != nil {
+		return "", err
+	}
+
+	chart := struct {
+		Version string `json:"version"`
+	}{}
+
+	if err := yaml.Unmarshal(content, &chart); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", filepath.Join(dir, "Chart.yaml"), err)
+	}
+	if chart.Version == "" {
+		return "", fmt.Errorf("chart %s has no version", dir)
+	}
+
+	return chart.Version, nil
+}
+
+// compareVersions compares two chart versions as semantic versions, falling
+// back to a lexical comparison when any of them is not a valid one.
+func compareVersions(a, b string) int {
+	va, errA := semver.ParseTolerant(a)
+	vb, errB := semver.ParseTolerant(b)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+
+	return va.Compare(vb)
+}
+
+// chartVersion is the chart version selected for a dependency.
+type chartVersion struct {
+	path string
+	// version is the selected version, empty when no bundled chart declares one.
+	version string
+	// available is the newest bundled version.
+	available string
+}
+
+// selectChartVersion selects, among the bundled versions of the chart of def,
+// the one to render according to the version policy of the dependency.
+func selectChartVersion(
+	ctx context.Context,
+	cli client.Client,
+	chartsPath string,
+	def chartDef,
+	policy ccmcommon.VersionPolicy,
+) (chartVersion, error) {
+	name := def.chart.ReleaseName
+
+	bundled, err := BundledChartVersions(chartsPath, name)
+	if err != nil {
+		return chartVersion{}, err
+	}
+
+	if len(bundled) == 0 {
+		if policy.Version != "" && (policy.UpgradePolicy == ccmcommon.UpgradePinned || policy.UpgradePolicy == ccmcommon.UpgradeManual) {
+			return chartVersion{}, fmt.Errorf("version %s of %s is not bundled", policy.Version, name)
+		}
+
+		return chartVersion{path: filepath.Join(chartsPath, name)}, nil
+	}
+
+	latest := bundled[0]
+
+	find := func(version string) (BundledChart, bool) {
+		i := slices.IndexFunc(bundled, func(c BundledChart) bool {
+			return compareVersions(c.Version, version) == 0
+		})
+		if i < 0 {
+			return BundledChart{}, false
+		}
+
+		return bundled[i], true
+	}
+
+	selected := latest
+
+	switch policy.UpgradePolicy {
+	case ccmcommon.UpgradePinned, ccmcommon.UpgradeManual:
+		version := policy.Version
+
+		if version == "" && policy.UpgradePolicy == ccmcommon.UpgradeManual {
+			version, err = installedChartVersion(ctx, cli, name, def.monitor.Namespace, def.monitor.HasDeployments)
+			if err != nil {
+				return chartVersion{}, err
+			}
+		}
+
+		if version == "" {
+			break
+		}
+
+		c, ok := find(version)
+		if !ok {
+			if policy.Version == "" {
+				// the installed version cannot be kept, and upgrading it is
+				// left to the user
+				return chartVersion{}, fmt.Errorf(
+					"installed version %s of %s is not bundled, set the version to upgrade to, available versions: %s",
+					version, name, strings.Join(bundledVersions(bundled), ", "))
+			}
+
+			return chartVersion{}, fmt.Errorf("version %s of %s is not bundled, available versions: %s",
+				policy.Version, name, strings.Join(bundledVersions(bundled), ", "))
+		}
+
+		selected = c
+	default:
+		// Automatic: follow the newest bundled version
+	}
+
+	return chartVersion{
+		path:      selected.Path,
+		version:   selected.Version,
+		available: latest.Version,
+	}, nil
+}
+
+func bundledVersions(bundled []BundledChart) []string {
+	versions := make([]string, 0, len(bundled))
+	for _, c := range bundled {
+		versions = append(versions, c.Version)
+	}
+
+	return versions
+}
+
+// installedChartVersion returns the version of the chart named name deployed
+// on the cluster, as recorded on its resources by annotateChartVersion. The
+// operator deployments of the dependency are looked up in namespace, or its
+// CRDs for dependencies without deployments. An empty version is returned
+// when the chart is not installed.
+func installedChartVersion(ctx context.Context, cli client.Client, name string, namespace string, hasDeployments bool) (string, error) {
+	var objects []client.Object
+
+	if hasDeployments {
+		list := &appsv1.DeploymentList{}
+		if err := cli.List(ctx, list, client.InNamespace(namespace), client.HasLabels{labels.InfrastructurePartOf}); err != nil {
+			return "", fmt.Errorf("failed to list deployments of %s: %w", name, err)
+		}
+
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	} else {
+		list := &extv1.CustomResourceDefinitionList{}
+		if err := cli.List(ctx, list, client.HasLabels{labels.InfrastructurePartOf}); err != nil {
+			return "", fmt.Errorf("failed to list CRDs of %s: %w", name, err)
+		}
+
+		for i := range list.Items {
+			objects = append(objects, &list.Items[i])
+		}
+	}
+
+	for _, obj := range objects {
+		a := obj.GetAnnotations()
+		if a[annotations.DependencyChart] == name && a[annotations.DependencyChartVersion] != "" {
+			return a[annotations.DependencyChartVersion], nil
+		}
+	}
+
+	return "", nil
+}
+
+// annotateChartVersion returns a post renderer recording on every resource of
+// a chart the chart it was rendered from and its version, so the installed
+// version can be found on the cluster.
+func annotateChartVersion(name string, version string) engineTypes.PostRenderer {
+	return func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+		for i := range objects {
+			resources.SetAnnotation(&objects[i], annotations.DependencyChart, name)
+			resources.SetAnnotation(&objects[i], annotations.DependencyChartVersion, version)
+		}
+
+		return objects, nil
+	}
+}
@@ -0,0 +1,227 @@
+//nolint:testpackage // testing unexported methods
+package common
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func writeChart(t *testing.T, chartsPath string, dir string, version string) {
+	t.Helper()
+
+	path := filepath.Join(chartsPath, dir)
+	NewWithT(t).Expect(os.MkdirAll(path, 0o755)).To(Succeed())
+	NewWithT(t).Expect(os.WriteFile(
+		filepath.Join(path, "Chart.yaml"),
+		[]byte("apiVersion: v2\nname: lws-operator\nversion: "+version+"\n"),
+		0o600,
+	)).To(Succeed())
+}
+
+// newBundledCharts bundles lws-operator 1.1.0 as the default version, and
+// 1.0.0 and 0.9.0 side by side.
+func newBundledCharts(t *testing.T) string {
+	t.Helper()
+
+	chartsPath := t.TempDir()
+	writeChart(t, chartsPath, "lws-operator", "1.1.0")
+	writeChart(t, chartsPath, "lws-operator@0.9.0", "0.9.0")
+	writeChart(t, chartsPath, "lws-operator@1.0.0", "1.0.0")
+
+	return chartsPath
+}
+
+func TestBundledChartVersions(t *testing.T) {
+	t.Run("returns bundled versions newest first", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		bundled, err := BundledChartVersions(chartsPath, "lws-operator")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(bundled).To(Equal([]BundledChart{
+			{Version: "1.1.0", Path: filepath.Join(chartsPath, "lws-operator")},
+			{Version: "1.0.0", Path: filepath.Join(chartsPath, "lws-operator@1.0.0")},
+			{Version: "0.9.0", Path: filepath.Join(chartsPath, "lws-operator@0.9.0")},
+		}))
+	})
+
+	t.Run("returns nothing when the chart is not bundled", func(t *testing.T) {
+		g := NewWithT(t)
+
+		bundled, err := BundledChartVersions(t.TempDir(), "lws-operator")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(bundled).To(BeEmpty())
+	})
+}
+
+func TestBuildHelmChartsVersions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("automatic policy renders the newest bundled version", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), ccmcommon.Dependencies{}, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts[2].Chart).To(Equal(filepath.Join(chartsPath, "lws-operator")))
+
+		cfg := result.MonitorConfigs[2]
+		g.Expect(cfg.Version).To(Equal("1.1.0"))
+		g.Expect(cfg.AvailableVersion).To(Equal("1.1.0"))
+	})
+
+	t.Run("pinned policy renders the requested version", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradePinned
+		deps.LWS.Version = "1.0.0"
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts[2].Chart).To(Equal(filepath.Join(chartsPath, "lws-operator@1.0.0")))
+
+		cfg := result.MonitorConfigs[2]
+		g.Expect(cfg.Version).To(Equal("1.0.0"))
+		g.Expect(cfg.AvailableVersion).To(Equal("1.1.0"))
+	})
+
+	t.Run("pinned policy fails when the version is not bundled", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradePinned
+		deps.LWS.Version = "2.0.0"
+
+		_, err := BuildHelmCharts(ctx, newFakeClient(t), deps, chartsPath)
+		g.Expect(err).To(MatchError(ContainSubstring("version 2.0.0 of lws-operator is not bundled, available versions: 1.1.0, 1.0.0, 0.9.0")))
+	})
+
+	t.Run("unmanaged dependency with a version not bundled is removed at the default version", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		deps := getAllUnmanagedDependencies()
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradePinned
+		deps.LWS.Version = "2.0.0"
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.CleanupCharts).To(ContainElement(HaveField("Source.Chart", filepath.Join(chartsPath, "lws-operator"))))
+	})
+
+	t.Run("manual policy keeps the installed version", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		installed := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "openshift-lws-operator",
+				Namespace: ccmcommon.DefaultNamespaceLWSOperator,
+				Labels:    map[string]string{labels.InfrastructurePartOf: "awskubernetesengine"},
+				Annotations: map[string]string{
+					annotations.DependencyChart:        "lws-operator",
+					annotations.DependencyChartVersion: "0.9.0",
+				},
+			},
+		}
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradeManual
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t, fakeclient.WithObjects(installed)), deps, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts[2].Chart).To(Equal(filepath.Join(chartsPath, "lws-operator@0.9.0")))
+		g.Expect(result.MonitorConfigs[2].Version).To(Equal("0.9.0"))
+	})
+
+	t.Run("manual policy fails when the installed version is not bundled", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		installed := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "openshift-lws-operator",
+				Namespace: ccmcommon.DefaultNamespaceLWSOperator,
+				Labels:    map[string]string{labels.InfrastructurePartOf: "awskubernetesengine"},
+				Annotations: map[string]string{
+					annotations.DependencyChart:        "lws-operator",
+					annotations.DependencyChartVersion: "0.8.0",
+				},
+			},
+		}
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradeManual
+
+		_, err := BuildHelmCharts(ctx, newFakeClient(t, fakeclient.WithObjects(installed)), deps, chartsPath)
+		g.Expect(err).To(MatchError(ContainSubstring(
+			"installed version 0.8.0 of lws-operator is not bundled, set the version to upgrade to, available versions: 1.1.0, 1.0.0, 0.9.0")))
+	})
+
+	t.Run("manual policy installs the newest version when not installed", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradeManual
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts[2].Chart).To(Equal(filepath.Join(chartsPath, "lws-operator")))
+	})
+
+	t.Run("manual policy upgrades to the requested version", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		deps := ccmcommon.Dependencies{}
+		deps.LWS.UpgradePolicy = ccmcommon.UpgradeManual
+		deps.LWS.Version = "v1.0"
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), deps, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts[2].Chart).To(Equal(filepath.Join(chartsPath, "lws-operator@1.0.0")))
+	})
+
+	t.Run("rendered resources record the chart version", func(t *testing.T) {
+		g := NewWithT(t)
+		chartsPath := newBundledCharts(t)
+
+		result, err := BuildHelmCharts(ctx, newFakeClient(t), ccmcommon.Dependencies{}, chartsPath)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		lws := result.Charts[2]
+		g.Expect(lws.PostRenderers).To(HaveLen(1))
+
+		objects, err := lws.PostRenderers[0](ctx, []unstructured.Unstructured{{Object: map[string]any{}}})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(objects[0].GetAnnotations()).To(Equal(map[string]string{
+			annotations.DependencyChart:        "lws-operator",
+			annotations.DependencyChartVersion: "1.1.0",
+		}))
+
+		// charts without a bundled version are not annotated
+		g.Expect(result.Charts[3].PostRenderers).To(BeEmpty())
+	})
+}
@@ -0,0 +1,115 @@
+// Package engine provides the reconciler shared by every cloud manager
+// provider. Providers only differ in the KubernetesEngine CR type they
+// reconcile and in the chart overrides and hooks tailoring the dependencies.
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	extv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/cleanup"
+	certmanager "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/dependency/certmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/cloudmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/handlers"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/operatorconfig"
+)
+
+// KubernetesEngine describes a cloud manager provider: the KubernetesEngine
+// CR type T, the name of its singleton instance and the chart overrides
+// applied to the dependencies.
+type KubernetesEngine[T ccmcommon.KubernetesEngineInstance] struct {
+	object       T
+	instanceName string
+	overrides    []cloudmanager.ChartOverride
+}
+
+// Option configures a KubernetesEngine.
+type Option func(*options)
+
+type options struct {
+	overrides []cloudmanager.ChartOverride
+}
+
+// WithChartOverrides adds provider-specific chart values and hooks.
+func WithChartOverrides(overrides ...cloudmanager.ChartOverride) Option {
+	return func(o *options) {
+		o.overrides = append(o.overrides, overrides...)
+	}
+}
+
+// New returns the KubernetesEngine reconciling objects of the type of object,
+// whose singleton instance is named instanceName.
+func New[T ccmcommon.KubernetesEngineInstance](object T, instanceName string, opts ...Option) *KubernetesEngine[T] {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &KubernetesEngine[T]{
+		object:       object,
+		instanceName: instanceName,
+		overrides:    o.overrides,
+	}
+}
+
+// Object returns an empty object of the KubernetesEngine CR type.
+func (e *KubernetesEngine[T]) Object() T {
+	return e.object
+}
+
+// InstanceName returns the name of the singleton instance of the
+// KubernetesEngine CR.
+func (e *KubernetesEngine[T]) InstanceName() string {
+	return e.instanceName
+}
+
+// NewReconciler sets up the controller of the KubernetesEngine and registers
+// it with the manager.
+func (e *KubernetesEngine[T]) NewReconciler(ctx context.Context, mgr ctrl.Manager, cfg *operatorconfig.CloudManagerConfig) error {
+	if e.instanceName == "" {
+		return errors.New("instance name is required")
+	}
+
+	objGVK, err := mgr.GetClient().GroupVersionKindFor(e.object)
+	if err != nil {
+		return fmt.Errorf("unable to determine GVK: %w", err)
+	}
+
+	resourceID := labels.NormalizePartOfValue(objGVK.Kind)
+	bootstrapConfig := certmanager.DefaultBootstrapConfig(certmanager.WithOperatorCert(cfg.RhaiOperatorNamespace))
+
+	_, err = reconciler.ReconcilerFor(mgr, e.object).
+		WithDynamicOwnership(common.OperatorCRGVKPredicates()).
+		Watches(
+			&extv1.CustomResourceDefinition{},
+			reconciler.WithEventHandler(handlers.ToNamed(e.instanceName)),
+			reconciler.WithPredicates(resources.CreatedOrUpdatedOrDeletedNamed(common.ServiceMonitorCRDName)),
+		).
+		ComposeWith(certmanager.Bootstrap[T](
+			e.instanceName,
+			bootstrapConfig,
+		)).
+		WithActionE(cloudmanager.NewReconcileAction(resourceID,
+			cloudmanager.WithChartOverrides(e.overrides...),
+			cloudmanager.WithAPIReader(mgr.GetAPIReader()),
+		)).
+		// GC must be last: evaluates every CCM resource and removes stale or orphaned ones.
+		WithActionE(cloudmanager.NewGCAction(resourceID, cfg.RhaiOperatorNamespace,
+			cloudmanager.BootstrapProtectedObjects(bootstrapConfig),
+		)).
+		WithFinalizer(cleanup.NewFinalizer(
+			cloudmanager.FinalizerCleanupTargets()...,
+		)).
+		WithConditions(cloudmanager.ConditionsTypes...).
+		Build(ctx)
+	return err
+}
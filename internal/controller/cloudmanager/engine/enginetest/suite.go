@@ -0,0 +1,571 @@
+// Package enginetest provides the envtest suite shared by every cloud manager
+// provider built on engine.KubernetesEngine. Providers only supply their
+// KubernetesEngine; the suite derives the CR type, the instance name, the
+// CRD directory and the infrastructure label from it.
+//
+// The CRD directory is the provider segment of the API package of the CR
+// type: the CRDs of api/cloudmanager/<provider>/v1alpha1 are loaded from
+// config/cloudmanager/<provider>/crd/bases.
+package enginetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	ccmtest "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/cloudmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/envt"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/testf"
+
+	. "github.com/onsi/gomega"
+)
+
+const nsCertManagerOperator = "cert-manager-operator"
+
+// Suite runs the envtest tests shared by every KubernetesEngine provider.
+type Suite[T ccmcommon.KubernetesEngineInstance] struct {
+	engine *engine.KubernetesEngine[T]
+	cfg    ccmtest.ControllerTestConfig
+	tc     *testf.TestContext
+}
+
+// New returns the Suite testing the given KubernetesEngine. It panics if the
+// CR type of the KubernetesEngine is not registered in the test scheme.
+func New[T ccmcommon.KubernetesEngineInstance](e *engine.KubernetesEngine[T]) *Suite[T] {
+	s, err := scheme.New()
+	if err != nil {
+		panic(fmt.Sprintf("unable to create the test scheme: %v", err))
+	}
+
+	objGVK, err := apiutil.GVKForObject(e.Object(), s)
+	if err != nil {
+		panic(fmt.Sprintf("unable to get the GVK of the KubernetesEngine: %v", err))
+	}
+
+	suite := &Suite[T]{
+		engine: e,
+	}
+
+	suite.cfg = ccmtest.ControllerTestConfig{
+		CRDSubdir:     path.Base(path.Dir(reflect.TypeOf(e.Object()).Elem().PkgPath())),
+		NewReconciler: e.NewReconciler,
+		NewCR: func(deps ccmcommon.Dependencies) client.Object {
+			return suite.NewCR(deps)
+		},
+		InstanceName: e.InstanceName(),
+		InfraLabel:   labels.NormalizePartOfValue(objGVK.Kind),
+		GVK:          objGVK,
+	}
+
+	return suite
+}
+
+// Config returns the ControllerTestConfig of the KubernetesEngine.
+func (s *Suite[T]) Config() ccmtest.ControllerTestConfig {
+	return s.cfg
+}
+
+// GVK returns the GroupVersionKind of the KubernetesEngine CR.
+func (s *Suite[T]) GVK() schema.GroupVersionKind {
+	return s.cfg.GVK
+}
+
+// InfraLabel returns the infrastructure label value set on the resources
+// deployed by the KubernetesEngine.
+func (s *Suite[T]) InfraLabel() string {
+	return s.cfg.InfraLabel
+}
+
+// RunTestMain starts the envtest environment shared by the tests of the
+// package and runs them. It is meant to be called from TestMain.
+func (s *Suite[T]) RunTestMain(m *testing.M) {
+	ccmtest.RunTestMain(m, &s.tc, s.cfg)
+}
+
+// NewWithT returns a testf.WithT bound to the shared envtest environment.
+func (s *Suite[T]) NewWithT(t *testing.T) *testf.WithT {
+	t.Helper()
+
+	return s.tc.NewWithT(t)
+}
+
+// NewCR returns the singleton instance of the KubernetesEngine CR with the
+// given dependencies.
+func (s *Suite[T]) NewCR(deps ccmcommon.Dependencies) T {
+	obj, ok := s.engine.Object().DeepCopyObject().(T)
+	if !ok {
+		panic(fmt.Sprintf("unexpected type %T", s.engine.Object()))
+	}
+
+	obj.SetName(s.engine.InstanceName())
+
+	spec, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"dependencies": deps,
+		},
+	})
+	if err != nil {
+		panic(fmt.Sprintf("unable to marshal the dependencies: %v", err))
+	}
+
+	if err := json.Unmarshal(spec, obj); err != nil {
+		panic(fmt.Sprintf("unable to set the dependencies: %v", err))
+	}
+
+	return obj
+}
+
+// CreateCR creates the singleton instance of the KubernetesEngine CR with the
+// given dependencies, after applying the mutate functions, and registers its
+// cleanup.
+func (s *Suite[T]) CreateCR(t *testing.T, wt *testf.WithT, deps ccmcommon.Dependencies, mutate ...func(T)) {
+	t.Helper()
+
+	cfg := s.cfg
+	cfg.NewCR = func(deps ccmcommon.Dependencies) client.Object {
+		obj := s.NewCR(deps)
+		for _, fn := range mutate {
+			fn(obj)
+		}
+
+		return obj
+	}
+
+	ccmtest.CreateCR(t, wt, cfg, deps)
+}
+
+// Get returns the singleton instance of the KubernetesEngine CR.
+func (s *Suite[T]) Get(wt *testf.WithT) T {
+	obj, ok := s.engine.Object().DeepCopyObject().(T)
+	if !ok {
+		panic(fmt.Sprintf("unexpected type %T", s.engine.Object()))
+	}
+
+	wt.Expect(wt.Client().Get(wt.Context(), s.key(), obj)).To(Succeed())
+
+	return obj
+}
+
+// SetManagementPolicy sets the management policy of a dependency of the
+// singleton instance, using the JSON name of the dependency (e.g. "lws").
+func (s *Suite[T]) SetManagementPolicy(wt *testf.WithT, dependency string, policy ccmcommon.ManagementPolicy) {
+	patch := fmt.Sprintf(`{"spec":{"dependencies":{%q:{"managementPolicy":%q}}}}`, dependency, policy)
+
+	wt.Expect(wt.Client().Patch(wt.Context(), s.Get(wt), client.RawPatch(types.MergePatchType, []byte(patch)))).
+		To(Succeed())
+}
+
+// Run runs every shared test of the suite.
+func (s *Suite[T]) Run(t *testing.T) {
+	t.Helper()
+
+	t.Run("Controller", s.TestController)
+	t.Run("GC", s.TestGC)
+	t.Run("WithoutCertManager", s.TestWithoutCertManager)
+	t.Run("CleanupAction", s.TestCleanupAction)
+}
+
+// TestController tests the deployment of the dependencies by the controller
+// running in the shared envtest environment.
+func (s *Suite[T]) TestController(t *testing.T) {
+	ccmtest.RequireCharts(t)
+
+	t.Run("namespaces do not have owner references", func(t *testing.T) {
+		wt := s.NewWithT(t)
+
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wt.Get(gvk.Namespace, types.NamespacedName{Name: nsCertManagerOperator}).
+			Eventually().Should(
+			jq.Match(`.metadata.ownerReferences == null or (.metadata.ownerReferences | length == 0)`),
+		)
+	})
+
+	t.Run("deploys managed dependencies", func(t *testing.T) {
+		wt := s.NewWithT(t)
+
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Managed},
+			CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+			LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Managed},
+			SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		// Verify dependency deployments are created
+		wt.Eventually(func() bool { return s.hasCertManagerDeployments(wt) }).Should(BeTrue())
+
+		wt.Get(gvk.Deployment, types.NamespacedName{
+			Name: "openshift-lws-operator", Namespace: "openshift-lws-operator",
+		}).Eventually().Should(Not(BeNil()))
+
+		wt.Get(gvk.Deployment, types.NamespacedName{
+			Name: "servicemesh-operator3", Namespace: "istio-system",
+		}).Eventually().Should(Not(BeNil()))
+	})
+
+	t.Run("sets infrastructure label on deployed resources", func(t *testing.T) {
+		wt := s.NewWithT(t)
+
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wt.Get(gvk.Deployment, types.NamespacedName{
+			Name: "cert-manager-operator-controller-manager", Namespace: "cert-manager-operator",
+		}).Eventually().Should(
+			jq.Match(`.metadata.labels."%s" == "%s"`, labels.InfrastructurePartOf, s.cfg.InfraLabel),
+		)
+	})
+
+	t.Run("creates PKI bootstrap resources when cert-manager is installed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		et, wtC := ccmtest.StartIsolatedController(t, ctx, s.cfg)
+		t.Cleanup(cancel) // stop the manager before the test environment (registered after et.Stop, so it runs first)
+
+		_, err := et.RegisterCertManagerCRDs(ctx, envt.WithPermissiveSchema())
+		wtC.Expect(err).NotTo(HaveOccurred())
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"}}
+		if err := et.Client().Create(ctx, ns); err != nil && !k8serr.IsAlreadyExists(err) {
+			wtC.Expect(err).NotTo(HaveOccurred())
+		}
+
+		s.CreateCR(t, wtC, ccmcommon.Dependencies{
+			CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+			LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Managed},
+			SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wtC.Get(gvk.CertManagerClusterIssuer, types.NamespacedName{Name: "opendatahub-selfsigned-issuer"}).
+			Eventually().ShouldNot(BeNil())
+		wtC.Get(gvk.CertManagerCertificate, types.NamespacedName{Name: "opendatahub-ca", Namespace: "cert-manager"}).
+			Eventually().ShouldNot(BeNil())
+		wtC.Get(gvk.CertManagerClusterIssuer, types.NamespacedName{Name: "opendatahub-ca-issuer"}).
+			Eventually().ShouldNot(BeNil())
+
+		wtC.Get(s.cfg.GVK, s.key()).Eventually().Should(
+			jq.Match(`.status.conditions[] | select(.type == "DependenciesAvailable") | .status == "True"`),
+		)
+	})
+}
+
+// TestGC tests garbage collection behavior. All subtests share a single
+// isolated envtest to reduce startup overhead. They run sequentially and each
+// creates/deletes its own CR via CreateCR cleanup. The "protected resources"
+// subtest must be last because it permanently registers cert-manager CRDs.
+func (s *Suite[T]) TestGC(t *testing.T) {
+	ccmtest.RequireCharts(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	et, wt := ccmtest.StartIsolatedController(t, ctx, s.cfg)
+	t.Cleanup(cancel)
+
+	t.Run("deletes resources of dependency that transitions to Unmanaged", func(t *testing.T) {
+		// Start with cert-manager Managed — the controller deploys it.
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		// Wait until the cert-manager Deployment exists.
+		wt.Eventually(func() bool { return s.hasCertManagerDeployments(wt) }).Should(BeTrue())
+
+		// Transition cert-manager to Unmanaged. Helm no longer renders cert-manager
+		// resources, so they retain stale generation annotations. GC deletes them.
+		s.SetManagementPolicy(wt, "certManager", ccmcommon.Unmanaged)
+
+		wt.Eventually(func() bool {
+			list, err := ccmtest.ListInfraDeployments(wt, nsCertManagerOperator, s.cfg.InfraLabel)
+			wt.Expect(err).NotTo(HaveOccurred())
+			if len(list) == 0 {
+				return true
+			}
+			for i := range list {
+				if list[i].GetDeletionTimestamp() == nil {
+					return false
+				}
+			}
+			return true
+		}).Should(BeTrue())
+	})
+
+	t.Run("GC deletes stale resources with mismatched generation", func(t *testing.T) {
+		// Create the CR — after the first reconcile, the CR gets a real UID and generation.
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		// Wait for the CR to be reconciled (cert-manager deployment appears, which means
+		// the reconcile ran and the CR has a non-zero UID and generation).
+		wt.Eventually(func() bool { return s.hasCertManagerDeployments(wt) }).Should(BeTrue())
+
+		// Fetch the CR to obtain its UID.
+		obj := s.Get(wt)
+
+		// Create a ConfigMap that looks like a stale owned CCM resource (wrong generation).
+		// GC only processes owned resources, so the ConfigMap must have an owner reference
+		// matching the CR's GVK.
+		staleCM := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "stale-ccm-resource",
+				Namespace: nsCertManagerOperator,
+				Labels: map[string]string{
+					labels.InfrastructurePartOf: s.cfg.InfraLabel,
+				},
+				Annotations: map[string]string{
+					labels.ODHInfrastructurePrefix + annotations.SuffixInstanceUID: string(obj.GetUID()),
+					// A generation far in the past — will never match the current CR generation.
+					labels.ODHInfrastructurePrefix + annotations.SuffixInstanceGeneration: strconv.FormatInt(-1, 10),
+				},
+				OwnerReferences: []metav1.OwnerReference{s.ownerReference(obj)},
+			},
+		}
+		wt.Expect(wt.Client().Create(wt.Context(), staleCM)).To(Succeed())
+		t.Cleanup(func() {
+			_ = wt.Client().Delete(wt.Context(), staleCM)
+		})
+
+		// Trigger a spec change to cause a cache miss → GC runs.
+		s.SetManagementPolicy(wt, "lws", ccmcommon.Managed)
+
+		// GC should delete the stale resource. In envtest there is no garbage collector
+		// process, so Foreground deletion marks the object with a deletionTimestamp but
+		// does not remove it. Either outcome (gone or marked for deletion) confirms the
+		// GC predicate fired correctly.
+		wt.Get(gvk.ConfigMap, client.ObjectKeyFromObject(staleCM)).Eventually().Should(
+			Or(BeNil(), jq.Match(`.metadata.deletionTimestamp != null`)),
+		)
+	})
+
+	t.Run("GC keeps protected resources regardless of generation mismatch", func(t *testing.T) {
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wt.Eventually(func() bool { return s.hasCertManagerDeployments(wt) }).Should(BeTrue())
+
+		// Register cert-manager CRDs so we can create actual ClusterIssuer resources.
+		_, err := et.RegisterCertManagerCRDs(wt.Context(), envt.WithPermissiveSchema())
+		wt.Expect(err).NotTo(HaveOccurred())
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"}}
+		if err := wt.Client().Create(wt.Context(), ns); err != nil && !k8serr.IsAlreadyExists(err) {
+			wt.Expect(err).NotTo(HaveOccurred())
+		}
+
+		// Wait for the bootstrap PKI resources to be created.
+		wt.Get(gvk.CertManagerClusterIssuer, types.NamespacedName{Name: "opendatahub-selfsigned-issuer"}).
+			Eventually().ShouldNot(BeNil())
+
+		// Trigger a spec change → cache miss → GC runs.
+		s.SetManagementPolicy(wt, "lws", ccmcommon.Managed)
+
+		// Wait for LWS deployment to confirm the reconcile (and GC) completed.
+		wt.Get(gvk.Deployment, types.NamespacedName{
+			Name: "openshift-lws-operator", Namespace: "openshift-lws-operator",
+		}).Eventually().Should(Not(BeNil()))
+
+		// The protected PKI resources must survive across GC runs.
+		NewWithT(t).Consistently(func() error {
+			return wt.Client().Get(wt.Context(), types.NamespacedName{Name: "opendatahub-selfsigned-issuer"},
+				resources.GvkToPartial(gvk.CertManagerClusterIssuer))
+		}).WithTimeout(5 * time.Second).WithPolling(250 * time.Millisecond).Should(Succeed())
+
+		NewWithT(t).Consistently(func() error {
+			return wt.Client().Get(wt.Context(), types.NamespacedName{Name: "opendatahub-ca", Namespace: "cert-manager"},
+				resources.GvkToPartial(gvk.CertManagerCertificate))
+		}).WithTimeout(5 * time.Second).WithPolling(250 * time.Millisecond).Should(Succeed())
+
+		NewWithT(t).Consistently(func() error {
+			return wt.Client().Get(wt.Context(), types.NamespacedName{Name: "opendatahub-ca-issuer"},
+				resources.GvkToPartial(gvk.CertManagerClusterIssuer))
+		}).WithTimeout(5 * time.Second).WithPolling(250 * time.Millisecond).Should(Succeed())
+	})
+}
+
+// TestWithoutCertManager tests cert-manager CRD absence and dynamic
+// registration. Each sub-test uses an isolated envtest to start with zero
+// cert-manager CRDs.
+func (s *Suite[T]) TestWithoutCertManager(t *testing.T) {
+	ccmtest.RequireCharts(t)
+
+	logf.SetLogger(zap.New(zap.WriteTo(io.Discard), zap.UseDevMode(true)))
+
+	t.Run("reports DependenciesAvailable=False and Ready=False when cert-manager CRDs absent", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, wtC := ccmtest.StartIsolatedController(t, ctx, s.cfg)
+		t.Cleanup(cancel) // stop the manager before the test environment (registered after et.Stop, so it runs first)
+
+		s.CreateCR(t, wtC, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wtC.Get(s.cfg.GVK, s.key()).Eventually().Should(
+			jq.Match(`.status.conditions[] | select(.type == "DependenciesAvailable") | .status == "False"`),
+		)
+		wtC.Get(s.cfg.GVK, s.key()).Eventually().Should(
+			jq.Match(`.status.conditions[] | select(.type == "Ready") | .status == "False"`),
+		)
+	})
+
+	t.Run("reconciles PKI resources after cert-manager CRDs appear", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		et, wtC := ccmtest.StartIsolatedController(t, ctx, s.cfg)
+		t.Cleanup(cancel) // stop the manager before the test environment (registered after et.Stop, so it runs first)
+
+		s.CreateCR(t, wtC, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wtC.Get(s.cfg.GVK, s.key()).Eventually().Should(
+			jq.Match(`.status.conditions[] | select(.type == "DependenciesAvailable") | .status == "False"`),
+		)
+
+		_, err := et.RegisterCertManagerCRDs(ctx, envt.WithPermissiveSchema())
+		wtC.Expect(err).NotTo(HaveOccurred())
+
+		ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"}}
+		if err := et.Client().Create(ctx, ns); err != nil && !k8serr.IsAlreadyExists(err) {
+			wtC.Expect(err).NotTo(HaveOccurred())
+		}
+
+		wtC.Get(s.cfg.GVK, s.key()).Eventually().Should(
+			jq.Match(`.status.conditions[] | select(.type == "DependenciesAvailable") | .status == "True"`),
+		)
+		wtC.Get(gvk.CertManagerClusterIssuer, types.NamespacedName{Name: "opendatahub-selfsigned-issuer"}).
+			Eventually().ShouldNot(BeNil())
+		wtC.Get(gvk.CertManagerCertificate, types.NamespacedName{Name: "opendatahub-ca", Namespace: "cert-manager"}).
+			Eventually().ShouldNot(BeNil())
+		wtC.Get(gvk.CertManagerClusterIssuer, types.NamespacedName{Name: "opendatahub-ca-issuer"}).
+			Eventually().ShouldNot(BeNil())
+	})
+}
+
+// TestCleanupAction verifies that the cleanup finalizer action deletes
+// dependency operator CRs (CertManager/cluster and Istio/default) before
+// cascade deletion is released, allowing each operator to process its own
+// finalizers while still running.
+func (s *Suite[T]) TestCleanupAction(t *testing.T) {
+	ccmtest.RequireCharts(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	et, wt := ccmtest.StartIsolatedController(t, ctx, s.cfg)
+	t.Cleanup(cancel)
+
+	t.Run("cleanup action deletes CertManager/cluster and Istio/default before cascade", func(t *testing.T) {
+		// Register operator CRDs so dependency CRs can be created.
+		_, err := et.RegisterCRD(wt.Context(),
+			gvk.CertManagerV1Alpha1,
+			"certmanagers", "certmanager",
+			apiextensionsv1.ClusterScoped,
+		)
+		wt.Expect(err).NotTo(HaveOccurred())
+
+		_, err = et.RegisterCRD(wt.Context(),
+			gvk.Istio,
+			"istios", "istio",
+			apiextensionsv1.ClusterScoped,
+		)
+		wt.Expect(err).NotTo(HaveOccurred())
+
+		s.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+			SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wt.Get(s.cfg.GVK, s.key()).Eventually().Should(
+			jq.Match(`.metadata.finalizers | index("platform.opendatahub.io/finalizer") != null`),
+		)
+
+		obj := s.Get(wt)
+
+		type depCR struct {
+			gvk       schema.GroupVersionKind
+			name      string
+			finalizer string
+			spec      map[string]any
+		}
+
+		deps := []depCR{
+			{gvk: gvk.CertManagerV1Alpha1, name: "cluster", finalizer: "cert-manager-operator.operator.openshift.io/test-hold", spec: map[string]any{"managementState": "Managed"}},
+			{gvk: gvk.Istio, name: "default", finalizer: "sailoperator.io/test-hold", spec: map[string]any{"version": "v1.24.3"}},
+		}
+
+		for _, d := range deps {
+			dep := &unstructured.Unstructured{}
+			dep.SetGroupVersionKind(d.gvk)
+			dep.SetName(d.name)
+			dep.SetOwnerReferences([]metav1.OwnerReference{s.ownerReference(obj)})
+			dep.SetFinalizers([]string{d.finalizer})
+			dep.Object["spec"] = d.spec
+			wt.Expect(wt.Client().Create(wt.Context(), dep)).To(Succeed())
+		}
+
+		wt.Expect(wt.Client().Delete(wt.Context(), obj)).To(Succeed())
+
+		// For each dependency CR: verify the cleanup action triggered deletion,
+		// simulate the operator completing its finalizer processing, then confirm
+		// the CR is fully removed. If any assertion fails, the finalizer action is
+		// not targeting that CR — the CR deletion would hang because the operator's
+		// finalizers can't process after the operator pod is gone (RHOAIENG-60496).
+		for _, d := range deps {
+			objNN := types.NamespacedName{Name: d.name}
+
+			wt.Get(d.gvk, objNN).Eventually().Should(jq.Match(`.metadata.deletionTimestamp != null`))
+
+			got := &unstructured.Unstructured{}
+			got.SetGroupVersionKind(d.gvk)
+			wt.Expect(wt.Client().Get(wt.Context(), objNN, got)).To(Succeed())
+			got.SetFinalizers(nil)
+			wt.Expect(wt.Client().Update(wt.Context(), got)).To(Succeed())
+
+			wt.Get(d.gvk, objNN).Eventually().Should(BeNil())
+		}
+
+		wt.Get(s.cfg.GVK, s.key()).Eventually().Should(BeNil())
+	})
+}
+
+func (s *Suite[T]) key() types.NamespacedName {
+	return types.NamespacedName{Name: s.engine.InstanceName()}
+}
+
+func (s *Suite[T]) ownerReference(obj T) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion: s.cfg.GVK.GroupVersion().String(),
+		Kind:       s.cfg.GVK.Kind,
+		Name:       obj.GetName(),
+		UID:        obj.GetUID(),
+	}
+}
+
+func (s *Suite[T]) hasCertManagerDeployments(wt *testf.WithT) bool {
+	return ccmtest.HasInfraDeployments(wt, nsCertManagerOperator, s.cfg.InfraLabel)
+}
@@ -0,0 +1,55 @@
+package generic
+
+import (
+	"context"
+	"fmt"
+
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/generic/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/cloudmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/operatorconfig"
+)
+
+// KubernetesEngine returns the cloud manager provider reconciling the GenericKubernetesEngine.
+func KubernetesEngine() *engine.KubernetesEngine[*ccmv1alpha1.GenericKubernetesEngine] {
+	return engine.New(
+		&ccmv1alpha1.GenericKubernetesEngine{},
+		ccmv1alpha1.GenericKubernetesEngineInstanceName,
+		engine.WithChartOverrides(ClusterSettingsOverride()),
+	)
+}
+
+// NewReconciler sets up the GenericKubernetesEngine controller and registers it with the manager.
+func NewReconciler(ctx context.Context, mgr ctrl.Manager, cfg *operatorconfig.CloudManagerConfig) error {
+	return KubernetesEngine().NewReconciler(ctx, mgr, cfg)
+}
+
+// ClusterSettingsOverride returns the chart override applying the cluster
+// settings of the instance to the resources of every chart.
+func ClusterSettingsOverride() cloudmanager.ChartOverride {
+	return cloudmanager.ChartOverride{
+		PostRenderers: clusterSettingsPostRenderers,
+	}
+}
+
+// clusterSettingsPostRenderers applies the cluster settings of the instance to
+// the resources of every chart, since a generic cluster provides no
+// cloud-specific defaults.
+func clusterSettingsPostRenderers(_ context.Context, rr *types.ReconciliationRequest) ([]engineTypes.PostRenderer, error) {
+	instance, ok := rr.Instance.(*ccmv1alpha1.GenericKubernetesEngine)
+	if !ok {
+		return nil, fmt.Errorf("expected *GenericKubernetesEngine, got %T", rr.Instance)
+	}
+
+	renderer := common.ClusterSettingsPostRenderer(instance.Spec.Cluster)
+	if renderer == nil {
+		return nil, nil
+	}
+
+	return []engineTypes.PostRenderer{renderer}, nil
+}
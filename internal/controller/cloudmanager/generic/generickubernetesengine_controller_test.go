@@ -0,0 +1,154 @@
+package generic_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	helmRenderer "github.com/k8s-manifest-kit/renderer-helm/pkg"
+	"github.com/operator-framework/api/pkg/lib/version"
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/generic/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	ccmcharts "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/generic"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/helm"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/cloudmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	ccmtest "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/cloudmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/mocks"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/testf"
+
+	. "github.com/onsi/gomega"
+)
+
+var clusterSettings = ccmcommon.ClusterSettings{
+	StorageClassName: "local-path",
+	LoadBalancerType: ccmcommon.LoadBalancerTypeClusterIP,
+}
+
+func TestGenericKubernetesEngine(t *testing.T) {
+	suite.Run(t)
+}
+
+func TestGenericKubernetesEngineClusterSettings(t *testing.T) {
+	ccmtest.RequireCharts(t)
+
+	t.Run("deploys managed dependencies with cluster settings", func(t *testing.T) {
+		wt := suite.NewWithT(t)
+
+		suite.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+			LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Managed},
+			SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Managed},
+		}, func(obj *ccmv1alpha1.GenericKubernetesEngine) {
+			obj.Spec.Cluster = clusterSettings
+		})
+
+		wt.Get(gvk.GenericKubernetesEngine, types.NamespacedName{Name: ccmv1alpha1.GenericKubernetesEngineInstanceName}).
+			Eventually().Should(
+			jq.Match(`.status.conditions[] | select(.type == "DependenciesAvailable") | .status == "True"`),
+		)
+
+		// None of the deployed resources may be exposed outside of the cluster
+		// nor claim storage from another StorageClass.
+		wt.Expect(listInfraResources(wt, gvk.Service)).To(HaveEach(
+			jq.Match(`.spec.type == "ClusterIP"`),
+		))
+		wt.Expect(listInfraResources(wt, gvk.PersistentVolumeClaim)).To(HaveEach(
+			jq.Match(`.spec.storageClassName == "local-path"`),
+		))
+		wt.Expect(listInfraResources(wt, gvk.StatefulSet)).To(HaveEach(
+			jq.Match(`[.spec.volumeClaimTemplates[]? | .spec.storageClassName == "local-path"] | all`),
+		))
+	})
+}
+
+func TestGenericKubernetesEngineClusterSettingsRendering(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	action, err := cloudmanager.NewReconcileAction(
+		labels.NormalizePartOfValue(ccmv1alpha1.GenericKubernetesEngineKind),
+		cloudmanager.WithDeployOptions(),
+		cloudmanager.WithHelmOptions(helm.WithCache(false)),
+		cloudmanager.WithChartOverrides(generic.ClusterSettingsOverride()),
+		cloudmanager.WithBuildChartsFn(func(_ context.Context, _ *odhtypes.ReconciliationRequest) (ccmcharts.BuildResult, error) {
+			return ccmcharts.BuildResult{Charts: []odhtypes.HelmChartInfo{{
+				Source: helmRenderer.Source{
+					Chart:       filepath.Join("testdata", "cluster-settings-chart"),
+					ReleaseName: "test",
+					Values:      helmRenderer.Values(map[string]any{"namespace": ns}),
+				},
+			}}}, nil
+		}),
+	)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	instance := &ccmv1alpha1.GenericKubernetesEngine{}
+	instance.SetName(ccmv1alpha1.GenericKubernetesEngineInstanceName)
+	instance.Spec.Cluster = clusterSettings
+
+	rr := &odhtypes.ReconciliationRequest{
+		Client:   cl,
+		Instance: instance,
+		Controller: mocks.NewMockController(func(m *mocks.MockController) {
+			m.On("Owns", mock.Anything).Return(false)
+		}),
+		Release: common.Release{
+			Name: cluster.OpenDataHub,
+			Version: version.OperatorVersion{Version: semver.Version{
+				Major: 1, Minor: 0, Patch: 0,
+			}},
+		},
+	}
+	rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady)
+
+	g.Expect(action(ctx, rr)).Should(Succeed())
+
+	svc := &corev1.Service{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: "test-webhook"}, svc)).Should(Succeed())
+	g.Expect(svc.Spec.Type).Should(Equal(corev1.ServiceTypeClusterIP))
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: "test-data"}, pvc)).Should(Succeed())
+	g.Expect(pvc.Spec.StorageClassName).Should(HaveValue(Equal("local-path")))
+
+	sts := &appsv1.StatefulSet{}
+	g.Expect(cl.Get(ctx, client.ObjectKey{Namespace: ns, Name: "test"}, sts)).Should(Succeed())
+	g.Expect(sts.Spec.VolumeClaimTemplates).Should(HaveExactElements(
+		HaveField("Spec.StorageClassName", HaveValue(Equal("local-path"))),
+	))
+}
+
+func listInfraResources(wt *testf.WithT, objGVK schema.GroupVersionKind) []unstructured.Unstructured {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(objGVK.GroupVersion().WithKind(objGVK.Kind + "List"))
+
+	wt.Expect(wt.Client().List(wt.Context(), list, client.MatchingLabels{
+		labels.InfrastructurePartOf: suite.InfraLabel(),
+	})).To(Succeed())
+
+	return list.Items
+}
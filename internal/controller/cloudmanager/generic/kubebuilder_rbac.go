@@ -0,0 +1,5 @@
+package generic
+
+// +kubebuilder:rbac:groups=infrastructure.opendatahub.io,resources=generickubernetesengines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.opendatahub.io,resources=generickubernetesengines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.opendatahub.io,resources=generickubernetesengines/finalizers,verbs=update
@@ -0,0 +1,14 @@
+package generic_test
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine/enginetest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/generic"
+)
+
+var suite = enginetest.New(generic.KubernetesEngine())
+
+func TestMain(m *testing.M) {
+	suite.RunTestMain(m)
+}
@@ -0,0 +1,24 @@
+package gke
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/gke/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/operatorconfig"
+)
+
+// KubernetesEngine returns the cloud manager provider reconciling the GKEKubernetesEngine.
+func KubernetesEngine() *engine.KubernetesEngine[*ccmv1alpha1.GKEKubernetesEngine] {
+	return engine.New(
+		&ccmv1alpha1.GKEKubernetesEngine{},
+		ccmv1alpha1.GKEKubernetesEngineInstanceName,
+	)
+}
+
+// NewReconciler sets up the GKEKubernetesEngine controller and registers it with the manager.
+func NewReconciler(ctx context.Context, mgr ctrl.Manager, cfg *operatorconfig.CloudManagerConfig) error {
+	return KubernetesEngine().NewReconciler(ctx, mgr, cfg)
+}
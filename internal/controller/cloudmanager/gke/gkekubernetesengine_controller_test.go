@@ -0,0 +1,55 @@
+package gke_test
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/gke/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	ccmtest "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/cloudmanager"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGKEKubernetesEngine(t *testing.T) {
+	suite.Run(t)
+}
+
+func TestGKEKubernetesEngineInstance(t *testing.T) {
+	ccmtest.RequireCharts(t)
+
+	t.Run("rejects instances not named default-gkekubernetesengine", func(t *testing.T) {
+		wt := suite.NewWithT(t)
+
+		obj := suite.NewCR(ccmcommon.Dependencies{})
+		obj.SetName("gke")
+
+		wt.Expect(wt.Client().Create(wt.Context(), obj)).To(
+			MatchError(ContainSubstring("GKEKubernetesEngine name must be default-gkekubernetesengine")),
+		)
+	})
+
+	t.Run("reports the dependencies deployed for the GKE cluster", func(t *testing.T) {
+		wt := suite.NewWithT(t)
+
+		suite.CreateCR(t, wt, ccmcommon.Dependencies{
+			CertManager: ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+		})
+
+		wt.Get(gvk.Deployment, types.NamespacedName{
+			Name: "cert-manager-operator-controller-manager", Namespace: ccmcommon.DefaultNamespaceCertManagerOperator,
+		}).Eventually().Should(
+			jq.Match(`.metadata.labels."%s" == "gkekubernetesengine"`, labels.InfrastructurePartOf),
+		)
+
+		wt.Get(gvk.GKEKubernetesEngine, types.NamespacedName{Name: ccmv1alpha1.GKEKubernetesEngineInstanceName}).
+			Eventually().Should(And(
+			jq.Match(`.status.dependencies.certManager.managementPolicy == "Managed"`),
+			jq.Match(`.status.dependencies.certManager.namespace == "%s"`, ccmcommon.DefaultNamespaceCertManagerOperator),
+		))
+	})
+}
@@ -0,0 +1,5 @@
+package gke
+
+// +kubebuilder:rbac:groups=infrastructure.opendatahub.io,resources=gkekubernetesengines,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.opendatahub.io,resources=gkekubernetesengines/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=infrastructure.opendatahub.io,resources=gkekubernetesengines/finalizers,verbs=update
@@ -0,0 +1,14 @@
+package gke_test
+
+import (
+	"testing"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/engine/enginetest"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/gke"
+)
+
+var suite = enginetest.New(gke.KubernetesEngine())
+
+func TestMain(m *testing.M) {
+	suite.RunTestMain(m)
+}
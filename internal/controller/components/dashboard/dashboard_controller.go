@@ -19,6 +19,7 @@ package dashboard
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	consolev1 "github.com/openshift/api/console/v1"
 	routev1 "github.com/openshift/api/route/v1"
@@ -37,6 +38,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/kustomize"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/deployments"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/handlers"
@@ -121,6 +123,10 @@ func (s *componentHandler) NewComponentReconciler(ctx context.Context, mgr ctrl.
 		WithAction(deployObservabilityManifests).
 		WithAction(setKustomizedParams).
 		WithAction(configureDependencies).
+		ComposeWith(patches.ComponentPatches[*componentApi.Dashboard](
+			componentApi.DashboardInstanceName,
+			strings.ToLower(componentApi.DashboardKind),
+		)).
 		WithAction(kustomize.NewAction(
 			// Those are the default labels added by the legacy deploy method
 			// and should be preserved as the original plugin were affecting
@@ -133,12 +139,15 @@ func (s *componentHandler) NewComponentReconciler(ctx context.Context, mgr ctrl.
 			kustomize.WithLabel(labels.ODH.Component(componentName), labels.True),
 			kustomize.WithLabel(labels.K8SCommon.PartOf, componentName),
 		)).
-		WithAction(deploy.NewAction()).
+		WithAction(deploy.NewAction(
+			deploy.WithResourceInventory(),
+		)).
 		WithAction(deployments.NewAction()).
 		WithAction(reconcileHardwareProfiles).
 		WithAction(updateStatus).
 		// must be the final action
 		WithAction(gc.NewAction(
+			gc.WithInventory(),
 			gc.WithUnremovables(gvk.OdhDashboardConfig),
 		)).
 		// declares the list of additional, controller specific conditions that are
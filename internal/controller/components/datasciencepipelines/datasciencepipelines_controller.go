@@ -18,6 +18,7 @@ package datasciencepipelines
 
 import (
 	"context"
+	"strings"
 
 	securityv1 "github.com/openshift/api/security/v1"
 	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
@@ -30,6 +31,7 @@ import (
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/kustomize"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/deployments"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/releases"
@@ -67,16 +69,21 @@ func (s *componentHandler) NewComponentReconciler(ctx context.Context, mgr ctrl.
 		WithAction(initialize).
 		WithAction(argoWorkflowsControllersOptions).
 		WithAction(releases.NewAction()).
+		ComposeWith(patches.ComponentPatches[*componentApi.DataSciencePipelines](
+			componentApi.DataSciencePipelinesInstanceName,
+			strings.ToLower(componentApi.DataSciencePipelinesKind),
+		)).
 		WithAction(kustomize.NewAction(
 			kustomize.WithLabel(labels.ODH.Component(LegacyComponentName), labels.True),
 			kustomize.WithLabel(labels.K8SCommon.PartOf, LegacyComponentName),
 		)).
 		WithAction(deploy.NewAction(
 			deploy.WithCache(),
+			deploy.WithResourceInventory(),
 		)).
 		WithAction(deployments.NewAction()).
 		// must be the final action
-		WithAction(gc.NewAction()).
+		WithAction(gc.NewAction(gc.WithInventory())).
 		// declares the list of additional, controller specific conditions that are
 		// contributing to the controller readiness status
 		WithConditions(conditionTypes...).
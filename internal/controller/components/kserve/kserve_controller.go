@@ -38,6 +38,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/render/kustomize"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/deployments"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/status/releases"
@@ -215,6 +216,10 @@ func (s *componentHandler) NewComponentReconciler(ctx context.Context, mgr ctrl.
 		WithAction(releases.NewAction()).
 		WithAction(removeOwnershipFromUnmanagedResources).
 		WithAction(cleanUpTemplatedResources).
+		ComposeWith(patches.ComponentPatches[*componentApi.Kserve](
+			componentApi.KserveInstanceName,
+			strings.ToLower(componentApi.KserveKind),
+		)).
 		WithAction(kustomize.NewAction(
 			// These are the default labels added by the legacy deploy method
 			// and should be preserved as the original plugin were affecting
@@ -233,12 +238,16 @@ func (s *componentHandler) NewComponentReconciler(ctx context.Context, mgr ctrl.
 		}).
 		WithAction(deploy.NewAction(
 			deploy.WithCache(),
+			deploy.WithResourceInventory(),
 			withApplyOrder(),
 		)).
 		WithAction(reconcileModelCache).
 		WithAction(deployments.NewAction()).
 		// must be the final action
-		WithAction(gc.NewAction(gc.WithUnremovables(gvk.LLMInferenceServiceConfigV1Alpha1, gvk.LLMInferenceServiceConfigV1Alpha2))).
+		WithAction(gc.NewAction(
+			gc.WithInventory(),
+			gc.WithUnremovables(gvk.LLMInferenceServiceConfigV1Alpha1, gvk.LLMInferenceServiceConfigV1Alpha2),
+		)).
 		WithFinalizer(deleteLLMInferenceServiceConfigs).
 		// declares the list of additional, controller specific conditions that are
 		// contributing to the controller readiness status
@@ -36,6 +36,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates/dependent"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates/resources"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
@@ -101,13 +102,20 @@ func NewDataScienceClusterReconciler(ctx context.Context, mgr ctrl.Manager) erro
 			}),
 			reconciler.WithPredicates(
 				resources.CreatedOrUpdatedOrDeletedNamed(gates.AcksConfigMap),
-			))
+			)).
+		WatchesGVK(
+			gvk.UpgradeGate,
+			reconciler.Dynamic(reconciler.CrdExists(gvk.UpgradeGate)),
+			reconciler.WithEventMapper(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				return watchDataScienceClusters(ctx, mgr.GetClient())
+			}),
+			reconciler.WithPredicates(predicates.DefaultPredicate))
 
 	_, err := b.
 		WithAction(initialize).
 		WithAction(checkPreConditions).
 		WithAction(updateStatus).
-		WithAction(checkUpgradeGates).
+		WithAction(newCheckUpgradeGatesAction(mgr.GetAPIReader())).
 		WithAction(provisionComponents).
 		WithAction(deploy.NewAction(
 			deploy.WithCache()),
@@ -18,8 +18,10 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/modules"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
 	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/dag"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
 	odhtype "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 )
@@ -72,7 +74,15 @@ func checkPreConditions(ctx context.Context, rr *odhtype.ReconciliationRequest)
 	return nil
 }
 
-func checkUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest) error {
+// newCheckUpgradeGatesAction returns the action checking the upgrade gates, the
+// resources evaluated by the gates are read with the given uncached reader.
+func newCheckUpgradeGatesAction(reader client.Reader) actions.Fn {
+	return func(ctx context.Context, rr *odhtype.ReconciliationRequest) error {
+		return checkUpgradeGates(ctx, rr, reader)
+	}
+}
+
+func checkUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest, reader client.Reader) error {
 	instance, ok := rr.Instance.(*dscv2.DataScienceCluster)
 	if !ok {
 		return fmt.Errorf("resource instance %v is not a dscv2.DataScienceCluster)", rr.Instance)
@@ -90,7 +100,7 @@ func checkUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest) e
 		return nil
 	}
 
-	return provision.CheckUpgradeGates(ctx, rr.Client, rr.Release, rr.Conditions, nil)
+	return provision.CheckUpgradeGates(ctx, rr.Client, rr.Release, rr.Conditions, nil, gates.WithAPIReader(reader))
 }
 
 func watchDataScienceClusters(ctx context.Context, cli client.Client) []reconcile.Request {
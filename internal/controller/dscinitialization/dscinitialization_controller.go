@@ -265,10 +265,11 @@ func (r *DSCInitializationReconciler) Reconcile(ctx context.Context, req ctrl.Re
 	}
 
 	// Finish reconciling
-	monitoringConditions := r.GetMonitoringReadyCondition(ctx)
+	statusConditions := r.GetMonitoringReadyCondition(ctx)
+	statusConditions = append(statusConditions, r.GetMigrationsCondition(ctx, instance.Spec.ApplicationsNamespace))
 	_, err = status.UpdateWithRetry(ctx, r.Client, instance, func(saved *dsciv2.DSCInitialization) {
 		status.SetCompleteCondition(&saved.Status.Conditions, status.ReconcileCompleted, status.ReconcileCompletedMessage)
-		for _, c := range monitoringConditions {
+		for _, c := range statusConditions {
 			status.SetCondition(&saved.Status.Conditions, c.Type, c.ReadyReason, c.ReadyMessage, c.ReadyStatus)
 		}
 		saved.Status.Phase = status.PhaseReady
@@ -451,6 +452,7 @@ func (r *DSCInitializationReconciler) GetMonitoringReadyCondition(ctx context.Co
 			status.ConditionTempoAvailable,
 			status.ConditionPersesAvailable,
 			status.ConditionAlertingAvailable,
+			status.ConditionLogsAvailable,
 			status.ConditionNodeMetricsEndpointAvailable:
 			conditions = append(conditions, DSCInitializationCondition{
 				Type:         c.Type,
@@ -524,6 +526,7 @@ func (r *DSCInitializationReconciler) newMonitoringCR(ctx context.Context, dsci
 	}
 
 	defaultMonitoring.Spec.Alerting = dsci.Spec.Monitoring.Alerting
+	defaultMonitoring.Spec.Logs = dsci.Spec.Monitoring.Logs
 
 	if metricsEnabled || tracesEnabled {
 		if dsci.Spec.Monitoring.CollectorReplicas != 0 {
@@ -0,0 +1,55 @@
+package dscinitialization
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+)
+
+// GetMigrationsCondition summarizes the migration ledger of the given
+// application namespace as the MigrationsSucceeded condition.
+func (r *DSCInitializationReconciler) GetMigrationsCondition(ctx context.Context, applicationNS string) DSCInitializationCondition {
+	ledger, err := upgrade.ReadLedger(ctx, r.Client, applicationNS)
+	if err != nil {
+		return DSCInitializationCondition{status.ConditionMigrationsSucceeded, status.NotReadyReason,
+			fmt.Sprintf("Failed to read migration ledger: %v", err), metav1.ConditionUnknown}
+	}
+
+	return MigrationsCondition(ledger)
+}
+
+// MigrationsCondition computes the MigrationsSucceeded condition from the
+// given ledger entries: the condition is false if any migration failed.
+func MigrationsCondition(ledger map[string]upgrade.LedgerEntry) DSCInitializationCondition {
+	if len(ledger) == 0 {
+		return DSCInitializationCondition{status.ConditionMigrationsSucceeded, status.NoMigrationsReason,
+			"No migrations recorded", metav1.ConditionTrue}
+	}
+
+	names := make([]string, 0, len(ledger))
+	for name := range ledger {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var failed []string
+	for _, name := range names {
+		if e := ledger[name]; e.Outcome == upgrade.MigrationFailed {
+			failed = append(failed, fmt.Sprintf("%s (%s)", name, e.Message))
+		}
+	}
+
+	if len(failed) > 0 {
+		return DSCInitializationCondition{status.ConditionMigrationsSucceeded, status.MigrationFailedReason,
+			"Failed migrations: " + strings.Join(failed, ", "), metav1.ConditionFalse}
+	}
+
+	return DSCInitializationCondition{status.ConditionMigrationsSucceeded, status.MigrationsSucceededReason,
+		"Completed migrations: " + strings.Join(names, ", "), metav1.ConditionTrue}
+}
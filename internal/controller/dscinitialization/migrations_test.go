@@ -0,0 +1,62 @@
+package dscinitialization_test
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/dscinitialization"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMigrationsCondition(t *testing.T) {
+	tests := []struct {
+		name    string
+		ledger  map[string]upgrade.LedgerEntry
+		status  metav1.ConditionStatus
+		reason  string
+		message string
+	}{
+		{
+			name:    "empty ledger",
+			status:  metav1.ConditionTrue,
+			reason:  status.NoMigrationsReason,
+			message: "No migrations recorded",
+		},
+		{
+			name: "all migrations succeeded",
+			ledger: map[string]upgrade.LedgerEntry{
+				"b": {Outcome: upgrade.MigrationSucceeded},
+				"a": {Outcome: upgrade.MigrationSucceeded},
+			},
+			status:  metav1.ConditionTrue,
+			reason:  status.MigrationsSucceededReason,
+			message: "Completed migrations: a, b",
+		},
+		{
+			name: "failed migration",
+			ledger: map[string]upgrade.LedgerEntry{
+				"a": {Outcome: upgrade.MigrationSucceeded},
+				"b": {Outcome: upgrade.MigrationFailed, Message: "boom"},
+			},
+			status:  metav1.ConditionFalse,
+			reason:  status.MigrationFailedReason,
+			message: "Failed migrations: b (boom)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := dscinitialization.MigrationsCondition(tt.ledger)
+			g.Expect(c.Type).Should(Equal(status.ConditionMigrationsSucceeded))
+			g.Expect(c.ReadyStatus).Should(Equal(tt.status))
+			g.Expect(c.ReadyReason).Should(Equal(tt.reason))
+			g.Expect(c.ReadyMessage).Should(Equal(tt.message))
+		})
+	}
+}
@@ -3,6 +3,8 @@ package modules
 // +kubebuilder:rbac:groups="config.opendatahub.io",resources=platforms,verbs=get;list;watch
 // +kubebuilder:rbac:groups="config.opendatahub.io",resources=platforms/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="config.opendatahub.io",resources=platforms/finalizers,verbs=update
+// +kubebuilder:rbac:groups="config.opendatahub.io",resources=upgradegates,verbs=get;list;watch
+// +kubebuilder:rbac:groups="config.opendatahub.io",resources=upgradegates/status,verbs=get;update;patch
 
 // AIGateway: new mega module
 // +kubebuilder:rbac:groups=components.platform.opendatahub.io,resources=aigateways,verbs=get;list;watch;create;update;patch;delete
@@ -18,6 +18,7 @@ import (
 	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
@@ -40,8 +41,9 @@ import (
 // before the check runs. ExtractUpgradeGates pulls gate CMs out of
 // rr.Resources and stashes them on rr.GateEntries. checkUpgradeGates
 // then merges all gate sources and writes descriptions to
-// odh-upgrade-acks. If unacked gates exist, deploy never runs.
-func commonActions() []actions.Fn {
+// odh-upgrade-acks. If unacked gates exist, deploy never runs. The
+// resources evaluated by the gates are read with the given uncached reader.
+func commonActions(reader client.Reader) []actions.Fn {
 	return []actions.Fn{
 		initializeModules,
 		cleanupDisabledModules,
@@ -49,7 +51,7 @@ func commonActions() []actions.Fn {
 		helmrender.NewAction(),
 		kustomizerender.NewAction(),
 		provision.ExtractUpgradeGates,
-		checkUpgradeGates,
+		newCheckUpgradeGatesAction(reader),
 		injectModuleEnv,
 		injectPlatformConfig,
 		deploy.NewAction(
@@ -59,6 +61,7 @@ func commonActions() []actions.Fn {
 		),
 		updateModuleStatus,
 		gc.NewAction(
+			gc.WithInventory(),
 			gc.WithTypePredicate(
 				func(rr *types.ReconciliationRequest, objGVK schema.GroupVersionKind) (bool, error) {
 					return rr.Controller.Owns(objGVK), nil
@@ -116,11 +119,18 @@ func newDSCModuleReconciler(ctx context.Context, mgr ctrl.Manager) error {
 			reconciler.WithPredicates(predicate.Or(
 				resources.CreatedOrUpdatedOrDeletedNamed(gates.AcksConfigMap),
 				resources.CreatedOrUpdatedOrDeletedLabeled(gates.UpgradeGateLabel, "true"),
-			)))
+			))).
+		WatchesGVK(
+			gvk.UpgradeGate,
+			reconciler.Dynamic(reconciler.CrdExists(gvk.UpgradeGate)),
+			reconciler.WithEventMapper(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				return cluster.WatchDataScienceClusters(ctx, mgr.GetClient())
+			}),
+			reconciler.WithPredicates(predicates.DefaultPredicate))
 
 	b = addModuleCRWatches(b)
 
-	for _, a := range commonActions() {
+	for _, a := range commonActions(mgr.GetAPIReader()) {
 		b = b.WithAction(a)
 	}
 
@@ -140,6 +150,13 @@ func newPlatformModuleReconciler(ctx context.Context, mgr ctrl.Manager) error {
 		WithDynamicOwnership(reconciler.WithGVKPredicates(moduleStatusPredicates())).
 		WithoutConditionCleanup().
 		WithoutStatusConditionsIf(cr.HasEntries).
+		WatchesGVK(
+			gvk.UpgradeGate,
+			reconciler.Dynamic(reconciler.CrdExists(gvk.UpgradeGate)),
+			reconciler.WithEventMapper(func(ctx context.Context, _ client.Object) []reconcile.Request {
+				return cluster.WatchPlatforms(ctx, mgr.GetClient())
+			}),
+			reconciler.WithPredicates(predicates.DefaultPredicate)).
 		WithAction(enableModulesFromPlatform)
 
 	reg := DefaultRegistry()
@@ -161,7 +178,7 @@ func newPlatformModuleReconciler(ctx context.Context, mgr ctrl.Manager) error {
 		}
 	}
 
-	for _, a := range commonActions() {
+	for _, a := range commonActions(mgr.GetAPIReader()) {
 		b = b.WithAction(a)
 	}
 
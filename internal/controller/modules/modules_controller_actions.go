@@ -8,6 +8,7 @@ import (
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
@@ -17,15 +18,25 @@ import (
 	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
 	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/dag"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
 	odhtype "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/flags"
 )
 
-func checkUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest) error {
+// newCheckUpgradeGatesAction returns the action checking the upgrade gates, the
+// resources evaluated by the gates are read with the given uncached reader.
+func newCheckUpgradeGatesAction(reader client.Reader) actions.Fn {
+	return func(ctx context.Context, rr *odhtype.ReconciliationRequest) error {
+		return checkUpgradeGates(ctx, rr, reader)
+	}
+}
+
+func checkUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest, reader client.Reader) error {
 	reg := DefaultRegistry()
 	if !reg.HasEntries() {
 		return nil
@@ -40,7 +51,7 @@ func checkUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest) e
 		return nil
 	}
 
-	return provision.CheckUpgradeGates(ctx, rr.Client, rr.Release, rr.Conditions, rr.GateEntries)
+	return provision.CheckUpgradeGates(ctx, rr.Client, rr.Release, rr.Conditions, rr.GateEntries, gates.WithAPIReader(reader))
 }
 
 // initializeModules fetches DSCI once per reconcile and stores it on the
@@ -98,7 +98,7 @@ func (h *ServiceHandler) NewReconciler(ctx context.Context, mgr ctrl.Manager) er
 			deploy.WithCache(),
 		)).
 		WithAction(syncGatewayConfigStatus).
-		WithAction(gc.NewAction()).
+		WithAction(gc.NewAction(gc.WithInventory())).
 		WithConditions(ReadyConditionType)
 
 	if _, err := gw.Build(ctx); err != nil {
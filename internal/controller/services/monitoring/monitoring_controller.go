@@ -110,6 +110,7 @@ func (h *serviceHandler) NewReconciler(ctx context.Context, mgr ctrl.Manager) er
 		OwnsGVK(gvk.ServiceMonitor, reconciler.Dynamic(reconciler.CrdExists(gvk.ServiceMonitor))).
 		OwnsGVK(gvk.PrometheusRule, reconciler.Dynamic(reconciler.CrdExists(gvk.PrometheusRule))).
 		OwnsGVK(gvk.ThanosQuerier, reconciler.Dynamic(reconciler.CrdExists(gvk.ThanosQuerier))).
+		OwnsGVK(gvk.SecurityContextConstraints, reconciler.Dynamic(reconciler.CrdExists(gvk.SecurityContextConstraints))).
 		OwnsGVK(gvk.PersesV1Alpha1, reconciler.Dynamic(reconciler.CrdExistsWithoutPreferred(gvk.PersesV1Alpha1, gvk.PersesV1Alpha2))).
 		OwnsGVK(gvk.PersesV1Alpha2, reconciler.Dynamic(reconciler.CrdExists(gvk.PersesV1Alpha2))).
 		OwnsGVK(gvk.PersesDatasourceV1Alpha1, reconciler.Dynamic(reconciler.CrdExistsWithoutPreferred(gvk.PersesDatasourceV1Alpha1, gvk.PersesDatasourceV1Alpha2))).
@@ -155,12 +156,19 @@ func (h *serviceHandler) NewReconciler(ctx context.Context, mgr ctrl.Manager) er
 			reconciler.WithEventHandler(handlers.ToNamed(serviceApi.MonitoringInstanceName)),
 			reconciler.WithPredicates(resources.CMContentChangedPredicate),
 		).
+		// Watch Secrets for changes of the Alertmanager receivers and remote write credentials
+		Watches(
+			&corev1.Secret{},
+			reconciler.WithEventHandler(handlers.ToNamed(serviceApi.MonitoringInstanceName)),
+			reconciler.WithPredicates(resources.SecretContentChangedPredicate),
+		).
 		WithAction(addMonitoringCapability).
 		WithAction(deployMonitoringAdmissionPolicies).
 		WithAction(deployMonitoringStackWithQuerierAndRestrictions).
 		WithAction(deployTracingStack).
 		WithAction(deployAlerting).
 		WithAction(deployOpenTelemetryCollector).
+		WithAction(deployLogs).
 		WithAction(deployPerses).
 		WithAction(deployPersesTempoIntegration).
 		WithAction(deployPersesPrometheusIntegration).
@@ -168,11 +176,14 @@ func (h *serviceHandler) NewReconciler(ctx context.Context, mgr ctrl.Manager) er
 		WithAction(template.NewAction(
 			template.WithDataFn(getTemplateData),
 		)).
+		WithAction(customizeAlertingRules).
 		WithAction(deploy.NewAction(
 			deploy.WithCache(),
 		)).
 		// Sync CA from ConfigMap to Secret (handles initial creation and rotation updates)
 		WithAction(syncPrometheusWebTLSCA).
+		WithAction(reportSLOCompliance).
+		WithAction(updateRemoteWriteCondition).
 		WithConditions(
 			status.ConditionMonitoringAvailable,
 			status.ConditionMonitoringStackAvailable,
@@ -181,12 +192,14 @@ func (h *serviceHandler) NewReconciler(ctx context.Context, mgr ctrl.Manager) er
 			status.ConditionInstrumentationAvailable,
 			status.ConditionAlertingAvailable,
 			status.ConditionThanosQuerierAvailable,
+			status.ConditionRemoteWriteAvailable,
+			status.ConditionLogsAvailable,
 			status.ConditionPersesAvailable,
 			status.ConditionPersesTempoDataSourceAvailable,
 			status.ConditionPersesPrometheusDataSourceAvailable,
 			status.ConditionNodeMetricsEndpointAvailable,
 		).
-		WithAction(gc.NewAction()).
+		WithAction(gc.NewAction(gc.WithInventory())).
 		Build(ctx)
 
 	if err != nil {
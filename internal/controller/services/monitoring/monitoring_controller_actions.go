@@ -53,6 +53,9 @@ const (
 	PrometheusClusterProxyTemplate                   = "resources/data-science-prometheus-cluster-proxy.tmpl.yaml"
 	TempoServiceCAConfigMapTemplate                  = "resources/tempo-service-ca-configmap.tmpl.yaml"
 	PersesOperatorAccessNetworkPolicyTemplate        = "resources/perses-operator-access-network-policy.tmpl.yaml"
+	SLOPrometheusRulesTemplate                       = "resources/slo-prometheusrules.tmpl.yaml"
+	LogsCollectorTemplate                            = "resources/logs-collector.tmpl.yaml"
+	LogsCollectorRBACTemplate                        = "resources/logs-collector-rbac.tmpl.yaml"
 
 	// API versions.
 	persesV1Alpha2 = "v1alpha2"
@@ -159,6 +162,7 @@ func deployMonitoringStackWithQuerierAndRestrictions(ctx context.Context, rr *od
 	if monitoring.Spec.Metrics == nil {
 		setConditionNotConfigured(rr, status.ConditionMonitoringStackAvailable, status.MetricsNotConfiguredReason, status.MetricsNotConfiguredMessage)
 		setConditionNotConfigured(rr, status.ConditionThanosQuerierAvailable, status.MetricsNotConfiguredReason, status.MetricsNotConfiguredMessage)
+		setConditionNotConfigured(rr, status.ConditionRemoteWriteAvailable, status.MetricsNotConfiguredReason, status.MetricsNotConfiguredMessage)
 		return nil
 	}
 
@@ -173,6 +177,10 @@ func deployMonitoringStackWithQuerierAndRestrictions(ctx context.Context, rr *od
 		return nil
 	}
 
+	if err := checkRemoteWrite(ctx, rr, monitoring); err != nil {
+		return fmt.Errorf("invalid remote write configuration: %w", err)
+	}
+
 	// All prerequisites met, mark all components as available and deploy
 	rr.Conditions.MarkTrue(status.ConditionMonitoringStackAvailable)
 	rr.Conditions.MarkTrue(status.ConditionThanosQuerierAvailable)
@@ -357,7 +365,21 @@ func deployAlerting(ctx context.Context, rr *odhtypes.ReconciliationRequest) err
 		return nil
 	}
 
+	components, err := alertComponents()
+	if err != nil {
+		return err
+	}
+	if err := validateAlerting(monitoring.Spec.Alerting, components); err != nil {
+		setConditionFalse(rr, status.ConditionAlertingAvailable, status.InvalidAlertingConfigReason, err.Error())
+		return err
+	}
+
 	rr.Conditions.MarkTrue(status.ConditionAlertingAvailable)
+
+	if err := addAlertmanagerConfig(ctx, rr, monitoring); err != nil {
+		return fmt.Errorf("failed to add Alertmanager configuration: %w", err)
+	}
+
 	// Add operator prometheus rules, we can deploy operator alerts without any components
 	templates := []odhtypes.TemplateInfo{
 		{
@@ -365,6 +387,12 @@ func deployAlerting(ctx context.Context, rr *odhtypes.ReconciliationRequest) err
 			Path: "monitoring/operator-prometheusrules.tmpl.yaml",
 		},
 	}
+	if len(monitoring.Spec.Alerting.SLOs) > 0 {
+		templates = append(templates, odhtypes.TemplateInfo{
+			FS:   resourcesFS,
+			Path: SLOPrometheusRulesTemplate,
+		})
+	}
 	rr.Templates = append(rr.Templates, templates...)
 
 	dsc, err := cluster.GetDSC(ctx, rr.Client)
@@ -0,0 +1,505 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+const (
+	// alertmanagerConfigSecretName is the Secret the Alertmanager of the MonitoringStack reads
+	// its configuration from, following the alertmanager-<name> convention of the Prometheus operator.
+	alertmanagerConfigSecretName = "alertmanager-data-science-monitoringstack"
+	alertmanagerConfigKey        = "alertmanager.yaml"
+
+	// nullAlertReceiver receives the alerts no route matches when no default receiver is set.
+	nullAlertReceiver = "null"
+
+	// alertComponentLabel is set on the alerting rules shipped with the platform, so that
+	// alerts can be routed by the component they belong to.
+	alertComponentLabel    = "platform_component"
+	operatorAlertComponent = "operator"
+	prometheusRulesSuffix  = "-prometheusrules"
+)
+
+var (
+	alertSeverities = []string{
+		serviceApi.AlertSeverityCritical,
+		serviceApi.AlertSeverityWarning,
+		serviceApi.AlertSeverityInfo,
+	}
+
+	defaultAlertGroupBy     = []string{"namespace", "alertname"}
+	defaultInhibitRuleEqual = []string{"namespace", "alertname"}
+
+	// alertThresholdRE matches the number an alerting rule expression is compared to at its end,
+	// e.g. the 3 of "increase(...[5m]) > 3".
+	alertThresholdRE = regexp.MustCompile(`[^<>=!][<>]=?\s*(-?[0-9]+(?:\.[0-9]+)?)\s*$`)
+)
+
+// alertmanagerConfig is the subset of the Alertmanager configuration file rendered from the
+// alerting spec of the Monitoring service.
+type alertmanagerConfig struct {
+	Route        alertmanagerRoute         `yaml:"route"`
+	Receivers    []alertmanagerReceiver    `yaml:"receivers"`
+	InhibitRules []alertmanagerInhibitRule `yaml:"inhibit_rules,omitempty"`
+}
+
+type alertmanagerRoute struct {
+	Receiver string              `yaml:"receiver"`
+	GroupBy  []string            `yaml:"group_by,omitempty"`
+	Matchers []string            `yaml:"matchers,omitempty"`
+	Continue bool                `yaml:"continue,omitempty"`
+	Routes   []alertmanagerRoute `yaml:"routes,omitempty"`
+}
+
+type alertmanagerReceiver struct {
+	Name             string                  `yaml:"name"`
+	WebhookConfigs   []alertmanagerWebhook   `yaml:"webhook_configs,omitempty"`
+	EmailConfigs     []alertmanagerEmail     `yaml:"email_configs,omitempty"`
+	PagerdutyConfigs []alertmanagerPagerDuty `yaml:"pagerduty_configs,omitempty"`
+}
+
+type alertmanagerWebhook struct {
+	URL          string `yaml:"url"`
+	SendResolved bool   `yaml:"send_resolved"`
+}
+
+type alertmanagerEmail struct {
+	To           string `yaml:"to"`
+	From         string `yaml:"from"`
+	Smarthost    string `yaml:"smarthost"`
+	AuthUsername string `yaml:"auth_username,omitempty"`
+	AuthPassword string `yaml:"auth_password,omitempty"`
+	SendResolved bool   `yaml:"send_resolved"`
+}
+
+type alertmanagerPagerDuty struct {
+	RoutingKey   string `yaml:"routing_key"`
+	URL          string `yaml:"url,omitempty"`
+	SendResolved bool   `yaml:"send_resolved"`
+}
+
+type alertmanagerInhibitRule struct {
+	SourceMatchers []string `yaml:"source_matchers"`
+	TargetMatchers []string `yaml:"target_matchers"`
+	Equal          []string `yaml:"equal"`
+}
+
+// alertComponents returns the components alerts can be routed by: the registered
+// components and the operator itself.
+func alertComponents() ([]string, error) {
+	components := []string{operatorAlertComponent}
+
+	err := cr.ForEach(func(ch cr.ComponentHandler) error {
+		components = append(components, ch.GetName())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate components: %w", err)
+	}
+
+	return components, nil
+}
+
+// validateAlerting validates the alerting configuration beyond what the CRD schema enforces,
+// so that the rendered Alertmanager configuration is always accepted by Alertmanager.
+func validateAlerting(alerting *serviceApi.Alerting, components []string) error {
+	receivers := make(map[string]bool, len(alerting.Receivers))
+	for _, r := range alerting.Receivers {
+		if r.Name == nullAlertReceiver {
+			return fmt.Errorf("receiver name '%s' is reserved and cannot be used", r.Name)
+		}
+		if receivers[r.Name] {
+			return fmt.Errorf("duplicate receiver name '%s'", r.Name)
+		}
+		receivers[r.Name] = true
+
+		if err := validateAlertReceiver(r); err != nil {
+			return err
+		}
+	}
+
+	if alerting.DefaultReceiver != "" && !receivers[alerting.DefaultReceiver] {
+		return fmt.Errorf("default receiver '%s' is not defined", alerting.DefaultReceiver)
+	}
+
+	for i, route := range alerting.Routes {
+		if !receivers[route.Receiver] {
+			return fmt.Errorf("route %d references undefined receiver '%s'", i, route.Receiver)
+		}
+		for _, c := range route.Components {
+			if !slices.Contains(components, c) {
+				return fmt.Errorf("route %d references unknown component '%s' (allowed: %v)", i, c, components)
+			}
+		}
+		for _, s := range route.Severities {
+			if !slices.Contains(alertSeverities, s) {
+				return fmt.Errorf("route %d references unknown severity '%s' (allowed: %v)", i, s, alertSeverities)
+			}
+		}
+	}
+
+	for i, rule := range alerting.InhibitRules {
+		if !slices.Contains(alertSeverities, rule.SourceSeverity) || !slices.Contains(alertSeverities, rule.TargetSeverity) {
+			return fmt.Errorf("inhibit rule %d: severities must be one of %v", i, alertSeverities)
+		}
+		if rule.SourceSeverity == rule.TargetSeverity {
+			return fmt.Errorf("inhibit rule %d: source and target severity must differ", i)
+		}
+	}
+
+	overrides := make(map[string]bool, len(alerting.Rules))
+	for _, o := range alerting.Rules {
+		if o.Alert == "" {
+			return errors.New("rule override is missing the alert name")
+		}
+		if overrides[o.Alert] {
+			return fmt.Errorf("duplicate rule override for alert '%s'", o.Alert)
+		}
+		overrides[o.Alert] = true
+
+		if o.Threshold != nil {
+			if _, err := strconv.ParseFloat(*o.Threshold, 64); err != nil {
+				return fmt.Errorf("rule override for alert '%s' has an invalid threshold '%s'", o.Alert, *o.Threshold)
+			}
+		}
+	}
+
+	return validateSLOs(alerting.SLOs, components)
+}
+
+func validateAlertReceiver(r serviceApi.AlertReceiver) error {
+	count := 0
+	for _, set := range []bool{r.Webhook != nil, r.Email != nil, r.PagerDuty != nil} {
+		if set {
+			count++
+		}
+	}
+	if count != 1 {
+		return fmt.Errorf("receiver '%s' must set exactly one of webhook, email or pagerDuty", r.Name)
+	}
+
+	switch {
+	case r.Webhook != nil:
+		return validateSecretKeySelector(r.Name, "urlSecret", r.Webhook.URLSecret)
+	case r.Email != nil:
+		if r.Email.To == "" || r.Email.From == "" || r.Email.Smarthost == "" {
+			return fmt.Errorf("receiver '%s' must set the to, from and smarthost email fields", r.Name)
+		}
+		if r.Email.AuthPasswordSecret != nil {
+			if r.Email.AuthUsername == "" {
+				return fmt.Errorf("receiver '%s' sets authPasswordSecret without authUsername", r.Name)
+			}
+			return validateSecretKeySelector(r.Name, "authPasswordSecret", *r.Email.AuthPasswordSecret)
+		}
+	case r.PagerDuty != nil:
+		if r.PagerDuty.URL != "" {
+			u, err := url.Parse(r.PagerDuty.URL)
+			if err != nil || u.Scheme != "https" || u.Host == "" {
+				return fmt.Errorf("receiver '%s' pagerDuty url must be a valid https URL", r.Name)
+			}
+		}
+		return validateSecretKeySelector(r.Name, "routingKeySecret", r.PagerDuty.RoutingKeySecret)
+	}
+
+	return nil
+}
+
+func validateSecretKeySelector(receiver, field string, sel corev1.SecretKeySelector) error {
+	if sel.Name == "" || sel.Key == "" {
+		return fmt.Errorf("receiver '%s' field '%s' must set both the secret name and key", receiver, field)
+	}
+	return nil
+}
+
+// renderAlertmanagerConfig renders the Alertmanager configuration of the given alerting spec.
+// The receiver credentials are read from the Secrets of the monitoring namespace, as the
+// Alertmanager configuration file cannot reference Secrets by itself.
+func renderAlertmanagerConfig(ctx context.Context, cli client.Reader, namespace string, alerting *serviceApi.Alerting) (string, error) {
+	config := alertmanagerConfig{
+		Route: alertmanagerRoute{
+			Receiver: alerting.DefaultReceiver,
+			GroupBy:  defaultAlertGroupBy,
+		},
+	}
+
+	if config.Route.Receiver == "" {
+		config.Route.Receiver = nullAlertReceiver
+		config.Receivers = append(config.Receivers, alertmanagerReceiver{Name: nullAlertReceiver})
+	}
+
+	for _, r := range alerting.Receivers {
+		receiver, err := renderAlertReceiver(ctx, cli, namespace, r)
+		if err != nil {
+			return "", err
+		}
+		config.Receivers = append(config.Receivers, receiver)
+	}
+
+	for _, r := range alerting.Routes {
+		route := alertmanagerRoute{
+			Receiver: r.Receiver,
+			Continue: r.Continue,
+		}
+		if m := alertMatcher(alertComponentLabel, r.Components); m != "" {
+			route.Matchers = append(route.Matchers, m)
+		}
+		if m := alertMatcher("severity", r.Severities); m != "" {
+			route.Matchers = append(route.Matchers, m)
+		}
+		config.Route.Routes = append(config.Route.Routes, route)
+	}
+
+	for _, r := range alerting.InhibitRules {
+		equal := r.Equal
+		if len(equal) == 0 {
+			equal = defaultInhibitRuleEqual
+		}
+		config.InhibitRules = append(config.InhibitRules, alertmanagerInhibitRule{
+			SourceMatchers: []string{alertMatcher("severity", []string{r.SourceSeverity})},
+			TargetMatchers: []string{alertMatcher("severity", []string{r.TargetSeverity})},
+			Equal:          equal,
+		})
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal Alertmanager configuration: %w", err)
+	}
+
+	return string(out), nil
+}
+
+func renderAlertReceiver(ctx context.Context, cli client.Reader, namespace string, r serviceApi.AlertReceiver) (alertmanagerReceiver, error) {
+	receiver := alertmanagerReceiver{Name: r.Name}
+
+	switch {
+	case r.Webhook != nil:
+		u, err := secretKeyValue(ctx, cli, namespace, r.Webhook.URLSecret)
+		if err != nil {
+			return receiver, fmt.Errorf("receiver '%s': %w", r.Name, err)
+		}
+		receiver.WebhookConfigs = []alertmanagerWebhook{{
+			URL:          u,
+			SendResolved: r.Webhook.SendResolved,
+		}}
+	case r.Email != nil:
+		email := alertmanagerEmail{
+			To:           r.Email.To,
+			From:         r.Email.From,
+			Smarthost:    r.Email.Smarthost,
+			AuthUsername: r.Email.AuthUsername,
+			SendResolved: r.Email.SendResolved,
+		}
+		if r.Email.AuthPasswordSecret != nil {
+			password, err := secretKeyValue(ctx, cli, namespace, *r.Email.AuthPasswordSecret)
+			if err != nil {
+				return receiver, fmt.Errorf("receiver '%s': %w", r.Name, err)
+			}
+			email.AuthPassword = password
+		}
+		receiver.EmailConfigs = []alertmanagerEmail{email}
+	case r.PagerDuty != nil:
+		key, err := secretKeyValue(ctx, cli, namespace, r.PagerDuty.RoutingKeySecret)
+		if err != nil {
+			return receiver, fmt.Errorf("receiver '%s': %w", r.Name, err)
+		}
+		receiver.PagerdutyConfigs = []alertmanagerPagerDuty{{
+			RoutingKey:   key,
+			URL:          r.PagerDuty.URL,
+			SendResolved: r.PagerDuty.SendResolved,
+		}}
+	}
+
+	return receiver, nil
+}
+
+func secretKeyValue(ctx context.Context, cli client.Reader, namespace string, sel corev1.SecretKeySelector) (string, error) {
+	secret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: sel.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s/%s: %w", namespace, sel.Name, err)
+	}
+
+	value, ok := secret.Data[sel.Key]
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("secret %s/%s has no key '%s'", namespace, sel.Name, sel.Key)
+	}
+
+	return strings.TrimSpace(string(value)), nil
+}
+
+// alertMatcher returns an Alertmanager matcher selecting the alerts whose label has one of
+// the given values, or an empty string if there are none.
+func alertMatcher(label string, values []string) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf("%s=%q", label, values[0])
+	default:
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			quoted = append(quoted, regexp.QuoteMeta(v))
+		}
+		return fmt.Sprintf("%s=~%q", label, strings.Join(quoted, "|"))
+	}
+}
+
+// addAlertmanagerConfig adds the Alertmanager configuration Secret of the MonitoringStack to
+// the resources to deploy. Without receivers, the Alertmanager keeps its default configuration.
+// Unresolvable receiver credentials are reported on the alerting condition and fail the
+// reconciliation: the Secret would otherwise be missing from the rendered resources and the
+// garbage collector would delete the configuration in use.
+func addAlertmanagerConfig(ctx context.Context, rr *odhtypes.ReconciliationRequest, monitoring *serviceApi.Monitoring) error {
+	alerting := monitoring.Spec.Alerting
+	if len(alerting.Receivers) == 0 {
+		return nil
+	}
+
+	config, err := renderAlertmanagerConfig(ctx, rr.Client, monitoring.Spec.Namespace, alerting)
+	if err != nil {
+		setConditionFalse(rr, status.ConditionAlertingAvailable, status.InvalidAlertingConfigReason, err.Error())
+		return err
+	}
+
+	return rr.AddResources(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      alertmanagerConfigSecretName,
+			Namespace: monitoring.Spec.Namespace,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			alertmanagerConfigKey: []byte(config),
+		},
+	})
+}
+
+// customizeAlertingRules labels the rendered alerting rules with the component they belong
+// to and applies the rule overrides of the alerting spec. It runs on the rendered resources
+// since the rules are templates shared by all the installations.
+func customizeAlertingRules(_ context.Context, rr *odhtypes.ReconciliationRequest) error {
+	monitoring, ok := rr.Instance.(*serviceApi.Monitoring)
+	if !ok {
+		return errors.New("instance is not of type *services.Monitoring")
+	}
+
+	if monitoring.Spec.Alerting == nil {
+		return nil
+	}
+
+	overrides := make(map[string]serviceApi.AlertRuleOverride, len(monitoring.Spec.Alerting.Rules))
+	for _, o := range monitoring.Spec.Alerting.Rules {
+		overrides[o.Alert] = o
+	}
+
+	return rr.ForEachResource(func(u *unstructured.Unstructured) (bool, error) {
+		if u.GroupVersionKind() != gvk.PrometheusRule {
+			return false, nil
+		}
+
+		component, ok := strings.CutSuffix(u.GetName(), prometheusRulesSuffix)
+		if !ok {
+			return false, nil
+		}
+
+		return false, customizePrometheusRule(u, component, overrides)
+	})
+}
+
+func customizePrometheusRule(obj *unstructured.Unstructured, component string, overrides map[string]serviceApi.AlertRuleOverride) error {
+	groups, found, err := unstructured.NestedSlice(obj.Object, "spec", "groups")
+	if err != nil || !found {
+		return err
+	}
+
+	kept := make([]any, 0, len(groups))
+
+	for _, g := range groups {
+		group, ok := g.(map[string]any)
+		if !ok {
+			kept = append(kept, g)
+			continue
+		}
+
+		rules, _, err := unstructured.NestedSlice(group, "rules")
+		if err != nil {
+			return err
+		}
+
+		keptRules := make([]any, 0, len(rules))
+
+		for _, r := range rules {
+			rule, ok := r.(map[string]any)
+			if !ok {
+				keptRules = append(keptRules, r)
+				continue
+			}
+
+			alert, _, _ := unstructured.NestedString(rule, "alert")
+			if alert == "" {
+				keptRules = append(keptRules, rule)
+				continue
+			}
+
+			override, ok := overrides[alert]
+			if ok && override.Enabled != nil && !*override.Enabled {
+				continue
+			}
+
+			if ok && override.Threshold != nil {
+				expr, _, _ := unstructured.NestedString(rule, "expr")
+
+				expr, err = overrideAlertThreshold(expr, *override.Threshold)
+				if err != nil {
+					return fmt.Errorf("cannot override the threshold of alert '%s': %w", alert, err)
+				}
+
+				rule["expr"] = expr
+			}
+
+			if err := unstructured.SetNestedField(rule, component, "labels", alertComponentLabel); err != nil {
+				return err
+			}
+
+			keptRules = append(keptRules, rule)
+		}
+
+		// Drop the groups whose rules were all disabled.
+		if len(keptRules) == 0 {
+			continue
+		}
+
+		group["rules"] = keptRules
+		kept = append(kept, group)
+	}
+
+	return unstructured.SetNestedSlice(obj.Object, kept, "spec", "groups")
+}
+
+// overrideAlertThreshold replaces the number the given expression is compared to at its end.
+func overrideAlertThreshold(expr string, threshold string) (string, error) {
+	loc := alertThresholdRE.FindStringSubmatchIndex(expr)
+	if loc == nil {
+		return "", errors.New("the expression does not end with a comparison to a number")
+	}
+
+	return expr[:loc[2]] + threshold + expr[loc[3]:], nil
+}
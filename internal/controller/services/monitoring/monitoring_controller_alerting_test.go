@@ -0,0 +1,383 @@
+//nolint:testpackage // Need to test unexported alerting helpers
+package monitoring
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func secretKey(name, key string) corev1.SecretKeySelector {
+	return corev1.SecretKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: name},
+		Key:                  key,
+	}
+}
+
+func TestValidateAlerting(t *testing.T) {
+	components := []string{operatorAlertComponent, "kserve"}
+	webhook := serviceApi.AlertReceiver{
+		Name:    "ops",
+		Webhook: &serviceApi.WebhookReceiver{URLSecret: secretKey("ops-webhook", "url")},
+	}
+
+	tests := []struct {
+		name     string
+		alerting serviceApi.Alerting
+		err      string
+	}{
+		{
+			name: "valid configuration",
+			alerting: serviceApi.Alerting{
+				Receivers:       []serviceApi.AlertReceiver{webhook},
+				DefaultReceiver: "ops",
+				Routes: []serviceApi.AlertRoute{
+					{Receiver: "ops", Components: []string{"kserve", "operator"}, Severities: []string{"critical"}},
+				},
+				InhibitRules: []serviceApi.AlertInhibitRule{{SourceSeverity: "critical", TargetSeverity: "warning"}},
+				Rules:        []serviceApi.AlertRuleOverride{{Alert: "OperatorPodRestartingFrequently", Threshold: ptr.To("5")}},
+			},
+		},
+		{
+			name: "reserved receiver name",
+			alerting: serviceApi.Alerting{
+				Receivers: []serviceApi.AlertReceiver{{Name: "null", Webhook: webhook.Webhook}},
+			},
+			err: "receiver name 'null' is reserved",
+		},
+		{
+			name: "receiver without destination",
+			alerting: serviceApi.Alerting{
+				Receivers: []serviceApi.AlertReceiver{{Name: "ops"}},
+			},
+			err: "must set exactly one of webhook, email or pagerDuty",
+		},
+		{
+			name: "email password without username",
+			alerting: serviceApi.Alerting{
+				Receivers: []serviceApi.AlertReceiver{{Name: "mail", Email: &serviceApi.EmailReceiver{
+					To: "ops@example.com", From: "alerts@example.com", Smarthost: "smtp.example.com:587",
+					AuthPasswordSecret: ptr.To(secretKey("smtp", "password")),
+				}}},
+			},
+			err: "sets authPasswordSecret without authUsername",
+		},
+		{
+			name: "insecure PagerDuty URL",
+			alerting: serviceApi.Alerting{
+				Receivers: []serviceApi.AlertReceiver{{Name: "pd", PagerDuty: &serviceApi.PagerDutyReceiver{
+					RoutingKeySecret: secretKey("pd", "key"), URL: "http://events.example.com",
+				}}},
+			},
+			err: "pagerDuty url must be a valid https URL",
+		},
+		{
+			name:     "undefined default receiver",
+			alerting: serviceApi.Alerting{DefaultReceiver: "ops"},
+			err:      "default receiver 'ops' is not defined",
+		},
+		{
+			name: "route to undefined receiver",
+			alerting: serviceApi.Alerting{
+				Routes: []serviceApi.AlertRoute{{Receiver: "ops"}},
+			},
+			err: "route 0 references undefined receiver 'ops'",
+		},
+		{
+			name: "route by unknown component",
+			alerting: serviceApi.Alerting{
+				Receivers: []serviceApi.AlertReceiver{webhook},
+				Routes:    []serviceApi.AlertRoute{{Receiver: "ops", Components: []string{"unknown"}}},
+			},
+			err: "route 0 references unknown component 'unknown'",
+		},
+		{
+			name: "inhibit rule with the same severities",
+			alerting: serviceApi.Alerting{
+				InhibitRules: []serviceApi.AlertInhibitRule{{SourceSeverity: "warning", TargetSeverity: "warning"}},
+			},
+			err: "source and target severity must differ",
+		},
+		{
+			name: "invalid threshold",
+			alerting: serviceApi.Alerting{
+				Rules: []serviceApi.AlertRuleOverride{{Alert: "KserveDown", Threshold: ptr.To("high")}},
+			},
+			err: "invalid threshold 'high'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateAlerting(&tt.alerting, components)
+			if tt.err == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+			}
+		})
+	}
+}
+
+func TestRenderAlertmanagerConfig(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "alerting", Namespace: "monitoring"},
+		Data: map[string][]byte{
+			"webhook":  []byte("https://hooks.example.com/token\n"),
+			"routing":  []byte("routing-key"),
+			"password": []byte("secret"),
+		},
+	}
+
+	alerting := &serviceApi.Alerting{
+		Receivers: []serviceApi.AlertReceiver{
+			{Name: "ops", Webhook: &serviceApi.WebhookReceiver{URLSecret: secretKey("alerting", "webhook"), SendResolved: true}},
+			{Name: "pager", PagerDuty: &serviceApi.PagerDutyReceiver{RoutingKeySecret: secretKey("alerting", "routing")}},
+			{Name: "mail", Email: &serviceApi.EmailReceiver{
+				To: "ops@example.com", From: "alerts@example.com", Smarthost: "smtp.example.com:587",
+				AuthUsername: "alerts", AuthPasswordSecret: ptr.To(secretKey("alerting", "password")),
+			}},
+		},
+		Routes: []serviceApi.AlertRoute{
+			{Receiver: "pager", Components: []string{"kserve"}, Severities: []string{"critical"}, Continue: true},
+			{Receiver: "ops", Severities: []string{"critical", "warning"}},
+		},
+		InhibitRules: []serviceApi.AlertInhibitRule{{SourceSeverity: "critical", TargetSeverity: "warning"}},
+	}
+
+	t.Run("receivers credentials are resolved from secrets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(secret))
+		g.Expect(err).NotTo(HaveOccurred())
+
+		config, err := renderAlertmanagerConfig(ctx, cli, "monitoring", alerting)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(config).To(Equal(`route:
+    receiver: "null"
+    group_by:
+        - namespace
+        - alertname
+    routes:
+        - receiver: pager
+          matchers:
+            - platform_component="kserve"
+            - severity="critical"
+          continue: true
+        - receiver: ops
+          matchers:
+            - severity=~"critical|warning"
+receivers:
+    - name: "null"
+    - name: ops
+      webhook_configs:
+        - url: https://hooks.example.com/token
+          send_resolved: true
+    - name: pager
+      pagerduty_configs:
+        - routing_key: routing-key
+          send_resolved: false
+    - name: mail
+      email_configs:
+        - to: ops@example.com
+          from: alerts@example.com
+          smarthost: smtp.example.com:587
+          auth_username: alerts
+          auth_password: secret
+          send_resolved: false
+inhibit_rules:
+    - source_matchers:
+        - severity="critical"
+      target_matchers:
+        - severity="warning"
+      equal:
+        - namespace
+        - alertname
+`))
+	})
+
+	t.Run("missing secrets are reported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New()
+		g.Expect(err).NotTo(HaveOccurred())
+
+		_, err = renderAlertmanagerConfig(ctx, cli, "monitoring", alerting)
+		g.Expect(err).To(MatchError(ContainSubstring("receiver 'ops': failed to get secret monitoring/alerting")))
+	})
+}
+
+func TestAddAlertmanagerConfigMissingSecret(t *testing.T) {
+	g := NewWithT(t)
+
+	cli, err := fakeclient.New()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	monitoring := &serviceApi.Monitoring{
+		Spec: serviceApi.MonitoringSpec{
+			MonitoringCommonSpec: serviceApi.MonitoringCommonSpec{
+				Namespace: "monitoring",
+				Alerting: &serviceApi.Alerting{
+					Receivers: []serviceApi.AlertReceiver{
+						{Name: "ops", Webhook: &serviceApi.WebhookReceiver{URLSecret: secretKey("alerting", "webhook")}},
+					},
+				},
+			},
+		},
+	}
+
+	rr := &odhtypes.ReconciliationRequest{
+		Client:     cli,
+		Instance:   monitoring,
+		Conditions: conditions.NewManager(monitoring, status.ConditionTypeReady),
+	}
+
+	// the reconciliation must fail rather than render no configuration, which would
+	// let the garbage collector delete the one in use
+	err = addAlertmanagerConfig(t.Context(), rr, monitoring)
+	g.Expect(err).To(MatchError(ContainSubstring("receiver 'ops': failed to get secret monitoring/alerting")))
+	g.Expect(rr.Resources).To(BeEmpty())
+
+	c := rr.Conditions.GetCondition(status.ConditionAlertingAvailable)
+	g.Expect(c).NotTo(BeNil())
+	g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+	g.Expect(c.Reason).To(Equal(status.InvalidAlertingConfigReason))
+}
+
+func newPrometheusRule(name string, rules ...map[string]any) *unstructured.Unstructured {
+	items := make([]any, 0, len(rules))
+	for _, r := range rules {
+		items = append(items, r)
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"groups": []any{
+				map[string]any{"name": "group", "rules": items},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(gvk.PrometheusRule)
+	obj.SetName(name)
+
+	return obj
+}
+
+func TestCustomizePrometheusRule(t *testing.T) {
+	record := map[string]any{"record": "kserve:up", "expr": "up"}
+	restarting := map[string]any{
+		"alert":  "KserveRestarting",
+		"expr":   "increase(kube_pod_container_status_restarts_total[5m]) > 3\n",
+		"labels": map[string]any{"severity": "warning"},
+	}
+	down := map[string]any{
+		"alert":  "KserveDown",
+		"expr":   "absent(up{job='kserve'}) or up{job='kserve'} != 1",
+		"labels": map[string]any{"severity": "critical"},
+	}
+
+	rules := func(obj *unstructured.Unstructured) []any {
+		groups, _, _ := unstructured.NestedSlice(obj.Object, "spec", "groups")
+		if len(groups) == 0 {
+			return nil
+		}
+		items, _, _ := unstructured.NestedSlice(groups[0].(map[string]any), "rules")
+		return items
+	}
+
+	t.Run("alerts are labeled with their component", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := newPrometheusRule("kserve-prometheusrules", record, restarting)
+		g.Expect(customizePrometheusRule(obj, "kserve", nil)).To(Succeed())
+
+		items := rules(obj)
+		g.Expect(items).To(HaveLen(2))
+		g.Expect(items[0]).To(Equal(record))
+		g.Expect(items[1]).To(HaveKeyWithValue("labels", map[string]any{
+			"severity":          "warning",
+			alertComponentLabel: "kserve",
+		}))
+	})
+
+	t.Run("overrides disable alerts and replace thresholds", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := newPrometheusRule("kserve-prometheusrules", restarting, down)
+		err := customizePrometheusRule(obj, "kserve", map[string]serviceApi.AlertRuleOverride{
+			"KserveRestarting": {Alert: "KserveRestarting", Threshold: ptr.To("10")},
+			"KserveDown":       {Alert: "KserveDown", Enabled: ptr.To(false)},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		items := rules(obj)
+		g.Expect(items).To(HaveLen(1))
+		g.Expect(items[0]).To(HaveKeyWithValue("expr", "increase(kube_pod_container_status_restarts_total[5m]) > 10\n"))
+	})
+
+	t.Run("groups without remaining rules are dropped", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := newPrometheusRule("kserve-prometheusrules", down)
+		err := customizePrometheusRule(obj, "kserve", map[string]serviceApi.AlertRuleOverride{
+			"KserveDown": {Alert: "KserveDown", Enabled: ptr.To(false)},
+		})
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(rules(obj)).To(BeEmpty())
+	})
+
+	t.Run("thresholds cannot be overridden without a numeric comparison", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := newPrometheusRule("kserve-prometheusrules", down)
+		err := customizePrometheusRule(obj, "kserve", map[string]serviceApi.AlertRuleOverride{
+			"KserveDown": {Alert: "KserveDown", Threshold: ptr.To("2")},
+		})
+		g.Expect(err).To(MatchError(ContainSubstring("cannot override the threshold of alert 'KserveDown'")))
+	})
+}
+
+func TestOverrideAlertThreshold(t *testing.T) {
+	tests := []struct {
+		expr     string
+		expected string
+		ok       bool
+	}{
+		{expr: "rate(errors[5m]) > 0.05", expected: "rate(errors[5m]) > 7", ok: true},
+		{expr: "free_bytes<=1024\n", expected: "free_bytes<=7\n", ok: true},
+		{expr: "sum(x) by (route) > (14.40 * (1-0.99950))"},
+		{expr: "min(ready) == 0"},
+		{expr: "up != 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			g := NewWithT(t)
+
+			expr, err := overrideAlertThreshold(tt.expr, "7")
+			if tt.ok {
+				g.Expect(err).NotTo(HaveOccurred())
+				g.Expect(expr).To(Equal(tt.expected))
+			} else {
+				g.Expect(err).To(HaveOccurred())
+			}
+		})
+	}
+}
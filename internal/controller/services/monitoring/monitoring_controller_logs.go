@@ -0,0 +1,214 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	cr "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/components/registry"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	lokiLogsExporterName = "otlphttp/loki"
+	otlpLogsExporterName = "otlp/logs"
+)
+
+// logsComponentLabelNames maps the components whose manifests are not labeled with the
+// component name to the name used in their app.opendatahub.io label.
+var logsComponentLabelNames = map[string]string{
+	componentApi.ModelRegistryComponentName:        "model-registry-operator",
+	componentApi.ModelControllerComponentName:      "odh-model-controller",
+	componentApi.DataSciencePipelinesComponentName: "data-science-pipelines-operator",
+}
+
+// logsComponentLabel returns the label the pods of the given component are deployed with.
+func logsComponentLabel(component string, platform common.Platform) string {
+	name := component
+	if n, ok := logsComponentLabelNames[component]; ok {
+		name = n
+	}
+
+	// the dashboard manifests are labeled with the downstream name on RHOAI
+	if component == componentApi.DashboardComponentName && (platform == cluster.SelfManagedRhoai || platform == cluster.ManagedRhoai) {
+		name = "rhods-dashboard"
+	}
+
+	return labels.ODH.Component(name)
+}
+
+// logsComponents returns the names of the components whose pod logs can be selected.
+func logsComponents() ([]string, error) {
+	var components []string
+
+	err := cr.ForEach(func(ch cr.ComponentHandler) error {
+		components = append(components, ch.GetName())
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to iterate components: %w", err)
+	}
+
+	return components, nil
+}
+
+// validateLogs validates the logs configuration beyond what the CRD schema enforces, with the
+// same rules as the metrics and traces exporters for the custom exporters.
+func validateLogs(logs *serviceApi.Logs, components []string) (map[string]string, error) {
+	for _, c := range logs.Components {
+		if !slices.Contains(components, c) {
+			return nil, fmt.Errorf("unknown component '%s' (allowed: %v)", c, components)
+		}
+	}
+
+	if logs.Loki == nil && logs.OTLP == nil && len(logs.Exporters) == 0 {
+		return nil, errors.New("at least one of loki, otlp or exporters must be configured")
+	}
+
+	if logs.Loki != nil {
+		if !strings.HasPrefix(logs.Loki.URL, "https://") && !strings.HasPrefix(logs.Loki.URL, "http://") {
+			return nil, fmt.Errorf("invalid Loki URL '%s'", logs.Loki.URL)
+		}
+		if strings.HasPrefix(logs.Loki.URL, "http://") && !isLocalServiceEndpoint(logs.Loki.URL) {
+			return nil, errors.New("insecure HTTP Loki URL not allowed for external services")
+		}
+	}
+
+	if logs.OTLP != nil && logs.OTLP.Insecure && !isLocalServiceEndpoint("http://"+logs.OTLP.Endpoint) {
+		return nil, errors.New("insecure OTLP endpoint not allowed for external services")
+	}
+
+	for name := range logs.Exporters {
+		if name == lokiLogsExporterName || name == otlpLogsExporterName {
+			return nil, fmt.Errorf("exporter name '%s' is reserved and cannot be used", name)
+		}
+	}
+
+	exporters, err := validateExporters(logs.Exporters)
+	if err != nil {
+		return nil, err
+	}
+
+	return exporters, nil
+}
+
+// deployLogs handles the deployment of the logs collector, a daemonset OpenTelemetry collector
+// reading the pod logs of the platform namespaces from the nodes.
+func deployLogs(ctx context.Context, rr *odhtypes.ReconciliationRequest) error {
+	monitoring, ok := rr.Instance.(*serviceApi.Monitoring)
+	if !ok {
+		return errors.New("instance is not of type *services.Monitoring")
+	}
+
+	if monitoring.Spec.Logs == nil {
+		setConditionNotConfigured(rr, status.ConditionLogsAvailable, status.LogsNotConfiguredReason, status.LogsNotConfiguredMessage)
+		return nil
+	}
+
+	otcExists, err := cluster.HasCRD(ctx, rr.Client, gvk.OpenTelemetryCollector)
+	if err != nil {
+		return fmt.Errorf("failed to check if CRD OpenTelemetryCollector exists: %w", err)
+	}
+	if !otcExists {
+		rr.Conditions.MarkFalse(
+			status.ConditionLogsAvailable,
+			conditions.WithReason(gvk.OpenTelemetryCollector.Kind+"CRDNotFoundReason"),
+			conditions.WithMessage("%s CRD Not Found", gvk.OpenTelemetryCollector.Kind),
+		)
+		return nil
+	}
+
+	components, err := logsComponents()
+	if err != nil {
+		return err
+	}
+	if _, err := validateLogs(monitoring.Spec.Logs, components); err != nil {
+		setConditionFalse(rr, status.ConditionLogsAvailable, status.InvalidLogsConfigReason, err.Error())
+		return err
+	}
+
+	rr.Conditions.MarkTrue(status.ConditionLogsAvailable)
+
+	rr.Templates = append(rr.Templates,
+		odhtypes.TemplateInfo{FS: resourcesFS, Path: LogsCollectorTemplate},
+		odhtypes.TemplateInfo{FS: resourcesFS, Path: LogsCollectorRBACTemplate},
+	)
+
+	return nil
+}
+
+// addLogsData adds the logs collector data to the template data map.
+func addLogsData(logs *serviceApi.Logs, platform common.Platform, appNamespace string, operatorNamespace string, templateData map[string]any) error {
+	exporters := make(map[string]string)
+	if len(logs.Exporters) > 0 {
+		var err error
+		exporters, err = validateExporters(logs.Exporters)
+		if err != nil {
+			return err
+		}
+	}
+
+	exporterNames := make([]string, 0, len(exporters))
+	for n := range exporters {
+		exporterNames = append(exporterNames, n)
+	}
+	sort.Strings(exporterNames)
+
+	pipelineExporters := make([]string, 0, len(exporterNames)+2)
+
+	templateData["LogsLokiEndpoint"] = ""
+	templateData["LogsLokiTenantID"] = ""
+	templateData["LogsLokiServiceCA"] = false
+	if logs.Loki != nil {
+		templateData["LogsLokiEndpoint"] = strings.TrimSuffix(logs.Loki.URL, "/") + "/otlp"
+		// in-cluster Loki instances serve certificates signed by the service CA
+		templateData["LogsLokiServiceCA"] = strings.HasPrefix(logs.Loki.URL, "https://") && isLocalServiceEndpoint(logs.Loki.URL)
+		templateData["LogsLokiTenantID"] = logs.Loki.TenantID
+		pipelineExporters = append(pipelineExporters, lokiLogsExporterName)
+	}
+
+	templateData["LogsOTLPEndpoint"] = ""
+	templateData["LogsOTLPInsecure"] = false
+	if logs.OTLP != nil {
+		templateData["LogsOTLPEndpoint"] = logs.OTLP.Endpoint
+		templateData["LogsOTLPInsecure"] = logs.OTLP.Insecure
+		pipelineExporters = append(pipelineExporters, otlpLogsExporterName)
+	}
+
+	namespaces := []string{appNamespace, operatorNamespace}
+	for _, ns := range logs.Namespaces {
+		if !slices.Contains(namespaces, ns) {
+			namespaces = append(namespaces, ns)
+		}
+	}
+
+	retention := ""
+	if logs.Retention.Duration > 0 {
+		retention = logs.Retention.Duration.String()
+	}
+
+	componentLabels := make([]string, 0, len(logs.Components))
+	for _, c := range logs.Components {
+		componentLabels = append(componentLabels, logsComponentLabel(c, platform))
+	}
+
+	templateData["LogsNamespaces"] = namespaces
+	templateData["LogsComponentLabels"] = componentLabels
+	templateData["LogsRetention"] = retention
+	templateData["LogsExporters"] = exporters
+	templateData["LogsExporterNames"] = exporterNames
+	templateData["LogsPipelineExporters"] = append(pipelineExporters, exporterNames...)
+
+	return nil
+}
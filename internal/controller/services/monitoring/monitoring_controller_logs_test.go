@@ -0,0 +1,226 @@
+//nolint:testpackage // Need to test unexported logs helpers
+package monitoring
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	tmplutil "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/template"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateLogs(t *testing.T) {
+	components := []string{"dashboard", "kserve"}
+
+	tests := []struct {
+		name string
+		logs serviceApi.Logs
+		err  string
+	}{
+		{
+			name: "loki over https",
+			logs: serviceApi.Logs{
+				Components: []string{"dashboard"},
+				Loki:       &serviceApi.LokiLogsExporter{URL: "https://loki.example.com"},
+			},
+		},
+		{
+			name: "in-cluster loki over http",
+			logs: serviceApi.Logs{Loki: &serviceApi.LokiLogsExporter{URL: "http://loki-gateway.loki.svc:8080"}},
+		},
+		{
+			name: "external loki over http",
+			logs: serviceApi.Logs{Loki: &serviceApi.LokiLogsExporter{URL: "http://loki.example.com"}},
+			err:  "insecure HTTP Loki URL not allowed for external services",
+		},
+		{
+			name: "insecure in-cluster otlp",
+			logs: serviceApi.Logs{OTLP: &serviceApi.OTLPLogsExporter{Endpoint: "otel-gateway.observability.svc:4317", Insecure: true}},
+		},
+		{
+			name: "insecure external otlp",
+			logs: serviceApi.Logs{OTLP: &serviceApi.OTLPLogsExporter{Endpoint: "otlp.example.com:4317", Insecure: true}},
+			err:  "insecure OTLP endpoint not allowed for external services",
+		},
+		{
+			name: "unknown component",
+			logs: serviceApi.Logs{
+				Components: []string{"unknown"},
+				Loki:       &serviceApi.LokiLogsExporter{URL: "https://loki.example.com"},
+			},
+			err: "unknown component 'unknown'",
+		},
+		{
+			name: "no exporter",
+			logs: serviceApi.Logs{Namespaces: []string{"team-a"}},
+			err:  "at least one of loki, otlp or exporters must be configured",
+		},
+		{
+			name: "reserved exporter name",
+			logs: serviceApi.Logs{Exporters: map[string]runtime.RawExtension{
+				"otlp/logs": {Raw: []byte(`{"endpoint":"otlp.example.com:4317"}`)},
+			}},
+			err: "exporter name 'otlp/logs' is reserved",
+		},
+		{
+			name: "invalid exporter config",
+			logs: serviceApi.Logs{Exporters: map[string]runtime.RawExtension{
+				"debug": {Raw: []byte(`not-json`)},
+			}},
+			err: "debug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := validateLogs(&tt.logs, components)
+			if tt.err == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+			}
+		})
+	}
+}
+
+func TestLogsCollectorRendering(t *testing.T) {
+	content, err := resourcesFS.ReadFile(LogsCollectorTemplate)
+	NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+	tmpl := template.Must(template.New("logs").Option("missingkey=error").Funcs(tmplutil.TextTemplateFuncMap()).Parse(string(content)))
+
+	render := func(g Gomega, logs *serviceApi.Logs, platform common.Platform) map[string]any {
+		data := map[string]any{
+			"Namespace": "monitoring",
+		}
+		addResourceData(data)
+		g.Expect(addLogsData(logs, platform, "apps", "operator", data)).To(Succeed())
+
+		var buf bytes.Buffer
+		g.Expect(tmpl.Execute(&buf, data)).To(Succeed())
+
+		collector := map[string]any{}
+		g.Expect(yaml.Unmarshal(buf.Bytes(), &collector)).To(Succeed())
+
+		return collector
+	}
+
+	t.Run("all exporters with component filtering", func(t *testing.T) {
+		g := NewWithT(t)
+
+		collector := render(g, &serviceApi.Logs{
+			Namespaces: []string{"team-a", "apps"},
+			Components: []string{"dashboard", "kserve", "modelregistry"},
+			Retention:  metav1.Duration{Duration: 24 * time.Hour},
+			Loki:       &serviceApi.LokiLogsExporter{URL: "https://loki-gateway.loki.svc:8080/", TenantID: "platform"},
+			OTLP:       &serviceApi.OTLPLogsExporter{Endpoint: "otel-gateway.observability.svc:4317", Insecure: true},
+			Exporters: map[string]runtime.RawExtension{
+				"debug": {Raw: []byte(`{"verbosity":"basic"}`)},
+			},
+		}, cluster.SelfManagedRhoai)
+
+		filelog, _, err := unstructured.NestedMap(collector, "spec", "config", "receivers", "filelog")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(filelog["include"]).To(Equal([]any{
+			"/var/log/pods/apps_*/*/*.log",
+			"/var/log/pods/operator_*/*/*.log",
+			"/var/log/pods/team-a_*/*/*.log",
+		}))
+		g.Expect(filelog["start_at"]).To(Equal("beginning"))
+		g.Expect(filelog["exclude_older_than"]).To(Equal("24h0m0s"))
+		g.Expect(filelog["storage"]).To(Equal("file_storage/filelog"))
+
+		filter, found, err := unstructured.NestedStringSlice(collector, "spec", "config", "processors", "filter/components", "logs", "log_record")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+		g.Expect(filter).To(Equal([]string{
+			`resource.attributes["k8s.pod.labels.app.opendatahub.io/rhods-dashboard"] == nil and ` +
+				`resource.attributes["k8s.pod.labels.app.opendatahub.io/kserve"] == nil and ` +
+				`resource.attributes["k8s.pod.labels.app.opendatahub.io/model-registry-operator"] == nil`,
+		}))
+
+		labels, _, err := unstructured.NestedSlice(collector, "spec", "config", "processors", "k8sattributes", "extract", "labels")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(labels).To(Equal([]any{
+			map[string]any{"key": "app.opendatahub.io/rhods-dashboard", "from": "pod"},
+			map[string]any{"key": "app.opendatahub.io/kserve", "from": "pod"},
+			map[string]any{"key": "app.opendatahub.io/model-registry-operator", "from": "pod"},
+		}))
+
+		exporters, _, err := unstructured.NestedMap(collector, "spec", "config", "exporters")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exporters).To(Equal(map[string]any{
+			"otlphttp/loki": map[string]any{
+				"endpoint": "https://loki-gateway.loki.svc:8080/otlp",
+				"tls":      map[string]any{"ca_file": "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"},
+				"headers":  map[string]any{"X-Scope-OrgID": "platform"},
+			},
+			"otlp/logs": map[string]any{
+				"endpoint": "otel-gateway.observability.svc:4317",
+				"tls":      map[string]any{"insecure": true},
+			},
+			"debug": map[string]any{"verbosity": "basic"},
+		}))
+
+		pipeline, _, err := unstructured.NestedMap(collector, "spec", "config", "service", "pipelines", "logs")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(pipeline["processors"]).To(Equal([]any{"memory_limiter", "k8sattributes", "filter/components", "batch"}))
+		g.Expect(pipeline["exporters"]).To(Equal([]any{"otlphttp/loki", "otlp/logs", "debug"}))
+	})
+
+	t.Run("single exporter without filtering", func(t *testing.T) {
+		g := NewWithT(t)
+
+		collector := render(g, &serviceApi.Logs{
+			Loki: &serviceApi.LokiLogsExporter{URL: "https://loki.example.com"},
+		}, cluster.OpenDataHub)
+
+		filelog, _, err := unstructured.NestedMap(collector, "spec", "config", "receivers", "filelog")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(filelog["start_at"]).To(Equal("end"))
+		g.Expect(filelog).NotTo(HaveKey("exclude_older_than"))
+		g.Expect(filelog["storage"]).To(Equal("file_storage/filelog"))
+
+		extensions, _, err := unstructured.NestedStringSlice(collector, "spec", "config", "service", "extensions")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(extensions).To(Equal([]string{"file_storage/filelog"}))
+
+		_, found, err := unstructured.NestedFieldNoCopy(collector, "spec", "config", "processors", "filter/components")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(BeFalse())
+
+		exporters, _, err := unstructured.NestedMap(collector, "spec", "config", "exporters")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(exporters).To(Equal(map[string]any{
+			"otlphttp/loki": map[string]any{"endpoint": "https://loki.example.com/otlp"},
+		}))
+
+		pipeline, _, err := unstructured.NestedMap(collector, "spec", "config", "service", "pipelines", "logs")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(pipeline["processors"]).To(Equal([]any{"memory_limiter", "k8sattributes", "batch"}))
+		g.Expect(pipeline["exporters"]).To(Equal([]any{"otlphttp/loki"}))
+	})
+}
+
+func TestLogsComponentLabel(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(logsComponentLabel("kserve", cluster.OpenDataHub)).To(Equal("app.opendatahub.io/kserve"))
+	g.Expect(logsComponentLabel("modelcontroller", cluster.OpenDataHub)).To(Equal("app.opendatahub.io/odh-model-controller"))
+	g.Expect(logsComponentLabel("datasciencepipelines", cluster.OpenDataHub)).To(Equal("app.opendatahub.io/data-science-pipelines-operator"))
+	g.Expect(logsComponentLabel("dashboard", cluster.OpenDataHub)).To(Equal("app.opendatahub.io/dashboard"))
+	g.Expect(logsComponentLabel("dashboard", cluster.ManagedRhoai)).To(Equal("app.opendatahub.io/rhods-dashboard"))
+}
@@ -0,0 +1,164 @@
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	prometheusQueryTimeout = 10 * time.Second
+	// prometheusIdleConnTimeout is how long a connection to Prometheus is kept open unused.
+	prometheusIdleConnTimeout = time.Minute
+)
+
+// prometheusQueryFn evaluates an instant PromQL query and returns the value of its first sample, and
+// false if the query returned no sample.
+type prometheusQueryFn func(ctx context.Context, query string) (float64, bool, error)
+
+// newPrometheusQuery returns a function querying the Prometheus of the MonitoringStack. The
+// Prometheus web server requires a client certificate issued by the service CA, hence the
+// same certificate and CA as the Prometheus proxies are used.
+func newPrometheusQuery(ctx context.Context, cli client.Reader, namespace string) (prometheusQueryFn, error) {
+	httpClient, err := prometheusClients.get(ctx, cli, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("https://prometheus-operated.%s.svc:9090/api/v1/query", namespace)
+
+	return func(ctx context.Context, query string) (float64, bool, error) {
+		return queryPrometheus(ctx, httpClient, endpoint, query)
+	}, nil
+}
+
+// prometheusClients holds the HTTP client of the MonitoringStack Prometheus shared by the
+// reconciliations, so that its connections are reused rather than left open by a new
+// client on each reconciliation.
+var prometheusClients = &prometheusClientCache{}
+
+// prometheusClientCache caches the HTTP client of the MonitoringStack Prometheus until its
+// namespace or its certificates change, at which point the connections of the previous
+// client are closed.
+type prometheusClientCache struct {
+	mu     sync.Mutex
+	key    string
+	client *http.Client
+}
+
+func (c *prometheusClientCache) get(ctx context.Context, cli client.Reader, namespace string) (*http.Client, error) {
+	ca := &corev1.ConfigMap{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "prometheus-web-tls-ca"}, ca); err != nil {
+		return nil, fmt.Errorf("failed to get the Prometheus CA: %w", err)
+	}
+
+	certSecret := &corev1.Secret{}
+	if err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: "prometheus-operated-tls"}, certSecret); err != nil {
+		return nil, fmt.Errorf("failed to get the Prometheus client certificate: %w", err)
+	}
+
+	caPEM := []byte(ca.Data["service-ca.crt"])
+	certPEM := certSecret.Data[corev1.TLSCertKey]
+	keyPEM := certSecret.Data[corev1.TLSPrivateKeyKey]
+
+	sum := sha256.Sum256(bytes.Join([][]byte{[]byte(namespace), caPEM, certPEM, keyPEM}, []byte{0}))
+	key := hex.EncodeToString(sum[:])
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client != nil && c.key == key {
+		return c.client, nil
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, errors.New("the Prometheus CA has not been injected yet")
+	}
+
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Prometheus client certificate: %w", err)
+	}
+
+	if c.client != nil {
+		c.client.CloseIdleConnections()
+	}
+
+	c.key = key
+	c.client = &http.Client{
+		Timeout: prometheusQueryTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				RootCAs:      pool,
+				Certificates: []tls.Certificate{cert},
+			},
+			// the queries run every few minutes, connections are not kept
+			// open in between
+			IdleConnTimeout: prometheusIdleConnTimeout,
+		},
+	}
+
+	return c.client, nil
+}
+
+// queryPrometheus evaluates an instant query with the Prometheus HTTP API.
+func queryPrometheus(ctx context.Context, httpClient *http.Client, endpoint string, query string) (float64, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"?"+url.Values{"query": {query}}.Encode(), nil)
+	if err != nil {
+		return 0, false, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, false, err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+		Data   struct {
+			Result []struct {
+				Value []any `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return 0, false, fmt.Errorf("failed to decode the query response (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if result.Status != "success" {
+		return 0, false, fmt.Errorf("query failed (HTTP %d): %s", resp.StatusCode, result.Error)
+	}
+	if len(result.Data.Result) == 0 || len(result.Data.Result[0].Value) != 2 {
+		return 0, false, nil
+	}
+
+	raw, ok := result.Data.Result[0].Value[1].(string)
+	if !ok {
+		return 0, false, errors.New("unexpected sample value in the query response")
+	}
+
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("unexpected sample value in the query response: %w", err)
+	}
+
+	return value, true, nil
+}
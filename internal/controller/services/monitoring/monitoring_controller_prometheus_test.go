@@ -0,0 +1,89 @@
+//nolint:testpackage // Need to test unexported Prometheus helpers
+package monitoring
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestQueryPrometheus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query") {
+		case "up":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[{"metric":{},"value":[1700000000,"0.25"]}]}}`))
+		case "missing":
+			_, _ = w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[]}}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		}
+	}))
+	defer server.Close()
+
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	value, found, err := queryPrometheus(ctx, server.Client(), server.URL, "up")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeTrue())
+	g.Expect(value).To(Equal(0.25))
+
+	_, found, err = queryPrometheus(ctx, server.Client(), server.URL, "missing")
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(found).To(BeFalse())
+
+	_, _, err = queryPrometheus(ctx, server.Client(), server.URL, "{")
+	g.Expect(err).To(MatchError(ContainSubstring("parse error")))
+}
+
+func TestPrometheusClientCache(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+	ns := "opendatahub-monitoring"
+
+	newSecret := func() *corev1.Secret {
+		secret, err := cluster.GenerateSelfSignedCertificateAsSecret("prometheus-operated-tls", "prometheus-operated", ns)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		return secret
+	}
+
+	secret := newSecret()
+	ca := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "prometheus-web-tls-ca", Namespace: ns},
+		Data:       map[string]string{"service-ca.crt": string(secret.Data[corev1.TLSCertKey])},
+	}
+
+	cli, err := fakeclient.New(fakeclient.WithObjects(secret, ca))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	cache := &prometheusClientCache{}
+
+	first, err := cache.get(ctx, cli, ns)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(first.Transport).To(HaveField("IdleConnTimeout", prometheusIdleConnTimeout))
+
+	// the client is reused while the certificates are unchanged
+	second, err := cache.get(ctx, cli, ns)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(second).To(BeIdenticalTo(first))
+
+	// a rotated certificate replaces the client
+	rotated := newSecret()
+	secret.Data = rotated.Data
+	g.Expect(cli.Update(ctx, secret)).To(Succeed())
+
+	third, err := cache.get(ctx, cli, ns)
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(third).NotTo(BeIdenticalTo(first))
+}
@@ -0,0 +1,293 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+const (
+	// remoteWriteCheckInterval is how often the health of the remote write endpoints is refreshed.
+	remoteWriteCheckInterval = 5 * time.Minute
+	remoteWriteRateWindow    = "5m"
+	// remoteWriteMaxLag is how far the samples sent may lag behind the samples ingested, as in
+	// the PrometheusRemoteWriteBehind alert of the Prometheus mixin.
+	remoteWriteMaxLag = 2 * time.Minute
+)
+
+// remoteWriteSecretRefs returns the Secret keys referenced by a remote write endpoint.
+func remoteWriteSecretRefs(rw serviceApi.RemoteWrite) []corev1.SecretKeySelector {
+	var refs []corev1.SecretKeySelector
+
+	if rw.BasicAuth != nil {
+		refs = append(refs, rw.BasicAuth.UsernameSecret, rw.BasicAuth.PasswordSecret)
+	}
+	if rw.BearerTokenSecret != nil {
+		refs = append(refs, *rw.BearerTokenSecret)
+	}
+	if rw.TLS != nil {
+		for _, ref := range []*corev1.SecretKeySelector{rw.TLS.CASecret, rw.TLS.CertSecret, rw.TLS.KeySecret} {
+			if ref != nil {
+				refs = append(refs, *ref)
+			}
+		}
+	}
+
+	return refs
+}
+
+// validateRemoteWrite validates the remote write endpoints beyond what the CRD schema enforces,
+// and checks that the Secrets they reference exist, as Prometheus Operator does not reconcile
+// the Prometheus instance at all when one of them is missing.
+func validateRemoteWrite(ctx context.Context, cli client.Reader, namespace string, remoteWrites []serviceApi.RemoteWrite) error {
+	names := make(map[string]bool, len(remoteWrites))
+
+	for _, rw := range remoteWrites {
+		if names[rw.Name] {
+			return fmt.Errorf("duplicate remote write name '%s'", rw.Name)
+		}
+		names[rw.Name] = true
+
+		if rw.BasicAuth != nil && rw.BearerTokenSecret != nil {
+			return fmt.Errorf("remote write '%s' must not set both basicAuth and bearerTokenSecret", rw.Name)
+		}
+		if rw.TLS != nil && (rw.TLS.CertSecret == nil) != (rw.TLS.KeySecret == nil) {
+			return fmt.Errorf("remote write '%s' must set tls certSecret and keySecret together", rw.Name)
+		}
+
+		for i, rc := range rw.WriteRelabelConfigs {
+			if rc.Regex != "" {
+				if _, err := regexp.Compile("^(?:" + rc.Regex + ")$"); err != nil {
+					return fmt.Errorf("remote write '%s' relabel config %d has an invalid regex: %w", rw.Name, i, err)
+				}
+			}
+		}
+
+		for _, ref := range remoteWriteSecretRefs(rw) {
+			if ref.Name == "" || ref.Key == "" {
+				return fmt.Errorf("remote write '%s' references a secret without name or key", rw.Name)
+			}
+			if _, err := secretKeyValue(ctx, cli, namespace, ref); err != nil {
+				return fmt.Errorf("remote write '%s': %w", rw.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// remoteWriteSpecs converts the remote write endpoints to the Prometheus Operator API the
+// MonitoringStack embeds.
+func remoteWriteSpecs(remoteWrites []serviceApi.RemoteWrite) []promv1.RemoteWriteSpec {
+	specs := make([]promv1.RemoteWriteSpec, 0, len(remoteWrites))
+
+	for _, rw := range remoteWrites {
+		spec := promv1.RemoteWriteSpec{
+			Name:          rw.Name,
+			URL:           rw.URL,
+			RemoteTimeout: promv1.Duration(rw.RemoteTimeout),
+			Headers:       rw.Headers,
+		}
+
+		if rw.BasicAuth != nil {
+			spec.BasicAuth = &promv1.BasicAuth{
+				Username: rw.BasicAuth.UsernameSecret,
+				Password: rw.BasicAuth.PasswordSecret,
+			}
+		}
+
+		if rw.BearerTokenSecret != nil {
+			spec.Authorization = &promv1.Authorization{
+				SafeAuthorization: promv1.SafeAuthorization{
+					Type:        "Bearer",
+					Credentials: rw.BearerTokenSecret,
+				},
+			}
+		}
+
+		if tls := rw.TLS; tls != nil {
+			spec.TLSConfig = &promv1.TLSConfig{
+				SafeTLSConfig: promv1.SafeTLSConfig{
+					KeySecret: tls.KeySecret,
+				},
+			}
+			if tls.CASecret != nil {
+				spec.TLSConfig.CA.Secret = tls.CASecret
+			}
+			if tls.CertSecret != nil {
+				spec.TLSConfig.Cert.Secret = tls.CertSecret
+			}
+			if tls.ServerName != "" {
+				spec.TLSConfig.ServerName = ptr.To(tls.ServerName)
+			}
+			if tls.InsecureSkipVerify {
+				spec.TLSConfig.InsecureSkipVerify = ptr.To(true)
+			}
+		}
+
+		for _, rc := range rw.WriteRelabelConfigs {
+			relabel := promv1.RelabelConfig{
+				TargetLabel: rc.TargetLabel,
+				Regex:       rc.Regex,
+				Replacement: rc.Replacement,
+				Action:      rc.Action,
+			}
+			if rc.Separator != "" {
+				relabel.Separator = ptr.To(rc.Separator)
+			}
+			for _, l := range rc.SourceLabels {
+				relabel.SourceLabels = append(relabel.SourceLabels, promv1.LabelName(l))
+			}
+			spec.WriteRelabelConfigs = append(spec.WriteRelabelConfigs, relabel)
+		}
+
+		if qc := rw.QueueConfig; qc != nil {
+			spec.QueueConfig = &promv1.QueueConfig{
+				Capacity:          int(qc.Capacity),
+				MinShards:         int(qc.MinShards),
+				MaxShards:         int(qc.MaxShards),
+				MaxSamplesPerSend: int(qc.MaxSamplesPerSend),
+				BatchSendDeadline: durationOrNil(qc.BatchSendDeadline),
+				MinBackoff:        durationOrNil(qc.MinBackoff),
+				MaxBackoff:        durationOrNil(qc.MaxBackoff),
+				RetryOnRateLimit:  qc.RetryOnRateLimit,
+			}
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+func durationOrNil(d string) *promv1.Duration {
+	if d == "" {
+		return nil
+	}
+	return ptr.To(promv1.Duration(d))
+}
+
+// addRemoteWriteData adds the remote write endpoints to the template data map.
+func addRemoteWriteData(metrics *serviceApi.Metrics, templateData map[string]any) {
+	templateData["RemoteWrite"] = remoteWriteSpecs(metrics.RemoteWrite)
+}
+
+// checkRemoteWrite validates the remote write configuration of the MonitoringStack.
+func checkRemoteWrite(ctx context.Context, rr *odhtypes.ReconciliationRequest, monitoring *serviceApi.Monitoring) error {
+	if len(monitoring.Spec.Metrics.RemoteWrite) == 0 {
+		setConditionNotConfigured(rr, status.ConditionRemoteWriteAvailable, status.RemoteWriteNotConfiguredReason, status.RemoteWriteNotConfiguredMessage)
+		return nil
+	}
+
+	if err := validateRemoteWrite(ctx, rr.Client, monitoring.Spec.Namespace, monitoring.Spec.Metrics.RemoteWrite); err != nil {
+		setConditionFalse(rr, status.ConditionRemoteWriteAvailable, status.InvalidRemoteWriteConfigReason, err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// updateRemoteWriteCondition reports the health of the remote write endpoints, as measured by
+// the remote storage metrics of the Prometheus of the MonitoringStack, and schedules its
+// periodic refresh. The queries share the Prometheus client of the SLO compliance.
+func updateRemoteWriteCondition(ctx context.Context, rr *odhtypes.ReconciliationRequest) error {
+	monitoring, ok := rr.Instance.(*serviceApi.Monitoring)
+	if !ok {
+		return errors.New("instance is not of type *services.Monitoring")
+	}
+
+	if monitoring.Spec.Metrics == nil || len(monitoring.Spec.Metrics.RemoteWrite) == 0 {
+		return nil
+	}
+
+	query, err := newPrometheusQuery(ctx, rr.Client, monitoring.Spec.Namespace)
+	if err != nil {
+		rr.Conditions.MarkUnknown(
+			status.ConditionRemoteWriteAvailable,
+			conditions.WithReason(status.RemoteWritePendingReason),
+			conditions.WithMessage("Cannot query the MonitoringStack Prometheus: %v", err),
+		)
+
+		return odherrors.NewRequeueAfterError(remoteWriteCheckInterval)
+	}
+
+	failed, message := remoteWriteFailure(ctx, monitoring.Spec.Metrics.RemoteWrite, query)
+	switch {
+	case failed:
+		rr.Conditions.MarkFalse(
+			status.ConditionRemoteWriteAvailable,
+			conditions.WithReason(status.RemoteWriteFailedReason),
+			conditions.WithMessage("%s", message),
+		)
+	case message != "":
+		rr.Conditions.MarkUnknown(
+			status.ConditionRemoteWriteAvailable,
+			conditions.WithReason(status.RemoteWritePendingReason),
+			conditions.WithMessage("%s", message),
+		)
+	default:
+		rr.Conditions.MarkTrue(status.ConditionRemoteWriteAvailable)
+	}
+
+	return odherrors.NewRequeueAfterError(remoteWriteCheckInterval)
+}
+
+// remoteWriteFailure evaluates, for each remote write endpoint, the rate of samples failing
+// to be sent and how far behind the queue lags the samples ingested, and reports whether one
+// of the endpoints is failing. The message of an endpoint without data yet is returned with
+// no failure.
+func remoteWriteFailure(ctx context.Context, remoteWrites []serviceApi.RemoteWrite, query prometheusQueryFn) (bool, string) {
+	var pending []string
+
+	for _, rw := range remoteWrites {
+		selector := fmt.Sprintf("{remote_name=%q}", rw.Name)
+
+		failedRate, found, err := query(ctx, fmt.Sprintf(
+			"sum(rate(prometheus_remote_storage_samples_failed_total%s[%s]))", selector, remoteWriteRateWindow))
+		if err != nil {
+			return false, fmt.Sprintf("Failed to query the remote write '%s' metrics: %v", rw.Name, err)
+		}
+		if !found {
+			pending = append(pending, rw.Name)
+			continue
+		}
+		if failedRate > 0 {
+			return true, fmt.Sprintf("Remote write '%s' fails to send %s samples/s", rw.Name, strconv.FormatFloat(failedRate, 'f', -1, 64))
+		}
+
+		lag, found, err := query(ctx, fmt.Sprintf(
+			"max(prometheus_remote_storage_highest_timestamp_in_seconds - ignoring(remote_name, url) group_right "+
+				"prometheus_remote_storage_queue_highest_sent_timestamp_seconds%s)", selector))
+		if err != nil {
+			return false, fmt.Sprintf("Failed to query the remote write '%s' metrics: %v", rw.Name, err)
+		}
+		if !found {
+			pending = append(pending, rw.Name)
+			continue
+		}
+		if lag > remoteWriteMaxLag.Seconds() {
+			return true, fmt.Sprintf("Remote write '%s' is %s behind", rw.Name, time.Duration(lag*float64(time.Second)).Round(time.Second))
+		}
+	}
+
+	if len(pending) > 0 {
+		return false, fmt.Sprintf("Waiting for the remote storage metrics of %s", strings.Join(pending, ", "))
+	}
+
+	return false, ""
+}
@@ -0,0 +1,265 @@
+//nolint:testpackage // Need to test unexported remote write helpers
+package monitoring
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"text/template"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	tmplutil "github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/template"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func thanosRemoteWrite() serviceApi.RemoteWrite {
+	return serviceApi.RemoteWrite{
+		Name:          "thanos",
+		URL:           "https://thanos-receive.example.com/api/v1/receive",
+		RemoteTimeout: "30s",
+		Headers:       map[string]string{"THANOS-TENANT": "cluster-a"},
+		BasicAuth: &serviceApi.RemoteWriteBasicAuth{
+			UsernameSecret: secretKey("remote-write", "username"),
+			PasswordSecret: secretKey("remote-write", "password"),
+		},
+		TLS: &serviceApi.RemoteWriteTLS{
+			CASecret:   ptr.To(secretKey("remote-write", "ca.crt")),
+			ServerName: "thanos-receive.example.com",
+		},
+		WriteRelabelConfigs: []serviceApi.WriteRelabelConfig{
+			{SourceLabels: []string{"__name__"}, Regex: "go_.*", Action: "drop"},
+		},
+		QueueConfig: &serviceApi.RemoteWriteQueueConfig{
+			MaxShards:         10,
+			MaxSamplesPerSend: 2000,
+			MinBackoff:        "100ms",
+		},
+	}
+}
+
+func TestValidateRemoteWrite(t *testing.T) {
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "remote-write", Namespace: "monitoring"},
+		Data: map[string][]byte{
+			"username": []byte("prometheus"),
+			"password": []byte("secret"),
+			"ca.crt":   []byte("-----BEGIN CERTIFICATE-----"),
+		},
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(rw *serviceApi.RemoteWrite)
+		err    string
+	}{
+		{
+			name:   "valid endpoint",
+			mutate: func(*serviceApi.RemoteWrite) {},
+		},
+		{
+			name: "basic auth and bearer token",
+			mutate: func(rw *serviceApi.RemoteWrite) {
+				rw.BearerTokenSecret = ptr.To(secretKey("remote-write", "password"))
+			},
+			err: "must not set both basicAuth and bearerTokenSecret",
+		},
+		{
+			name: "client certificate without key",
+			mutate: func(rw *serviceApi.RemoteWrite) {
+				rw.TLS.CertSecret = ptr.To(secretKey("remote-write", "tls.crt"))
+			},
+			err: "must set tls certSecret and keySecret together",
+		},
+		{
+			name: "invalid relabel regex",
+			mutate: func(rw *serviceApi.RemoteWrite) {
+				rw.WriteRelabelConfigs[0].Regex = "go_(.*"
+			},
+			err: "relabel config 0 has an invalid regex",
+		},
+		{
+			name: "missing secret",
+			mutate: func(rw *serviceApi.RemoteWrite) {
+				rw.BasicAuth.PasswordSecret = secretKey("missing", "password")
+			},
+			err: "failed to get secret monitoring/missing",
+		},
+		{
+			name: "missing secret key",
+			mutate: func(rw *serviceApi.RemoteWrite) {
+				rw.TLS.CASecret = ptr.To(secretKey("remote-write", "service-ca.crt"))
+			},
+			err: "secret monitoring/remote-write has no key 'service-ca.crt'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cli, err := fakeclient.New(fakeclient.WithObjects(secret))
+			g.Expect(err).NotTo(HaveOccurred())
+
+			rw := thanosRemoteWrite()
+			tt.mutate(&rw)
+
+			err = validateRemoteWrite(ctx, cli, "monitoring", []serviceApi.RemoteWrite{rw})
+			if tt.err == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+			}
+		})
+	}
+}
+
+func TestMonitoringStackRemoteWriteRendering(t *testing.T) {
+	content, err := resourcesFS.ReadFile(MonitoringStackTemplate)
+	NewWithT(t).Expect(err).NotTo(HaveOccurred())
+
+	tmpl := template.Must(template.New("stack").Option("missingkey=error").Funcs(tmplutil.TextTemplateFuncMap()).Parse(string(content)))
+
+	render := func(g Gomega, metrics *serviceApi.Metrics) map[string]any {
+		data := map[string]any{
+			"Namespace": "monitoring",
+			"Replicas":  "2",
+		}
+		addResourceData(data)
+		addStorageData(metrics, data)
+		addRemoteWriteData(metrics, data)
+
+		var buf bytes.Buffer
+		g.Expect(tmpl.Execute(&buf, data)).To(Succeed())
+
+		stack := map[string]any{}
+		g.Expect(yaml.Unmarshal(buf.Bytes(), &stack)).To(Succeed())
+
+		return stack
+	}
+
+	t.Run("remote write endpoints", func(t *testing.T) {
+		g := NewWithT(t)
+
+		stack := render(g, &serviceApi.Metrics{RemoteWrite: []serviceApi.RemoteWrite{thanosRemoteWrite()}})
+
+		remoteWrite, found, err := unstructured.NestedSlice(stack, "spec", "prometheusConfig", "remoteWrite")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(BeTrue())
+		g.Expect(remoteWrite).To(HaveLen(1))
+		g.Expect(remoteWrite[0]).To(Equal(map[string]any{
+			"name":          "thanos",
+			"url":           "https://thanos-receive.example.com/api/v1/receive",
+			"remoteTimeout": "30s",
+			"headers":       map[string]any{"THANOS-TENANT": "cluster-a"},
+			"basicAuth": map[string]any{
+				"username": map[string]any{"name": "remote-write", "key": "username"},
+				"password": map[string]any{"name": "remote-write", "key": "password"},
+			},
+			"tlsConfig": map[string]any{
+				"ca":         map[string]any{"secret": map[string]any{"name": "remote-write", "key": "ca.crt"}},
+				"cert":       map[string]any{},
+				"serverName": "thanos-receive.example.com",
+			},
+			"writeRelabelConfigs": []any{
+				map[string]any{"sourceLabels": []any{"__name__"}, "regex": "go_.*", "action": "drop"},
+			},
+			"queueConfig": map[string]any{
+				"maxShards":         float64(10),
+				"maxSamplesPerSend": float64(2000),
+				"minBackoff":        "100ms",
+			},
+		}))
+	})
+
+	t.Run("no remote write", func(t *testing.T) {
+		g := NewWithT(t)
+
+		stack := render(g, &serviceApi.Metrics{})
+
+		_, found, err := unstructured.NestedFieldNoCopy(stack, "spec", "prometheusConfig", "remoteWrite")
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(found).To(BeFalse())
+	})
+}
+
+func TestRemoteWriteFailure(t *testing.T) {
+	ctx := context.Background()
+
+	remoteWrites := []serviceApi.RemoteWrite{{Name: "thanos"}, {Name: "backup"}}
+
+	// query answers the failed samples rate and the queue lag of each endpoint from
+	// the given values, an endpoint missing from the map has no data
+	query := func(failed, lag map[string]float64) prometheusQueryFn {
+		return func(_ context.Context, q string) (float64, bool, error) {
+			values := lag
+			if strings.Contains(q, "prometheus_remote_storage_samples_failed_total") {
+				values = failed
+			}
+			for name, v := range values {
+				if strings.Contains(q, fmt.Sprintf("{remote_name=%q}", name)) {
+					return v, true, nil
+				}
+			}
+			return 0, false, nil
+		}
+	}
+
+	tests := []struct {
+		name    string
+		query   prometheusQueryFn
+		failed  bool
+		message string
+	}{
+		{
+			name:  "healthy endpoints",
+			query: query(map[string]float64{"thanos": 0, "backup": 0}, map[string]float64{"thanos": 5, "backup": 30}),
+		},
+		{
+			name:    "no metrics yet",
+			query:   query(map[string]float64{"thanos": 0}, map[string]float64{"thanos": 5}),
+			message: "Waiting for the remote storage metrics of backup",
+		},
+		{
+			name:    "failing samples",
+			query:   query(map[string]float64{"thanos": 0, "backup": 12.5}, map[string]float64{"thanos": 5, "backup": 5}),
+			failed:  true,
+			message: "Remote write 'backup' fails to send 12.5 samples/s",
+		},
+		{
+			name:    "queue behind",
+			query:   query(map[string]float64{"thanos": 0, "backup": 0}, map[string]float64{"thanos": 600.4, "backup": 5}),
+			failed:  true,
+			message: "Remote write 'thanos' is 10m0s behind",
+		},
+		{
+			name: "query error",
+			query: func(context.Context, string) (float64, bool, error) {
+				return 0, false, errors.New("connection refused")
+			},
+			message: "Failed to query the remote write 'thanos' metrics: connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			failed, message := remoteWriteFailure(ctx, remoteWrites, tt.query)
+			g.Expect(failed).To(Equal(tt.failed))
+			g.Expect(message).To(Equal(tt.message))
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	odhtypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+const (
+	defaultSLOWindow = "30d"
+
+	// sloComplianceInterval is how often the SLO compliance is refreshed in the status.
+	sloComplianceInterval = 5 * time.Minute
+
+	sloErrorBudgetBurnAlert = "SLOErrorBudgetBurn"
+)
+
+var (
+	metricSelectorRE = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*(\{[^{}\[\]]*\})?$`)
+	labelMatchersRE  = regexp.MustCompile(`^[^{}\[\]]*$`)
+)
+
+// sloBurnRateWindow is a multi-window, multi-burn-rate alerting window, as described in the
+// Google SRE workbook: the alert fires when both windows consume the error budget faster
+// than the given share of the budget over the long window.
+type sloBurnRateWindow struct {
+	long, short string
+	longHours   int64
+	budgetShare string
+	severity    string
+	forDuration string
+}
+
+var sloBurnRateWindows = []sloBurnRateWindow{
+	{long: "1h", short: "5m", longHours: 1, budgetShare: "0.02", severity: serviceApi.AlertSeverityCritical, forDuration: "2m"},
+	{long: "6h", short: "30m", longHours: 6, budgetShare: "0.05", severity: serviceApi.AlertSeverityCritical, forDuration: "15m"},
+	{long: "1d", short: "2h", longHours: 24, budgetShare: "0.1", severity: serviceApi.AlertSeverityWarning, forDuration: "1h"},
+	{long: "3d", short: "6h", longHours: 72, budgetShare: "0.1", severity: serviceApi.AlertSeverityWarning, forDuration: "3h"},
+}
+
+// sloRateWindows are the windows the SLI error ratio is recorded over, the compliance
+// window aside.
+var sloRateWindows = []string{"5m", "30m", "1h", "2h", "6h", "1d", "3d"}
+
+func sloWindow(slo serviceApi.ServiceLevelObjective) string {
+	return getStringValueOrDefault(slo.Window, defaultSLOWindow)
+}
+
+func sloErrorRatioRecord(window string) string {
+	return "slo:sli_error:ratio_rate" + window
+}
+
+// sloErrorBudget returns the error budget of the SLO as a ratio, e.g. 0.001 for "99.9".
+func sloErrorBudget(slo serviceApi.ServiceLevelObjective) (*big.Rat, error) {
+	objective, ok := new(big.Rat).SetString(slo.Objective)
+	if !ok || objective.Sign() <= 0 || objective.Cmp(big.NewRat(100, 1)) >= 0 {
+		return nil, fmt.Errorf("SLO '%s' objective must be a percentage between 0 and 100, got '%s'", slo.Name, slo.Objective)
+	}
+
+	budget := new(big.Rat).Sub(big.NewRat(100, 1), objective)
+
+	return budget.Quo(budget, big.NewRat(100, 1)), nil
+}
+
+// formatRat formats a ratio as a decimal number without trailing zeros.
+func formatRat(r *big.Rat) string {
+	s := r.FloatString(10)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// validateSLOs validates the SLOs beyond what the CRD schema enforces, so that the generated
+// rules are always accepted by Prometheus.
+func validateSLOs(slos []serviceApi.ServiceLevelObjective, components []string) error {
+	names := make(map[string]bool, len(slos))
+	for _, slo := range slos {
+		if names[slo.Name] {
+			return fmt.Errorf("duplicate SLO name '%s'", slo.Name)
+		}
+		names[slo.Name] = true
+
+		if !contains(components, slo.Component) {
+			return fmt.Errorf("SLO '%s' references unknown component '%s' (allowed: %v)", slo.Name, slo.Component, components)
+		}
+		if _, err := sloErrorBudget(slo); err != nil {
+			return err
+		}
+		if _, err := sloWindowDays(sloWindow(slo)); err != nil {
+			return fmt.Errorf("SLO '%s': %w", slo.Name, err)
+		}
+
+		indicator := slo.Indicator
+		switch {
+		case indicator.Ratio != nil && indicator.Latency != nil, indicator.Ratio == nil && indicator.Latency == nil:
+			return fmt.Errorf("SLO '%s' must set exactly one of the ratio or latency indicators", slo.Name)
+		case indicator.Ratio != nil:
+			if !metricSelectorRE.MatchString(indicator.Ratio.Errors) || !metricSelectorRE.MatchString(indicator.Ratio.Total) {
+				return fmt.Errorf("SLO '%s' ratio indicator counters must be metric selectors without range", slo.Name)
+			}
+		case indicator.Latency != nil:
+			if !metricSelectorRE.MatchString(indicator.Latency.Histogram) || strings.Contains(indicator.Latency.Histogram, "{") {
+				return fmt.Errorf("SLO '%s' latency indicator histogram must be a metric name", slo.Name)
+			}
+			if !labelMatchersRE.MatchString(indicator.Latency.Selector) {
+				return fmt.Errorf("SLO '%s' latency indicator selector must be a list of label matchers", slo.Name)
+			}
+			if _, err := strconv.ParseFloat(indicator.Latency.Threshold, 64); err != nil {
+				return fmt.Errorf("SLO '%s' latency indicator threshold must be a number, got '%s'", slo.Name, indicator.Latency.Threshold)
+			}
+		}
+	}
+
+	return nil
+}
+
+func sloWindowDays(window string) (int64, error) {
+	days, err := strconv.ParseInt(strings.TrimSuffix(window, "d"), 10, 64)
+	if err != nil || !strings.HasSuffix(window, "d") || days <= 0 {
+		return 0, fmt.Errorf("window must be a number of days, e.g. 30d, got '%s'", window)
+	}
+	return days, nil
+}
+
+// sloErrorRatioExpr returns the expression of the SLI error ratio over the given window.
+func sloErrorRatioExpr(indicator serviceApi.SLOIndicator, window string) string {
+	if indicator.Ratio != nil {
+		return fmt.Sprintf("(sum(rate(%s[%s])))\n/\n(sum(rate(%s[%s])))",
+			indicator.Ratio.Errors, window, indicator.Ratio.Total, window)
+	}
+
+	latency := indicator.Latency
+	bucketMatchers := fmt.Sprintf("le=%q", latency.Threshold)
+	if latency.Selector != "" {
+		bucketMatchers = latency.Selector + "," + bucketMatchers
+	}
+
+	return fmt.Sprintf("1 - (\n  (sum(rate(%s_bucket{%s}[%s])))\n  /\n  (sum(rate(%s_count{%s}[%s])))\n)",
+		latency.Histogram, bucketMatchers, window, latency.Histogram, latency.Selector, window)
+}
+
+// sloRuleGroups generates, for each SLO, the recording rules of its SLI error ratio over the
+// alerting windows and the compliance window, and its multi-window, multi-burn-rate alerts,
+// following the approach of Sloth and Pyrra.
+func sloRuleGroups(slos []serviceApi.ServiceLevelObjective) ([]promv1.RuleGroup, error) {
+	groups := make([]promv1.RuleGroup, 0, len(slos))
+
+	for _, slo := range slos {
+		budget, err := sloErrorBudget(slo)
+		if err != nil {
+			return nil, err
+		}
+
+		window := sloWindow(slo)
+		days, err := sloWindowDays(window)
+		if err != nil {
+			return nil, fmt.Errorf("SLO '%s': %w", slo.Name, err)
+		}
+
+		labels := map[string]string{
+			"slo":               slo.Name,
+			alertComponentLabel: slo.Component,
+		}
+		selector := fmt.Sprintf("{slo=%q}", slo.Name)
+
+		group := promv1.RuleGroup{Name: "slo-" + slo.Name}
+
+		for _, w := range sloRateWindows {
+			group.Rules = append(group.Rules, promv1.Rule{
+				Record: sloErrorRatioRecord(w),
+				Expr:   intstr.FromString(sloErrorRatioExpr(slo.Indicator, w)),
+				Labels: labels,
+			})
+		}
+
+		// The error ratio over the compliance window is averaged from the 5m one, as
+		// computing the rate of the raw series over days is too expensive.
+		base := sloErrorRatioRecord(sloRateWindows[0]) + selector
+		group.Rules = append(group.Rules,
+			promv1.Rule{
+				Record: sloErrorRatioRecord(window),
+				Expr:   intstr.FromString(fmt.Sprintf("sum_over_time(%s[%s])\n/\ncount_over_time(%s[%s])", base, window, base, window)),
+				Labels: labels,
+			},
+			promv1.Rule{
+				Record: "slo:error_budget_remaining:ratio",
+				Expr:   intstr.FromString(fmt.Sprintf("1 - (%s%s / %s)", sloErrorRatioRecord(window), selector, formatRat(budget))),
+				Labels: labels,
+			},
+		)
+
+		for _, w := range sloBurnRateWindows {
+			// The burn rate consuming the budget share over the long window,
+			// e.g. 14.4 for 2% of a 30 days budget in 1 hour.
+			share, _ := new(big.Rat).SetString(w.budgetShare)
+			burnRate := new(big.Rat).Mul(share, big.NewRat(days*24, w.longHours))
+			threshold := fmt.Sprintf("(%s * %s)", formatRat(burnRate), formatRat(budget))
+			forDuration := promv1.Duration(w.forDuration)
+
+			group.Rules = append(group.Rules, promv1.Rule{
+				Alert: sloErrorBudgetBurnAlert,
+				Expr: intstr.FromString(fmt.Sprintf("%s%s > %s\nand\n%s%s > %s",
+					sloErrorRatioRecord(w.long), selector, threshold,
+					sloErrorRatioRecord(w.short), selector, threshold)),
+				For: &forDuration,
+				Labels: map[string]string{
+					"slo":               slo.Name,
+					alertComponentLabel: slo.Component,
+					"severity":          w.severity,
+					"window":            w.long,
+				},
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("SLO %s error budget burn (%s/%s window)", slo.Name, w.long, w.short),
+					"message": fmt.Sprintf("The %s SLO of %s is consuming its %s error budget %s times faster than allowed.",
+						slo.Name, slo.Component, window, formatRat(burnRate)),
+				},
+			})
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// addSLOData adds the SLO rule groups to the template data map.
+func addSLOData(monitoring *serviceApi.Monitoring, templateData map[string]any) error {
+	templateData["SLORuleGroups"] = []promv1.RuleGroup{}
+
+	if monitoring.Spec.Alerting == nil || len(monitoring.Spec.Alerting.SLOs) == 0 {
+		return nil
+	}
+
+	groups, err := sloRuleGroups(monitoring.Spec.Alerting.SLOs)
+	if err != nil {
+		return err
+	}
+
+	templateData["SLORuleGroups"] = groups
+
+	return nil
+}
+
+// updateSLOStatus evaluates the compliance of the SLOs over their window.
+func updateSLOStatus(ctx context.Context, slos []serviceApi.ServiceLevelObjective, query prometheusQueryFn) []serviceApi.SLOStatus {
+	statuses := make([]serviceApi.SLOStatus, 0, len(slos))
+
+	for _, slo := range slos {
+		st := serviceApi.SLOStatus{
+			Name:      slo.Name,
+			Component: slo.Component,
+			Compliant: metav1.ConditionUnknown,
+		}
+
+		budget, err := sloErrorBudget(slo)
+		if err != nil {
+			st.Message = err.Error()
+			statuses = append(statuses, st)
+			continue
+		}
+
+		window := sloWindow(slo)
+
+		errorRatio, found, err := query(ctx, fmt.Sprintf("%s{slo=%q}", sloErrorRatioRecord(window), slo.Name))
+		switch {
+		case err != nil:
+			st.Message = fmt.Sprintf("Failed to evaluate the SLO: %v", err)
+		case !found:
+			st.Message = "No data recorded for the SLO yet"
+		default:
+			budgetRatio, _ := budget.Float64()
+
+			st.Compliance = strconv.FormatFloat((1-errorRatio)*100, 'f', 3, 64)
+			st.ErrorBudgetRemaining = strconv.FormatFloat((1-errorRatio/budgetRatio)*100, 'f', 1, 64)
+			st.Compliant = metav1.ConditionTrue
+			if errorRatio > budgetRatio {
+				st.Compliant = metav1.ConditionFalse
+			}
+		}
+
+		statuses = append(statuses, st)
+	}
+
+	return statuses
+}
+
+// reportSLOCompliance reports the compliance of the SLOs in the Monitoring status, as recorded
+// by the Prometheus of the MonitoringStack, and schedules its periodic refresh.
+func reportSLOCompliance(ctx context.Context, rr *odhtypes.ReconciliationRequest) error {
+	monitoring, ok := rr.Instance.(*serviceApi.Monitoring)
+	if !ok {
+		return errors.New("instance is not of type *services.Monitoring")
+	}
+
+	if monitoring.Spec.Alerting == nil || len(monitoring.Spec.Alerting.SLOs) == 0 {
+		monitoring.Status.SLOs = nil
+		return nil
+	}
+
+	query, err := newPrometheusQuery(ctx, rr.Client, monitoring.Spec.Namespace)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Cannot query the MonitoringStack Prometheus", "error", err.Error())
+
+		query = func(context.Context, string) (float64, bool, error) {
+			return 0, false, err
+		}
+	}
+
+	monitoring.Status.SLOs = updateSLOStatus(ctx, monitoring.Spec.Alerting.SLOs, query)
+
+	return odherrors.NewRequeueAfterError(sloComplianceInterval)
+}
@@ -0,0 +1,263 @@
+//nolint:testpackage // Need to test unexported SLO helpers
+package monitoring
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
+
+	. "github.com/onsi/gomega"
+)
+
+func availabilitySLO() serviceApi.ServiceLevelObjective {
+	return serviceApi.ServiceLevelObjective{
+		Name:      "dashboard-availability",
+		Component: "dashboard",
+		Objective: "99.9",
+		Indicator: serviceApi.SLOIndicator{
+			Ratio: &serviceApi.SLORatioIndicator{
+				Errors: `http_requests_total{job="dashboard",code=~"5.."}`,
+				Total:  `http_requests_total{job="dashboard"}`,
+			},
+		},
+	}
+}
+
+func latencySLO() serviceApi.ServiceLevelObjective {
+	return serviceApi.ServiceLevelObjective{
+		Name:      "kserve-latency",
+		Component: "kserve",
+		Objective: "95",
+		Window:    "7d",
+		Indicator: serviceApi.SLOIndicator{
+			Latency: &serviceApi.SLOLatencyIndicator{
+				Histogram: "request_duration_seconds",
+				Selector:  `namespace="models"`,
+				Threshold: "0.5",
+			},
+		},
+	}
+}
+
+func TestValidateSLOs(t *testing.T) {
+	components := []string{operatorAlertComponent, "dashboard", "kserve"}
+
+	tests := []struct {
+		name   string
+		mutate func(slo *serviceApi.ServiceLevelObjective)
+		err    string
+	}{
+		{
+			name:   "valid ratio SLO",
+			mutate: func(*serviceApi.ServiceLevelObjective) {},
+		},
+		{
+			name:   "unknown component",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) { slo.Component = "unknown" },
+			err:    "references unknown component 'unknown'",
+		},
+		{
+			name:   "zero objective",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) { slo.Objective = "0" },
+			err:    "objective must be a percentage between 0 and 100",
+		},
+		{
+			name:   "invalid window",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) { slo.Window = "4w" },
+			err:    "window must be a number of days",
+		},
+		{
+			name: "both indicators",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) {
+				slo.Indicator.Latency = latencySLO().Indicator.Latency
+			},
+			err: "must set exactly one of the ratio or latency indicators",
+		},
+		{
+			name: "range selector",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) {
+				slo.Indicator.Ratio.Errors = "http_requests_total[5m]"
+			},
+			err: "must be metric selectors without range",
+		},
+		{
+			name: "latency histogram with selector",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) {
+				*slo = latencySLO()
+				slo.Indicator.Latency.Histogram = `request_duration_seconds{job="kserve"}`
+			},
+			err: "histogram must be a metric name",
+		},
+		{
+			name: "latency selector with braces",
+			mutate: func(slo *serviceApi.ServiceLevelObjective) {
+				*slo = latencySLO()
+				slo.Indicator.Latency.Selector = `{job="kserve"}`
+			},
+			err: "selector must be a list of label matchers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			slo := availabilitySLO()
+			tt.mutate(&slo)
+
+			err := validateSLOs([]serviceApi.ServiceLevelObjective{slo}, components)
+			if tt.err == "" {
+				g.Expect(err).NotTo(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(ContainSubstring(tt.err)))
+			}
+		})
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		g := NewWithT(t)
+
+		err := validateSLOs([]serviceApi.ServiceLevelObjective{availabilitySLO(), availabilitySLO()}, components)
+		g.Expect(err).To(MatchError(ContainSubstring("duplicate SLO name 'dashboard-availability'")))
+	})
+}
+
+func TestSLORuleGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	groups, err := sloRuleGroups([]serviceApi.ServiceLevelObjective{availabilitySLO(), latencySLO()})
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(groups).To(HaveLen(2))
+
+	availability := groups[0]
+	g.Expect(availability.Name).To(Equal("slo-dashboard-availability"))
+	// 7 alerting windows, the compliance window, the remaining budget and 4 burn rate alerts
+	g.Expect(availability.Rules).To(HaveLen(13))
+
+	g.Expect(availability.Rules[0].Record).To(Equal("slo:sli_error:ratio_rate5m"))
+	g.Expect(availability.Rules[0].Expr.String()).To(Equal(
+		"(sum(rate(http_requests_total{job=\"dashboard\",code=~\"5..\"}[5m])))\n/\n(sum(rate(http_requests_total{job=\"dashboard\"}[5m])))"))
+	g.Expect(availability.Rules[0].Labels).To(Equal(map[string]string{
+		"slo":               "dashboard-availability",
+		alertComponentLabel: "dashboard",
+	}))
+
+	g.Expect(availability.Rules[7].Record).To(Equal("slo:sli_error:ratio_rate30d"))
+	g.Expect(availability.Rules[7].Expr.String()).To(Equal(
+		"sum_over_time(slo:sli_error:ratio_rate5m{slo=\"dashboard-availability\"}[30d])\n/\n" +
+			"count_over_time(slo:sli_error:ratio_rate5m{slo=\"dashboard-availability\"}[30d])"))
+	g.Expect(availability.Rules[8].Expr.String()).To(Equal(
+		"1 - (slo:sli_error:ratio_rate30d{slo=\"dashboard-availability\"} / 0.001)"))
+
+	// The burn rates of the Google SRE workbook for a 30 days window.
+	alerts := availability.Rules[9:]
+	for i, expected := range []struct{ factor, severity, window string }{
+		{"14.4", serviceApi.AlertSeverityCritical, "1h"},
+		{"6", serviceApi.AlertSeverityCritical, "6h"},
+		{"3", serviceApi.AlertSeverityWarning, "1d"},
+		{"1", serviceApi.AlertSeverityWarning, "3d"},
+	} {
+		g.Expect(alerts[i].Alert).To(Equal(sloErrorBudgetBurnAlert))
+		g.Expect(alerts[i].Expr.String()).To(ContainSubstring(
+			"slo:sli_error:ratio_rate" + expected.window + "{slo=\"dashboard-availability\"} > (" + expected.factor + " * 0.001)"))
+		g.Expect(alerts[i].Labels).To(HaveKeyWithValue("severity", expected.severity))
+		g.Expect(alerts[i].Labels).To(HaveKeyWithValue(alertComponentLabel, "dashboard"))
+	}
+
+	latency := groups[1]
+	g.Expect(latency.Rules[0].Expr.String()).To(Equal("1 - (\n" +
+		"  (sum(rate(request_duration_seconds_bucket{namespace=\"models\",le=\"0.5\"}[5m])))\n" +
+		"  /\n" +
+		"  (sum(rate(request_duration_seconds_count{namespace=\"models\"}[5m])))\n)"))
+	g.Expect(latency.Rules[7].Record).To(Equal("slo:sli_error:ratio_rate7d"))
+	g.Expect(latency.Rules[8].Expr.String()).To(ContainSubstring("/ 0.05)"))
+	// 2% of a 7 days budget in 1 hour
+	g.Expect(latency.Rules[9].Expr.String()).To(ContainSubstring("> (3.36 * 0.05)"))
+}
+
+func TestSLOTemplateRendering(t *testing.T) {
+	g := NewWithT(t)
+
+	monitoring := &serviceApi.Monitoring{
+		Spec: serviceApi.MonitoringSpec{
+			MonitoringCommonSpec: serviceApi.MonitoringCommonSpec{
+				Alerting: &serviceApi.Alerting{SLOs: []serviceApi.ServiceLevelObjective{availabilitySLO()}},
+			},
+		},
+	}
+
+	templateData := map[string]any{}
+	g.Expect(addSLOData(monitoring, templateData)).To(Succeed())
+
+	out, err := yaml.Marshal(templateData["SLORuleGroups"])
+	g.Expect(err).NotTo(HaveOccurred())
+
+	var groups []map[string]any
+	g.Expect(yaml.Unmarshal(out, &groups)).To(Succeed())
+	g.Expect(groups).To(HaveLen(1))
+	g.Expect(groups[0]).To(HaveKeyWithValue("name", "slo-dashboard-availability"))
+	g.Expect(groups[0]["rules"]).To(ContainElement(HaveKeyWithValue("for", "2m")))
+
+	t.Run("no SLOs", func(t *testing.T) {
+		g := NewWithT(t)
+
+		templateData := map[string]any{}
+		g.Expect(addSLOData(&serviceApi.Monitoring{}, templateData)).To(Succeed())
+		g.Expect(templateData).To(HaveKeyWithValue("SLORuleGroups", BeEmpty()))
+	})
+}
+
+func TestUpdateSLOStatus(t *testing.T) {
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	slos := []serviceApi.ServiceLevelObjective{availabilitySLO(), latencySLO()}
+
+	values := map[string]float64{
+		`slo:sli_error:ratio_rate30d{slo="dashboard-availability"}`: 0.0004,
+		`slo:sli_error:ratio_rate7d{slo="kserve-latency"}`:          0.08,
+	}
+	query := func(_ context.Context, q string) (float64, bool, error) {
+		v, ok := values[q]
+		return v, ok, nil
+	}
+
+	statuses := updateSLOStatus(ctx, slos, query)
+	g.Expect(statuses).To(HaveLen(2))
+
+	g.Expect(statuses[0].Name).To(Equal("dashboard-availability"))
+	g.Expect(statuses[0].Component).To(Equal("dashboard"))
+	g.Expect(statuses[0].Compliance).To(Equal("99.960"))
+	g.Expect(statuses[0].ErrorBudgetRemaining).To(Equal("60.0"))
+	g.Expect(statuses[0].Compliant).To(Equal(metav1.ConditionTrue))
+
+	g.Expect(statuses[1].Compliance).To(Equal("92.000"))
+	g.Expect(statuses[1].ErrorBudgetRemaining).To(Equal("-60.0"))
+	g.Expect(statuses[1].Compliant).To(Equal(metav1.ConditionFalse))
+
+	t.Run("no data", func(t *testing.T) {
+		g := NewWithT(t)
+
+		statuses := updateSLOStatus(ctx, slos[:1], func(context.Context, string) (float64, bool, error) {
+			return 0, false, nil
+		})
+		g.Expect(statuses[0].Compliant).To(Equal(metav1.ConditionUnknown))
+		g.Expect(statuses[0].Message).To(Equal("No data recorded for the SLO yet"))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		g := NewWithT(t)
+
+		statuses := updateSLOStatus(ctx, slos[:1], func(context.Context, string) (float64, bool, error) {
+			return 0, false, errors.New("connection refused")
+		})
+		g.Expect(statuses[0].Compliant).To(Equal(metav1.ConditionUnknown))
+		g.Expect(statuses[0].Compliance).To(BeEmpty())
+		g.Expect(statuses[0].Message).To(ContainSubstring("connection refused"))
+	})
+}
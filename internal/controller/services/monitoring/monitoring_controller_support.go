@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
+	promv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
 	"gopkg.in/yaml.v3"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -389,10 +390,12 @@ func getTemplateData(ctx context.Context, rr *odhtypes.ReconciliationRequest) (m
 		"Traces":               monitoring.Spec.Traces != nil,
 		"Metrics":              monitoring.Spec.Metrics != nil,
 		"AcceleratorMetrics":   monitoring.Spec.Metrics != nil,
+		"Logs":                 monitoring.Spec.Logs != nil,
 		"ApplicationNamespace": appNamespace,
 		"OperatorNamespace":    operatorNamespace,
 		"MetricsExporters":     make(map[string]string),
 		"MetricsExporterNames": []string{},
+		"RemoteWrite":          []promv1.RemoteWriteSpec{},
 		"PersesImage":          getPersesImage(),
 		"PersesAPIVersion":     persesAPIVersion,
 	}
@@ -416,6 +419,17 @@ func getTemplateData(ctx context.Context, rr *odhtypes.ReconciliationRequest) (m
 		}
 	}
 
+	// Add logs-related data if logs are configured
+	if logs := monitoring.Spec.Logs; logs != nil {
+		if err := addLogsData(logs, rr.Release.Name, appNamespace, operatorNamespace, templateData); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := addSLOData(monitoring, templateData); err != nil {
+		return nil, err
+	}
+
 	templateData["CollectorReplicas"] = monitoring.Spec.CollectorReplicas
 
 	return templateData, nil
@@ -451,8 +465,8 @@ func checkMonitoringPreconditions(ctx context.Context, rr *odhtypes.Reconciliati
 	}
 	var allErrors *multierror.Error
 
-	// Check for opentelemetry-product operator if either metrics or traces are enabled
-	if monitoring.Spec.Metrics != nil || monitoring.Spec.Traces != nil {
+	// Check for opentelemetry-product operator if metrics, traces or logs are enabled
+	if monitoring.Spec.Metrics != nil || monitoring.Spec.Traces != nil || monitoring.Spec.Logs != nil {
 		if openTelemetryInfo, err := cluster.OperatorExists(ctx, rr.Client, opentelemetryOperator); err != nil || openTelemetryInfo == nil {
 			if err != nil {
 				return odherrors.NewStopErrorW(err)
@@ -531,6 +545,7 @@ func cleanupPrometheusRules(ctx context.Context, componentName string, rr *odhty
 func addMetricsData(ctx context.Context, rr *odhtypes.ReconciliationRequest, metrics *serviceApi.Metrics, templateData map[string]any) error {
 	addStorageData(metrics, templateData)
 	addReplicasData(ctx, rr, metrics, templateData)
+	addRemoteWriteData(metrics, templateData)
 	return addExportersData(metrics, templateData)
 }
 
@@ -3,8 +3,10 @@ package setup
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -12,8 +14,11 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/modules"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
 )
@@ -26,15 +31,51 @@ func (r *SetupControllerReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	log := logf.FromContext(ctx).WithName("SetupController")
 	log.Info("Reconciling setup controller")
 
-	if !upgrade.HasDeleteConfigMap(ctx, r.Client) {
+	deleteCM, err := upgrade.GetDeleteConfigMap(ctx, r.Client)
+	if err != nil || deleteCM == nil {
 		return ctrl.Result{}, nil
 	}
 
-	if err := upgrade.OperatorUninstall(ctx, r.Client, cluster.GetRelease().Name); err != nil {
+	operatorNs, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	opts, err := upgrade.UninstallOptionsFromConfigMap(deleteCM)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("operator uninstall failed : %w", err)
+	}
+
+	opts = append(opts, upgrade.WithNodeGVK(r.nodeGVK))
+
+	res, err := upgrade.NewUninstaller(r.Client, operatorNs, cluster.GetRelease().Name, opts...).Reconcile(ctx)
+	if err != nil {
 		return ctrl.Result{}, fmt.Errorf("operator uninstall failed : %w", err)
 	}
 
-	return ctrl.Result{}, nil
+	return res, nil
+}
+
+// nodeGVK returns the kind of the CR backing a component or module of the
+// provisioning DAG. Component CR kinds are found in the scheme, as the
+// lowercase kind is the component name.
+func (r *SetupControllerReconciler) nodeGVK(node provision.UnifiedNode) (schema.GroupVersionKind, bool) {
+	switch node.GetKind() {
+	case provision.KindModule:
+		handler := modules.DefaultRegistry().Lookup(node.GetName())
+		if handler == nil {
+			return schema.GroupVersionKind{}, false
+		}
+		return handler.GetGVK(), true
+	case provision.KindComponent:
+		for kind := range r.Scheme().KnownTypes(componentApi.GroupVersion) {
+			if strings.ToLower(kind) == node.GetName() {
+				return componentApi.GroupVersion.WithKind(kind), true
+			}
+		}
+	}
+
+	return schema.GroupVersionKind{}, false
 }
 
 func (r *SetupControllerReconciler) SetupWithManager(mgr ctrl.Manager) error {
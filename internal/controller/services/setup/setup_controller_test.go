@@ -10,7 +10,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/dag"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
 
 	. "github.com/onsi/gomega"
 )
@@ -119,3 +124,28 @@ func TestFilterDeleteConfigMapPredicateWithTypedAndUnstructured(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeGVK(t *testing.T) {
+	g := NewWithT(t)
+
+	cli, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	r := &SetupControllerReconciler{Client: cli}
+
+	kind, ok := r.nodeGVK(provision.UnifiedNode{})
+	g.Expect(ok).Should(BeFalse())
+	g.Expect(kind.Empty()).Should(BeTrue())
+
+	provision.DefaultRegistry().Reset()
+	t.Cleanup(provision.DefaultRegistry().Reset)
+	provision.Add(componentApi.DataSciencePipelinesComponentName, provision.KindComponent, dag.RL(10))
+
+	batches, err := provision.ReverseBatchesAll()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(batches).Should(HaveLen(1))
+
+	kind, ok = r.nodeGVK(batches[0][0])
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(kind).Should(Equal(gvk.DataSciencePipelines))
+}
@@ -93,19 +93,30 @@ const (
 	ConditionInstrumentationAvailable            = "InstrumentationAvailable"
 	ConditionAlertingAvailable                   = "AlertingAvailable"
 	ConditionThanosQuerierAvailable              = "ThanosQuerierAvailable"
+	ConditionRemoteWriteAvailable                = "RemoteWriteAvailable"
+	ConditionLogsAvailable                       = "LogsAvailable"
 	ConditionPersesAvailable                     = "PersesAvailable"
 	ConditionPersesTempoDataSourceAvailable      = "PersesTempoDataSourceAvailable"
 	ConditionPersesPrometheusDataSourceAvailable = "PersesPrometheusDataSourceAvailable"
 	ConditionNodeMetricsEndpointAvailable        = "NodeMetricsEndpointAvailable"
 	ConditionImageStreamsAvailable               = "ImageStreamsAvailable"
 	ConditionImageStreamsNotAvailableReason      = "ImageStreamsNotReady"
+	ConditionPatchesValid                        = "PatchesValid"
+	ConditionResourceConflict                    = "ResourceConflict"
+	ConditionUpgradeGateCleared                  = "Cleared"
+	ConditionMigrationsSucceeded                 = "MigrationsSucceeded"
+	ConditionPendingMaintenance                  = "PendingMaintenance"
 
 	// Cloud controller manager conditions.
-	ConditionDependenciesReady = "DependenciesReady"
-	ConditionGatewayAPIReady   = "GatewayAPIReady"
-	ConditionCertManagerReady  = "CertManagerReady"
-	ConditionLWSReady          = "LWSReady"
-	ConditionSailOperatorReady = "SailOperatorReady"
+	ConditionDependenciesReady       = "DependenciesReady"
+	ConditionGatewayAPIReady         = "GatewayAPIReady"
+	ConditionCertManagerReady        = "CertManagerReady"
+	ConditionLWSReady                = "LWSReady"
+	ConditionSailOperatorReady       = "SailOperatorReady"
+	ConditionKueueReady              = "KueueReady"
+	ConditionPrometheusOperatorReady = "PrometheusOperatorReady"
+	ConditionKEDAReady               = "KEDAReady"
+	ConditionImagesMirrored          = "ImagesMirrored"
 )
 
 const (
@@ -131,6 +142,28 @@ const (
 	DAGResolutionFailedReason     = "DAGResolutionFailed"
 	RunlevelTimeoutExceededReason = "RunlevelTimeoutExceeded"
 	AdminAckRequiredReason        = "AdminAckRequired"
+
+	// Component patches reasons.
+	PatchesValidReason   = "PatchesValid"
+	InvalidPatchesReason = "InvalidPatches"
+
+	// Server-side apply conflicts reasons.
+	FieldManagerConflictReason = "FieldManagerConflict"
+
+	// Upgrade gates reasons.
+	PreflightPassedReason    = "PreflightPassed"
+	PreflightFailedReason    = "PreflightFailed"
+	AcknowledgedReason       = "Acknowledged"
+	InvalidUpgradeGateReason = "InvalidUpgradeGate"
+
+	// Migrations reasons.
+	MigrationsSucceededReason = "MigrationsSucceeded"
+	MigrationFailedReason     = "MigrationFailed"
+	NoMigrationsReason        = "NoMigrations"
+
+	// Maintenance windows reasons.
+	OutsideMaintenanceWindowReason = "OutsideMaintenanceWindow"
+	InvalidMaintenancePolicyReason = "InvalidMaintenancePolicy"
 )
 
 const (
@@ -170,6 +203,17 @@ const (
 
 	AlertingNotConfiguredReason  = "AlertingNotConfigured"
 	AlertingNotConfiguredMessage = "Alerting not configured in DSCI CR"
+	InvalidAlertingConfigReason  = "InvalidAlertingConfig"
+
+	RemoteWriteNotConfiguredReason  = "RemoteWriteNotConfigured"
+	RemoteWriteNotConfiguredMessage = "Remote write not configured in DSCI CR"
+	InvalidRemoteWriteConfigReason  = "InvalidRemoteWriteConfig"
+	RemoteWriteFailedReason         = "RemoteWriteFailed"
+	RemoteWritePendingReason        = "RemoteWritePending"
+
+	LogsNotConfiguredReason  = "LogsNotConfigured"
+	LogsNotConfiguredMessage = "Logs not configured in DSCI CR"
+	InvalidLogsConfigReason  = "InvalidLogsConfig"
 
 	TempoOperatorMissingMessage                  = "Tempo operator must be installed for traces configuration"
 	COOMissingMessage                            = "ClusterObservability operator must be installed for metrics configuration"
@@ -0,0 +1,20 @@
+//go:build !nowebhook
+
+package upgradegate
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// RegisterWebhooks registers the webhooks for UpgradeGate.
+func RegisterWebhooks(mgr ctrl.Manager) error {
+	if err := (&Validator{
+		Name:    "upgradegate-validating",
+		Decoder: admission.NewDecoder(mgr.GetScheme()),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+
+	return nil
+}
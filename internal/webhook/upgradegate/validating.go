@@ -0,0 +1,123 @@
+//go:build !nowebhook
+
+package upgradegate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/blang/semver/v4"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configApi "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+	webhookutils "github.com/opendatahub-io/opendatahub-operator/v2/pkg/webhook"
+)
+
+// ClockSkew is the tolerance applied when checking that an acknowledgment
+// timestamp is not in the future.
+const ClockSkew = 5 * time.Minute
+
+//+kubebuilder:webhook:path=/validate-upgradegate,mutating=false,failurePolicy=fail,sideEffects=None,groups=config.opendatahub.io,resources=upgradegates,verbs=create;update,versions=v1alpha1,name=upgradegate-validator.opendatahub.io,admissionReviewVersions=v1
+//nolint:lll
+
+// Validator implements webhook.AdmissionHandler for UpgradeGate validation webhooks.
+// It verifies the versions range and the preflight check of a gate, and that
+// acknowledgments are recorded by the user performing the request.
+type Validator struct {
+	Name    string
+	Decoder admission.Decoder
+}
+
+// Assert that Validator implements admission.Handler interface.
+var _ admission.Handler = &Validator{}
+
+// SetupWithManager registers the validating webhook with the provided controller-runtime manager.
+func (v *Validator) SetupWithManager(mgr ctrl.Manager) error {
+	hookServer := mgr.GetWebhookServer()
+	hookServer.Register("/validate-upgradegate", &webhook.Admission{
+		Handler:        v,
+		LogConstructor: webhookutils.NewWebhookLogConstructor(v.Name),
+	})
+	return nil
+}
+
+// Handle processes admission requests for create and update operations on UpgradeGate resources.
+func (v *Validator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	log := logf.FromContext(ctx)
+
+	allowMessage := fmt.Sprintf("Operation %s on %s allowed", req.Operation, req.Kind.Kind)
+
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed(allowMessage)
+	}
+
+	gate := &configApi.UpgradeGate{}
+	if err := v.Decoder.DecodeRaw(req.Object, gate); err != nil {
+		log.Error(err, "failed to decode UpgradeGate")
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	var old *configApi.UpgradeGate
+	if req.Operation == admissionv1.Update {
+		old = &configApi.UpgradeGate{}
+		if err := v.Decoder.DecodeRaw(req.OldObject, old); err != nil {
+			log.Error(err, "failed to decode old UpgradeGate")
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+	}
+
+	if _, err := semver.ParseRange(gate.Spec.Versions); err != nil {
+		return admission.Denied(fmt.Sprintf("invalid versions range %q: %v", gate.Spec.Versions, err))
+	}
+
+	if err := gates.ValidatePreflight(gate.Spec.Preflight); err != nil {
+		return admission.Denied(fmt.Sprintf("invalid preflight: %v", err))
+	}
+
+	if err := validateAcknowledgment(&req, gate, old); err != nil {
+		return admission.Denied(err.Error())
+	}
+
+	return admission.Allowed(allowMessage)
+}
+
+// validateAcknowledgment verifies an acknowledgment added or changed by the
+// request: only gates without a preflight check can be acknowledged, and the
+// acknowledgment must be recorded by the requesting user at the current time.
+func validateAcknowledgment(req *admission.Request, gate *configApi.UpgradeGate, old *configApi.UpgradeGate) error {
+	ack := gate.Spec.Acknowledgment
+	if ack == nil {
+		return nil
+	}
+
+	if old != nil && equality.Semantic.DeepEqual(ack, old.Spec.Acknowledgment) {
+		return nil
+	}
+
+	if gate.Spec.Preflight != nil {
+		return fmt.Errorf("gate %s has a preflight check and is cleared automatically, it cannot be acknowledged", gate.Name)
+	}
+
+	if ack.AcknowledgedBy != req.UserInfo.Username {
+		return fmt.Errorf("acknowledgedBy must be the requesting user %q, got %q", req.UserInfo.Username, ack.AcknowledgedBy)
+	}
+
+	if ack.AcknowledgedAt.IsZero() {
+		return errors.New("acknowledgedAt must be set")
+	}
+
+	if ack.AcknowledgedAt.After(time.Now().Add(ClockSkew)) {
+		return fmt.Errorf("acknowledgedAt %s is in the future", ack.AcknowledgedAt.UTC().Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package upgradegate_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	configApi "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook/envtestutil"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook/upgradegate"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+func newGate(opts ...func(*configApi.UpgradeGate)) *configApi.UpgradeGate {
+	g := &configApi.UpgradeGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-migration"},
+		Spec: configApi.UpgradeGateSpec{
+			Versions: ">=3.0.0 <3.1.0",
+			Message:  "Back up data before proceeding",
+		},
+	}
+	for _, o := range opts {
+		o(g)
+	}
+	return g
+}
+
+func withAck(user string, at time.Time) func(*configApi.UpgradeGate) {
+	return func(g *configApi.UpgradeGate) {
+		g.Spec.Acknowledgment = &configApi.UpgradeGateAcknowledgment{
+			AcknowledgedBy: user,
+			AcknowledgedAt: metav1.NewTime(at),
+		}
+	}
+}
+
+func withCEL(expression string) func(*configApi.UpgradeGate) {
+	return func(g *configApi.UpgradeGate) {
+		g.Spec.Preflight = &configApi.UpgradeGatePreflight{
+			CEL: &configApi.UpgradeGateCELCheck{
+				Resource:   configApi.UpgradeGateResourceReference{APIVersion: "v1", Kind: "ConfigMap", Name: "settings"},
+				Expression: expression,
+			},
+		}
+	}
+}
+
+func TestUpgradeGate_ValidatingWebhook(t *testing.T) {
+	t.Parallel()
+
+	sch, err := scheme.New()
+	NewWithT(t).Expect(err).ShouldNot(HaveOccurred())
+
+	validator := &upgradegate.Validator{
+		Name:    "test",
+		Decoder: admission.NewDecoder(sch),
+	}
+
+	gvr := metav1.GroupVersionResource{
+		Group:    gvk.UpgradeGate.Group,
+		Version:  gvk.UpgradeGate.Version,
+		Resource: "upgradegates",
+	}
+
+	now := time.Now()
+
+	request := func(op admissionv1.Operation, gate *configApi.UpgradeGate, old *configApi.UpgradeGate) admission.Request {
+		req := envtestutil.NewAdmissionRequest(t, op, gate, gvk.UpgradeGate, gvr)
+		req.UserInfo = authenticationv1.UserInfo{Username: "admin"}
+		if old != nil {
+			raw, err := json.Marshal(old)
+			NewWithT(t).Expect(err).ShouldNot(HaveOccurred())
+			req.OldObject = runtime.RawExtension{Raw: raw}
+		}
+		return req
+	}
+
+	cases := []struct {
+		name    string
+		req     admission.Request
+		allowed bool
+	}{
+		{
+			name:    "allows a manual gate",
+			req:     request(admissionv1.Create, newGate(), nil),
+			allowed: true,
+		},
+		{
+			name:    "allows a gate with a valid CEL preflight",
+			req:     request(admissionv1.Create, newGate(withCEL(`object == null || object.data.migrated == "true"`)), nil),
+			allowed: true,
+		},
+		{
+			name:    "denies an invalid versions range",
+			req:     request(admissionv1.Create, newGate(func(g *configApi.UpgradeGate) { g.Spec.Versions = "3.x.y" }), nil),
+			allowed: false,
+		},
+		{
+			name:    "denies an invalid CEL preflight",
+			req:     request(admissionv1.Create, newGate(withCEL(`object.data.`)), nil),
+			allowed: false,
+		},
+		{
+			name: "denies an unknown preflight check",
+			req: request(admissionv1.Create, newGate(func(g *configApi.UpgradeGate) {
+				g.Spec.Preflight = &configApi.UpgradeGatePreflight{Check: "unknown"}
+			}), nil),
+			allowed: false,
+		},
+		{
+			name:    "allows an acknowledgment by the requesting user",
+			req:     request(admissionv1.Update, newGate(withAck("admin", now)), newGate()),
+			allowed: true,
+		},
+		{
+			name:    "denies an acknowledgment on behalf of another user",
+			req:     request(admissionv1.Update, newGate(withAck("someone-else", now)), newGate()),
+			allowed: false,
+		},
+		{
+			name:    "denies an acknowledgment in the future",
+			req:     request(admissionv1.Update, newGate(withAck("admin", now.Add(time.Hour))), newGate()),
+			allowed: false,
+		},
+		{
+			name:    "denies an acknowledgment of a gate with a preflight check",
+			req:     request(admissionv1.Update, newGate(withCEL("true"), withAck("admin", now)), newGate(withCEL("true"))),
+			allowed: false,
+		},
+		{
+			name:    "allows updates preserving an existing acknowledgment",
+			req:     request(admissionv1.Update, newGate(withAck("someone-else", now)), newGate(withAck("someone-else", now))),
+			allowed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			g := NewWithT(t)
+
+			resp := validator.Handle(t.Context(), tc.req)
+			g.Expect(resp.Allowed).To(Equal(tc.allowed), resp.Result.Message)
+		})
+	}
+}
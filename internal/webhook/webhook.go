@@ -19,6 +19,7 @@ import (
 	hardwareprofilewebhook "github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook/hardwareprofile"
 	monitoringwebhook "github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook/monitoring"
 	notebookwebhook "github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook/notebook"
+	upgradegatewebhook "github.com/opendatahub-io/opendatahub-operator/v2/internal/webhook/upgradegate"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/flags"
 )
 
@@ -48,6 +49,7 @@ func RegisterAllWebhooks(mgr ctrl.Manager) error {
 		}},
 		{name: "notebook", register: notebookwebhook.RegisterWebhooks, disabled: func() bool { return mr.IsEnabled(componentApi.WorkbenchesComponentName) }},
 		{name: "dashboard", register: dashboard.RegisterWebhooks, disabled: func() bool { return !cr.IsEnabled(componentApi.DashboardComponentName) }},
+		{name: "upgradegate", register: upgradegatewebhook.RegisterWebhooks},
 	}
 
 	for _, e := range entries {
@@ -0,0 +1,334 @@
+// Package backup exports the platform configuration to a versioned archive
+// and restores it into a cluster.
+//
+// The archive is a gzipped tarball holding a manifest.json file, which lists
+// every object together with its API version, and one YAML document per
+// object. Only the desired state is kept: status and the server-populated
+// metadata are dropped on export.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+)
+
+const (
+	// FormatVersion is the version of the archive layout written by Backup.
+	// Restore rejects archives with a newer format.
+	FormatVersion = 1
+
+	// ManifestFile is the name of the archive entry holding the Manifest.
+	ManifestFile = "manifest.json"
+
+	objectsDir = "objects"
+)
+
+// Manifest describes the content of a backup archive.
+type Manifest struct {
+	FormatVersion   int         `json:"formatVersion"`
+	OperatorVersion string      `json:"operatorVersion,omitempty"`
+	CreatedAt       metav1.Time `json:"createdAt"`
+	// APIVersions lists, sorted, the API versions of the archived objects.
+	APIVersions []string `json:"apiVersions"`
+	// Objects lists the archived objects in the order they are restored.
+	Objects []ManifestEntry `json:"objects"`
+}
+
+// ManifestEntry locates a single object in the archive.
+type ManifestEntry struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Path       string `json:"path"`
+}
+
+// GroupVersionKind returns the GroupVersionKind of the entry.
+func (e ManifestEntry) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(e.APIVersion, e.Kind)
+}
+
+// String returns the entry as "Kind namespace/name", or "Kind name" for
+// cluster scoped objects.
+func (e ManifestEntry) String() string {
+	if e.Namespace == "" {
+		return e.Kind + " " + e.Name
+	}
+
+	return e.Kind + " " + e.Namespace + "/" + e.Name
+}
+
+// BackupOptions configures Backup.
+type BackupOptions struct {
+	// OperatorNamespace is the namespace of the user-editable ConfigMaps read
+	// by the operator. ConfigMaps are skipped if empty.
+	OperatorNamespace string
+	// OperatorVersion is recorded in the manifest.
+	OperatorVersion string
+}
+
+// Kinds returns, in restore order, the kinds of the platform objects included
+// in a backup. Component kinds are read from the given scheme.
+func Kinds(s *runtime.Scheme) []schema.GroupVersionKind {
+	kinds := []schema.GroupVersionKind{
+		gvk.DSCInitialization,
+		gvk.DataScienceCluster,
+		gvk.Platform,
+		gvk.GatewayConfig,
+		gvk.Auth,
+		gvk.Monitoring,
+		gvk.HardwareProfile,
+		gvk.UpgradeGate,
+	}
+
+	components := make([]string, 0)
+	for kind := range s.KnownTypes(componentApi.GroupVersion) {
+		if strings.HasSuffix(kind, "List") || !s.Recognizes(componentApi.GroupVersion.WithKind(kind+"List")) {
+			continue
+		}
+		components = append(components, kind)
+	}
+
+	slices.Sort(components)
+
+	for _, kind := range components {
+		kinds = append(kinds, componentApi.GroupVersion.WithKind(kind))
+	}
+
+	return kinds
+}
+
+// Backup writes the platform configuration to w. Kinds whose CRD is not
+// installed are skipped.
+func Backup(ctx context.Context, cli client.Client, w io.Writer, opts BackupOptions) (*Manifest, error) {
+	objects, err := collect(ctx, cli, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := Manifest{
+		FormatVersion:   FormatVersion,
+		OperatorVersion: opts.OperatorVersion,
+		CreatedAt:       metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+		APIVersions:     make([]string, 0),
+		Objects:         make([]ManifestEntry, 0, len(objects)),
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	for _, obj := range objects {
+		entry := ManifestEntry{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Namespace:  obj.GetNamespace(),
+			Name:       obj.GetName(),
+			Path:       objectPath(obj),
+		}
+
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal %s %s: %w", entry.Kind, entry.Name, err)
+		}
+
+		if err := writeFile(tw, entry.Path, data, manifest.CreatedAt.Time); err != nil {
+			return nil, err
+		}
+
+		manifest.Objects = append(manifest.Objects, entry)
+
+		if !slices.Contains(manifest.APIVersions, entry.APIVersion) {
+			manifest.APIVersions = append(manifest.APIVersions, entry.APIVersion)
+		}
+	}
+
+	slices.Sort(manifest.APIVersions)
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal backup manifest: %w", err)
+	}
+
+	if err := writeFile(tw, ManifestFile, data, manifest.CreatedAt.Time); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup archive: %w", err)
+	}
+
+	return &manifest, nil
+}
+
+func collect(ctx context.Context, cli client.Client, opts BackupOptions) ([]*unstructured.Unstructured, error) {
+	result := make([]*unstructured.Unstructured, 0)
+
+	// the ConfigMaps go first so that upgrade acknowledgements are in place
+	// before the DSC is reconciled.
+	cms, err := configMaps(ctx, cli, opts.OperatorNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	result = append(result, cms...)
+
+	for _, kind := range Kinds(cli.Scheme()) {
+		items := unstructured.UnstructuredList{}
+		items.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+
+		err := cli.List(ctx, &items)
+		switch {
+		case meta.IsNoMatchError(err):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to list %s: %w", kind.Kind, err)
+		}
+
+		for i := range items.Items {
+			obj := &items.Items[i]
+			obj.SetGroupVersionKind(kind)
+			sanitize(obj)
+			result = append(result, obj)
+		}
+	}
+
+	return result, nil
+}
+
+// configMaps returns the user-editable ConfigMaps read by the operator: the
+// upgrade acknowledgements and the custom install profile selected by the
+// DSC or the DSCI, if any.
+func configMaps(ctx context.Context, cli client.Client, namespace string) ([]*unstructured.Unstructured, error) {
+	if namespace == "" {
+		return nil, nil
+	}
+
+	names := []string{gates.AcksConfigMap}
+
+	for _, kind := range []schema.GroupVersionKind{gvk.DSCInitialization, gvk.DataScienceCluster} {
+		items := unstructured.UnstructuredList{}
+		items.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+
+		err := cli.List(ctx, &items)
+		switch {
+		case meta.IsNoMatchError(err):
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to list %s: %w", kind.Kind, err)
+		}
+
+		for _, item := range items.Items {
+			if p := item.GetAnnotations()[annotations.InstallProfile]; p != "" && !slices.Contains(names, p) {
+				names = append(names, p)
+			}
+		}
+	}
+
+	result := make([]*unstructured.Unstructured, 0, len(names))
+
+	for _, name := range names {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk.ConfigMap)
+
+		err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, obj)
+		switch {
+		case k8serr.IsNotFound(err):
+			// built-in profiles have no ConfigMap
+			continue
+		case err != nil:
+			return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", namespace, name, err)
+		}
+
+		obj.SetGroupVersionKind(gvk.ConfigMap)
+		sanitize(obj)
+		result = append(result, obj)
+	}
+
+	return result, nil
+}
+
+// sanitize drops the status and the metadata populated by the API server or
+// by the controllers, so that the object can be created in another cluster.
+// Owner references are kept without their UID, which is resolved again on
+// restore.
+func sanitize(obj *unstructured.Unstructured) {
+	unstructured.RemoveNestedField(obj.Object, "status")
+
+	for _, f := range []string{
+		"uid",
+		"resourceVersion",
+		"generation",
+		"creationTimestamp",
+		"deletionTimestamp",
+		"deletionGracePeriodSeconds",
+		"managedFields",
+		"finalizers",
+		"selfLink",
+	} {
+		unstructured.RemoveNestedField(obj.Object, "metadata", f)
+	}
+
+	refs, _, _ := unstructured.NestedSlice(obj.Object, "metadata", "ownerReferences")
+	for _, ref := range refs {
+		if m, ok := ref.(map[string]any); ok {
+			delete(m, "uid")
+		}
+	}
+
+	if len(refs) != 0 {
+		_ = unstructured.SetNestedSlice(obj.Object, refs, "metadata", "ownerReferences")
+	}
+}
+
+func objectPath(obj *unstructured.Unstructured) string {
+	group := obj.GroupVersionKind().Group
+	if group == "" {
+		group = "core"
+	}
+
+	return path.Join(objectsDir, group, strings.ToLower(obj.GetKind()), obj.GetNamespace(), obj.GetName()+".yaml")
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		ModTime:  modTime,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write %s to the backup archive: %w", name, err)
+	}
+
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s to the backup archive: %w", name, err)
+	}
+
+	return nil
+}
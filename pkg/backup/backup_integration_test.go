@@ -0,0 +1,61 @@
+//go:build integration
+
+package backup_test
+
+import (
+	"bytes"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/backup"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/envt"
+
+	. "github.com/onsi/gomega"
+)
+
+// TestBackupRestoreIntegration round-trips the platform configuration
+// through a real API server, which validates the restored objects against
+// the CRD schemas.
+func TestBackupRestoreIntegration(t *testing.T) {
+	g := NewWithT(t)
+
+	et, err := envt.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	t.Cleanup(func() {
+		g.Expect(et.Stop()).Should(Succeed())
+	})
+
+	cli := et.Client()
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testOperatorNamespace}}
+	g.Expect(cli.Create(t.Context(), ns)).Should(Succeed())
+
+	for _, obj := range platformObjects() {
+		g.Expect(cli.Create(t.Context(), obj)).Should(Succeed())
+	}
+
+	archive := bytes.Buffer{}
+
+	manifest, err := backup.Backup(t.Context(), cli, &archive, backup.BackupOptions{OperatorNamespace: testOperatorNamespace})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(manifest.Objects).Should(HaveLen(5))
+
+	dsc := &dscv2.DataScienceCluster{}
+	g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: "default-dsc"}, dsc)).Should(Succeed())
+
+	dsc.Finalizers = nil
+	g.Expect(cli.Update(t.Context(), dsc)).Should(Succeed())
+	g.Expect(cli.Delete(t.Context(), dsc)).Should(Succeed())
+
+	result, err := backup.Restore(t.Context(), cli, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(result.Created).Should(HaveExactElements("DataScienceCluster default-dsc"))
+	g.Expect(result.Skipped).Should(HaveLen(4))
+
+	g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: "default-dsc"}, dsc)).Should(Succeed())
+	g.Expect(dsc.Spec.Components.Dashboard.ManagementState).Should(BeEquivalentTo("Managed"))
+}
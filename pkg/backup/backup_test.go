@@ -0,0 +1,317 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v1"
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/backup"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+const testOperatorNamespace = "test-operator-ns"
+
+func platformObjects() []client.Object {
+	dsci := &dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+		Spec:       dsciv2.DSCInitializationSpec{ApplicationsNamespace: "opendatahub"},
+		Status:     dsciv2.DSCInitializationStatus{Phase: "Ready"},
+	}
+
+	dsc := &dscv2.DataScienceCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "default-dsc",
+			Annotations: map[string]string{annotations.InstallProfile: "edge"},
+			Finalizers:  []string{"platform.opendatahub.io/finalizer"},
+		},
+		Spec: dscv2.DataScienceClusterSpec{
+			Components: dscv2.Components{
+				Dashboard: componentApi.DSCDashboard{
+					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
+				},
+			},
+		},
+		Status: dscv2.DataScienceClusterStatus{Status: common.Status{Phase: "Ready"}},
+	}
+
+	dashboard := &componentApi.Dashboard{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: componentApi.DashboardInstanceName,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: gvk.DataScienceCluster.GroupVersion().String(),
+				Kind:       gvk.DataScienceCluster.Kind,
+				Name:       "default-dsc",
+				UID:        "dsc-uid",
+			}},
+		},
+	}
+
+	acks := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: gates.AcksConfigMap, Namespace: testOperatorNamespace},
+		Data:       map[string]string{"ack-3.1.0-breaking": "true"},
+	}
+
+	profile := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: testOperatorNamespace},
+		Data:       map[string]string{"profile.yaml": "dataScienceCluster: {}"},
+	}
+
+	unrelated := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: testOperatorNamespace},
+	}
+
+	return []client.Object{dsci, dsc, dashboard, acks, profile, unrelated}
+}
+
+func TestBackup(t *testing.T) {
+	g := NewWithT(t)
+
+	cli, err := fakeclient.New(fakeclient.WithObjects(platformObjects()...))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	archive := bytes.Buffer{}
+
+	manifest, err := backup.Backup(t.Context(), cli, &archive, backup.BackupOptions{
+		OperatorNamespace: testOperatorNamespace,
+		OperatorVersion:   "3.1.0",
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(manifest.FormatVersion).Should(Equal(backup.FormatVersion))
+	g.Expect(manifest.OperatorVersion).Should(Equal("3.1.0"))
+	g.Expect(manifest.APIVersions).Should(Equal([]string{
+		"components.platform.opendatahub.io/v1alpha1",
+		"datasciencecluster.opendatahub.io/v2",
+		"dscinitialization.opendatahub.io/v2",
+		"v1",
+	}))
+	g.Expect(manifest.Objects).Should(HaveExactElements(
+		And(HaveField("Kind", "ConfigMap"), HaveField("Name", gates.AcksConfigMap)),
+		And(HaveField("Kind", "ConfigMap"), HaveField("Name", "edge")),
+		HaveField("Kind", gvk.DSCInitialization.Kind),
+		HaveField("Kind", gvk.DataScienceCluster.Kind),
+		HaveField("Kind", componentApi.DashboardKind),
+	))
+
+	read, err := backup.ReadManifest(bytes.NewReader(archive.Bytes()))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(read.Objects).Should(Equal(manifest.Objects))
+	g.Expect(read.CreatedAt.Equal(&manifest.CreatedAt)).Should(BeTrue())
+}
+
+func TestRestore(t *testing.T) {
+	g := NewWithT(t)
+
+	src, err := fakeclient.New(fakeclient.WithObjects(platformObjects()...))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	archive := bytes.Buffer{}
+
+	_, err = backup.Backup(t.Context(), src, &archive, backup.BackupOptions{OperatorNamespace: testOperatorNamespace})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	cli, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	t.Run("should create the missing objects", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := backup.Restore(t.Context(), cli, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Created).Should(HaveExactElements(
+			"ConfigMap "+testOperatorNamespace+"/"+gates.AcksConfigMap,
+			"ConfigMap "+testOperatorNamespace+"/edge",
+			"DSCInitialization default-dsci",
+			"DataScienceCluster default-dsc",
+			"Dashboard "+componentApi.DashboardInstanceName,
+		))
+
+		dsc := dscv2.DataScienceCluster{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: "default-dsc"}, &dsc)).Should(Succeed())
+		g.Expect(dsc.Spec.Components.Dashboard.ManagementState).Should(Equal(operatorv1.Managed))
+		g.Expect(dsc.Annotations).Should(HaveKeyWithValue(annotations.InstallProfile, "edge"))
+		g.Expect(dsc.Finalizers).Should(BeEmpty())
+		g.Expect(dsc.Status.Phase).Should(BeEmpty())
+
+		// the owner is the restored DSC
+		dashboard := componentApi.Dashboard{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: componentApi.DashboardInstanceName}, &dashboard)).Should(Succeed())
+		g.Expect(dashboard.OwnerReferences).Should(HaveExactElements(And(
+			HaveField("Kind", gvk.DataScienceCluster.Kind),
+			HaveField("Name", "default-dsc"),
+			HaveField("UID", dsc.UID),
+		)))
+	})
+
+	t.Run("should skip existing objects unless asked to overwrite them", func(t *testing.T) {
+		g := NewWithT(t)
+
+		acks := corev1.ConfigMap{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Namespace: testOperatorNamespace, Name: gates.AcksConfigMap}, &acks)).Should(Succeed())
+		acks.Data = map[string]string{}
+		g.Expect(cli.Update(t.Context(), &acks)).Should(Succeed())
+
+		result, err := backup.Restore(t.Context(), cli, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Created).Should(BeEmpty())
+		g.Expect(result.Skipped).Should(HaveLen(5))
+
+		result, err = backup.Restore(t.Context(), cli, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{Overwrite: true})
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(result.Updated).Should(HaveLen(5))
+
+		g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(&acks), &acks)).Should(Succeed())
+		g.Expect(acks.Data).Should(HaveKeyWithValue("ack-3.1.0-breaking", "true"))
+	})
+}
+
+func TestRestore_ObjectsCreatedByTheOperator(t *testing.T) {
+	g := NewWithT(t)
+
+	src, err := fakeclient.New(fakeclient.WithObjects(platformObjects()...))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	archive := bytes.Buffer{}
+
+	_, err = backup.Backup(t.Context(), src, &archive, backup.BackupOptions{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// the operator creates the Dashboard between the Get and the Create of
+	// the restore
+	cli, err := fakeclient.New(fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if obj.GetObjectKind().GroupVersionKind().Kind != componentApi.DashboardKind {
+				return c.Create(ctx, obj, opts...)
+			}
+
+			if err := c.Create(ctx, &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Name: obj.GetName()}}); err != nil {
+				return err
+			}
+
+			return k8serr.NewAlreadyExists(schema.GroupResource{Resource: "dashboards"}, obj.GetName())
+		},
+	}))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	result, err := backup.Restore(t.Context(), cli, bytes.NewReader(archive.Bytes()), backup.RestoreOptions{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(result.Created).Should(HaveLen(2))
+	g.Expect(result.Skipped).Should(HaveExactElements("Dashboard " + componentApi.DashboardInstanceName))
+}
+
+func TestRestore_ConvertsLegacyVersions(t *testing.T) {
+	g := NewWithT(t)
+
+	dsc := dscv1.DataScienceCluster{
+		TypeMeta:   metav1.TypeMeta{APIVersion: gvk.DataScienceClusterV1.GroupVersion().String(), Kind: gvk.DataScienceClusterV1.Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"},
+		Spec: dscv1.DataScienceClusterSpec{
+			Components: dscv1.Components{
+				DataSciencePipelines: componentApi.DSCDataSciencePipelines{
+					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
+				},
+			},
+		},
+	}
+
+	archive := newArchive(g, backup.ManifestEntry{
+		APIVersion: dsc.APIVersion,
+		Kind:       dsc.Kind,
+		Name:       dsc.Name,
+		Path:       "objects/datasciencecluster.opendatahub.io/datasciencecluster/default-dsc.yaml",
+	}, &dsc)
+
+	cli, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	result, err := backup.Restore(t.Context(), cli, bytes.NewReader(archive), backup.RestoreOptions{})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(result.Created).Should(HaveExactElements("DataScienceCluster default-dsc"))
+
+	restored := dscv2.DataScienceCluster{}
+	g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: "default-dsc"}, &restored)).Should(Succeed())
+	g.Expect(restored.Spec.Components.AIPipelines.ManagementState).Should(Equal(operatorv1.Managed))
+}
+
+func TestRestore_RejectsInvalidArchives(t *testing.T) {
+	g := NewWithT(t)
+
+	cli, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	_, err = backup.Restore(t.Context(), cli, bytes.NewReader([]byte("not an archive")), backup.RestoreOptions{})
+	g.Expect(err).Should(MatchError(ContainSubstring("invalid backup archive")))
+
+	future := newArchiveWithManifest(g, backup.Manifest{FormatVersion: backup.FormatVersion + 1})
+	_, err = backup.Restore(t.Context(), cli, bytes.NewReader(future), backup.RestoreOptions{})
+	g.Expect(err).Should(MatchError(ContainSubstring("unsupported backup archive format version")))
+
+	missing := newArchiveWithManifest(g, backup.Manifest{
+		FormatVersion: backup.FormatVersion,
+		Objects:       []backup.ManifestEntry{{APIVersion: "v1", Kind: "ConfigMap", Name: "cm", Path: "objects/core/configmap/cm.yaml"}},
+	})
+	_, err = backup.Restore(t.Context(), cli, bytes.NewReader(missing), backup.RestoreOptions{})
+	g.Expect(err).Should(MatchError(ContainSubstring("objects/core/configmap/cm.yaml not found")))
+}
+
+func newArchive(g Gomega, entry backup.ManifestEntry, obj client.Object) []byte {
+	data, err := yaml.Marshal(obj)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return newArchiveWithManifest(g, backup.Manifest{
+		FormatVersion: backup.FormatVersion,
+		APIVersions:   []string{entry.APIVersion},
+		Objects:       []backup.ManifestEntry{entry},
+	}, entry.Path, data)
+}
+
+func newArchiveWithManifest(g Gomega, manifest backup.Manifest, files ...any) []byte {
+	data, err := json.Marshal(manifest)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	files = append(files, backup.ManifestFile, data)
+
+	out := bytes.Buffer{}
+	gz := gzip.NewWriter(&out)
+	tw := tar.NewWriter(gz)
+
+	for i := 0; i < len(files); i += 2 {
+		content, _ := files[i+1].([]byte)
+
+		g.Expect(tw.WriteHeader(&tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     files[i].(string),
+			Mode:     0o644,
+			Size:     int64(len(content)),
+		})).Should(Succeed())
+
+		_, err := tw.Write(content)
+		g.Expect(err).ShouldNot(HaveOccurred())
+	}
+
+	g.Expect(tw.Close()).Should(Succeed())
+	g.Expect(gz.Close()).Should(Succeed())
+
+	return out.Bytes()
+}
@@ -0,0 +1,264 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+	"sigs.k8s.io/yaml"
+
+	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v1"
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	dsciv1 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+)
+
+// RestoreOptions configures Restore.
+type RestoreOptions struct {
+	// Overwrite replaces the spec of the objects that already exist, which
+	// are otherwise left untouched.
+	Overwrite bool
+}
+
+// RestoreResult reports what Restore did, as "Kind namespace/name" or
+// "Kind name" references.
+type RestoreResult struct {
+	Created []string `json:"created,omitempty"`
+	Updated []string `json:"updated,omitempty"`
+	Skipped []string `json:"skipped,omitempty"`
+}
+
+// hubConversion converts a legacy version of a platform API to its hub.
+type hubConversion struct {
+	hub     schema.GroupVersionKind
+	objects func() (conversion.Convertible, conversion.Hub)
+}
+
+var hubConversions = map[schema.GroupVersionKind]hubConversion{
+	gvk.DataScienceClusterV1: {
+		hub: gvk.DataScienceCluster,
+		objects: func() (conversion.Convertible, conversion.Hub) {
+			return &dscv1.DataScienceCluster{}, &dscv2.DataScienceCluster{}
+		},
+	},
+	gvk.DSCInitializationV1: {
+		hub: gvk.DSCInitialization,
+		objects: func() (conversion.Convertible, conversion.Hub) {
+			return &dsciv1.DSCInitialization{}, &dsciv2.DSCInitialization{}
+		},
+	},
+}
+
+// ReadManifest returns the manifest of the archive read from r.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	manifest, _, err := readArchive(r)
+
+	return manifest, err
+}
+
+// Restore creates the objects of the archive read from r, in the order of its
+// manifest. Objects of legacy API versions are converted to the current
+// version first.
+func Restore(ctx context.Context, cli client.Client, r io.Reader, opts RestoreOptions) (*RestoreResult, error) {
+	manifest, files, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+
+	result := RestoreResult{}
+
+	for _, entry := range manifest.Objects {
+		data, ok := files[entry.Path]
+		if !ok {
+			return nil, fmt.Errorf("invalid backup archive: %s not found", entry.Path)
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(data, &obj.Object); err != nil {
+			return nil, fmt.Errorf("invalid backup archive: failed to decode %s: %w", entry.Path, err)
+		}
+
+		if obj.GroupVersionKind() != entry.GroupVersionKind() || obj.GetName() != entry.Name || obj.GetNamespace() != entry.Namespace {
+			return nil, fmt.Errorf("invalid backup archive: %s does not match its manifest entry", entry.Path)
+		}
+
+		obj, err = convertToHub(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		sanitize(obj)
+
+		ref := objectRef(obj)
+
+		existing := &unstructured.Unstructured{}
+		existing.SetGroupVersionKind(obj.GroupVersionKind())
+
+		err = cli.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		if k8serr.IsNotFound(err) {
+			if err := resolveOwners(ctx, cli, obj); err != nil {
+				return &result, fmt.Errorf("failed to resolve the owners of %s: %w", ref, err)
+			}
+
+			err = cli.Create(ctx, obj)
+			if err == nil {
+				result.Created = append(result.Created, ref)
+				continue
+			}
+
+			// the operator may create the object while reconciling the ones
+			// restored before it, it is then handled as an existing one
+			if !k8serr.IsAlreadyExists(err) {
+				return &result, fmt.Errorf("failed to create %s: %w", ref, err)
+			}
+
+			err = cli.Get(ctx, client.ObjectKeyFromObject(obj), existing)
+		}
+
+		switch {
+		case err != nil:
+			return &result, fmt.Errorf("failed to get %s: %w", ref, err)
+		case !opts.Overwrite:
+			result.Skipped = append(result.Skipped, ref)
+		default:
+			obj.SetResourceVersion(existing.GetResourceVersion())
+			obj.SetOwnerReferences(existing.GetOwnerReferences())
+			obj.SetFinalizers(existing.GetFinalizers())
+
+			if err := cli.Update(ctx, obj); err != nil {
+				return &result, fmt.Errorf("failed to update %s: %w", ref, err)
+			}
+			result.Updated = append(result.Updated, ref)
+		}
+	}
+
+	return &result, nil
+}
+
+// resolveOwners sets the UID of the owner references of obj, which are
+// archived without it, from the owners found in the cluster. References to
+// owners that do not exist are dropped. Owners are restored before the objects
+// they own, following the order of Kinds.
+func resolveOwners(ctx context.Context, cli client.Client, obj *unstructured.Unstructured) error {
+	refs := obj.GetOwnerReferences()
+	if len(refs) == 0 {
+		return nil
+	}
+
+	resolved := make([]metav1.OwnerReference, 0, len(refs))
+
+	for _, ref := range refs {
+		owner := &unstructured.Unstructured{}
+		owner.SetAPIVersion(ref.APIVersion)
+		owner.SetKind(ref.Kind)
+
+		err := cli.Get(ctx, client.ObjectKey{Namespace: obj.GetNamespace(), Name: ref.Name}, owner)
+		switch {
+		case k8serr.IsNotFound(err) || meta.IsNoMatchError(err):
+			continue
+		case err != nil:
+			return fmt.Errorf("failed to get %s %s: %w", ref.Kind, ref.Name, err)
+		}
+
+		ref.UID = owner.GetUID()
+		resolved = append(resolved, ref)
+	}
+
+	obj.SetOwnerReferences(resolved)
+
+	return nil
+}
+
+// convertToHub converts obj to the hub version of its API, if obj is of a
+// legacy version.
+func convertToHub(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	c, ok := hubConversions[obj.GroupVersionKind()]
+	if !ok {
+		return obj, nil
+	}
+
+	src, dst := c.objects()
+
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, src); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", objectRef(obj), err)
+	}
+
+	if err := src.ConvertTo(dst); err != nil {
+		return nil, fmt.Errorf("failed to convert %s: %w", objectRef(obj), err)
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(dst)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s: %w", objectRef(obj), err)
+	}
+
+	converted := &unstructured.Unstructured{Object: content}
+	converted.SetGroupVersionKind(c.hub)
+
+	return converted, nil
+}
+
+func objectRef(obj *unstructured.Unstructured) string {
+	return ManifestEntry{Kind: obj.GetKind(), Namespace: obj.GetNamespace(), Name: obj.GetName()}.String()
+}
+
+func readArchive(r io.Reader) (*Manifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid backup archive: %w", err)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid backup archive: %w", err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid backup archive: failed to read %s: %w", hdr.Name, err)
+		}
+
+		files[hdr.Name] = data
+	}
+
+	data, ok := files[ManifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid backup archive: %s not found", ManifestFile)
+	}
+
+	manifest := Manifest{}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, nil, fmt.Errorf("invalid backup archive: failed to decode %s: %w", ManifestFile, err)
+	}
+
+	if manifest.FormatVersion > FormatVersion {
+		return nil, nil, fmt.Errorf("unsupported backup archive format version %d, the newest supported is %d", manifest.FormatVersion, FormatVersion)
+	}
+
+	return &manifest, files, nil
+}
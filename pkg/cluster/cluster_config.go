@@ -74,6 +74,16 @@ func Init(ctx context.Context, cli client.Client, cfg operatorconfig.OperatorSet
 	}
 
 	printClusterConfig(log)
+
+	if r, ok, err := GetDowngradeRelease(ctx, cli, clusterConfig.Release); err != nil {
+		log.Error(err, "unable to detect a release downgrade")
+		// not fatal, only logged
+	} else if ok {
+		log.Info("Release downgrade detected, running in release-downgrade mode",
+			"Deployed", r.Version.String(),
+			"Running", clusterConfig.Release.Version.String())
+	}
+
 	return nil
 }
 
@@ -136,6 +146,29 @@ func GetDeployedRelease(ctx context.Context, cli client.Client) (common.Release,
 	return common.Release{}, nil
 }
 
+// GetDowngradeRelease returns the currently deployed release and whether it is
+// newer than the running one. In that case the operator runs in
+// release-downgrade mode: resources deployed by the newer release are accepted
+// instead of being garbage collected and the reverse migrations are run instead
+// of the upgrade ones. The mode ends once the running release is recorded as
+// the deployed one, so it is computed on each call instead of at startup.
+func GetDowngradeRelease(ctx context.Context, cli client.Client, running common.Release) (common.Release, bool, error) {
+	deployed, err := GetDeployedRelease(ctx, cli)
+	switch {
+	case meta.IsNoMatchError(err):
+		return common.Release{}, false, nil
+	case err != nil:
+		return common.Release{}, false, err
+	}
+
+	// the recorded release of another platform is not a downgrade
+	if deployed.Name != running.Name || !deployed.Version.GT(running.Version.Version) {
+		return common.Release{}, false, nil
+	}
+
+	return deployed, true, nil
+}
+
 func GetClusterInfo() ClusterInfo {
 	return clusterConfig.ClusterInfo
 }
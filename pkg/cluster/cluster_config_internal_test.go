@@ -5,7 +5,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/blang/semver/v4"
 	configv1 "github.com/openshift/api/config/v1"
+	"github.com/operator-framework/api/pkg/lib/version"
 	"github.com/spf13/viper"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
 	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
 )
 
@@ -474,3 +477,67 @@ func TestGetOCPVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDowngradeRelease(t *testing.T) {
+	running := common.Release{Name: OpenDataHub, Version: version.OperatorVersion{Version: semver.MustParse("3.0.0")}}
+
+	testCases := []struct {
+		name      string
+		deployed  *common.Release
+		downgrade bool
+	}{
+		{
+			name:      "fresh installation",
+			deployed:  nil,
+			downgrade: false,
+		},
+		{
+			name:      "older release deployed",
+			deployed:  &common.Release{Name: OpenDataHub, Version: version.OperatorVersion{Version: semver.MustParse("2.25.0")}},
+			downgrade: false,
+		},
+		{
+			name:      "same release deployed",
+			deployed:  &running,
+			downgrade: false,
+		},
+		{
+			name:      "newer release deployed",
+			deployed:  &common.Release{Name: OpenDataHub, Version: version.OperatorVersion{Version: semver.MustParse("3.1.0")}},
+			downgrade: true,
+		},
+		{
+			name:      "newer release of another platform deployed",
+			deployed:  &common.Release{Name: SelfManagedRhoai, Version: version.OperatorVersion{Version: semver.MustParse("3.1.0")}},
+			downgrade: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = dsciv2.AddToScheme(scheme)
+			_ = dscv2.AddToScheme(scheme)
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.deployed != nil {
+				builder = builder.WithObjects(&dsciv2.DSCInitialization{
+					ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+					Status:     dsciv2.DSCInitializationStatus{Release: *tc.deployed},
+				})
+			}
+
+			r, ok, err := GetDowngradeRelease(t.Context(), builder.Build(), running)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ok != tc.downgrade {
+				t.Fatalf("expected downgrade %v, got release %+v", tc.downgrade, r)
+			}
+			if tc.downgrade && (r.Name != tc.deployed.Name || !r.Version.EQ(tc.deployed.Version.Version)) {
+				t.Fatalf("expected release %+v, got %+v", *tc.deployed, r)
+			}
+		})
+	}
+}
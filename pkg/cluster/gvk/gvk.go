@@ -235,6 +235,12 @@ var (
 		Kind:    "APIServer",
 	}
 
+	ImageDigestMirrorSet = schema.GroupVersionKind{
+		Group:   configv1.GroupVersion.Group,
+		Version: configv1.GroupVersion.Version,
+		Kind:    "ImageDigestMirrorSet",
+	}
+
 	SecurityContextConstraints = schema.GroupVersionKind{
 		Group:   securityv1.SchemeGroupVersion.Group,
 		Version: securityv1.SchemeGroupVersion.Version,
@@ -373,6 +379,12 @@ var (
 		Kind:    configApi.PlatformKind,
 	}
 
+	UpgradeGate = schema.GroupVersionKind{
+		Group:   configApi.GroupVersion.Group,
+		Version: configApi.GroupVersion.Version,
+		Kind:    configApi.UpgradeGateKind,
+	}
+
 	FeastOperator = schema.GroupVersionKind{
 		Group:   componentApi.GroupVersion.Group,
 		Version: componentApi.GroupVersion.Version,
@@ -643,6 +655,12 @@ var (
 		Kind:    "LeaderWorkerSetOperator",
 	}
 
+	KedaControllerV1Alpha1 = schema.GroupVersionKind{
+		Group:   "keda.sh",
+		Version: "v1alpha1",
+		Kind:    "KedaController",
+	}
+
 	JobSetOperatorV1 = schema.GroupVersionKind{
 		Group:   "operator.openshift.io",
 		Version: "v1",
@@ -667,6 +685,12 @@ var (
 		Kind:    "Notebook",
 	}
 
+	DataSciencePipelinesApplication = schema.GroupVersionKind{
+		Group:   "datasciencepipelinesapplications.opendatahub.io",
+		Version: "v1",
+		Kind:    "DataSciencePipelinesApplication",
+	}
+
 	LLMInferenceServiceConfigV1Alpha1 = schema.GroupVersionKind{
 		Group:   "serving.kserve.io",
 		Version: "v1alpha1",
@@ -835,6 +859,12 @@ var (
 		Kind:    "PodMonitor",
 	}
 
+	CoreosPrometheus = schema.GroupVersionKind{
+		Group:   "monitoring.coreos.com",
+		Version: "v1",
+		Kind:    "Prometheus",
+	}
+
 	PrometheusRule = schema.GroupVersionKind{
 		Group:   "monitoring.rhobs",
 		Version: "v1",
@@ -1017,6 +1047,18 @@ var (
 		Kind:    "AWSKubernetesEngine",
 	}
 
+	GKEKubernetesEngine = schema.GroupVersionKind{
+		Group:   "infrastructure.opendatahub.io",
+		Version: "v1alpha1",
+		Kind:    "GKEKubernetesEngine",
+	}
+
+	GenericKubernetesEngine = schema.GroupVersionKind{
+		Group:   "infrastructure.opendatahub.io",
+		Version: "v1alpha1",
+		Kind:    "GenericKubernetesEngine",
+	}
+
 	SparkApplication = schema.GroupVersionKind{
 		Group:   "sparkoperator.k8s.io",
 		Version: "v1beta2",
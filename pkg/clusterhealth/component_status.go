@@ -8,6 +8,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -28,16 +29,19 @@ var KnownComponents = map[string]string{
 
 // ComponentStatusResult holds the health details for a single ODH component.
 type ComponentStatusResult struct {
-	Component   string             `json:"component"`
-	CRFound     bool               `json:"crFound"`
-	Conditions  []ConditionSummary `json:"conditions"`
-	Deployments []DeploymentInfo   `json:"deployments"`
-	Pods        []PodInfo          `json:"pods"`
-	Errors      []string           `json:"errors,omitempty"`
+	Component   string              `json:"component"`
+	CRFound     bool                `json:"crFound"`
+	Conditions  []ConditionSummary  `json:"conditions"`
+	Deployments []DeploymentInfo    `json:"deployments"`
+	Pods        []PodInfo           `json:"pods"`
+	Resources   []InventoryResource `json:"resources,omitempty"`
+	Errors      []string            `json:"errors,omitempty"`
 }
 
 // GetComponentStatus fetches the CR conditions, deployments, and pods for a named component.
-func GetComponentStatus(ctx context.Context, c client.Client, name, appsNS string) (*ComponentStatusResult, error) {
+// When the operator published the inventory of the component resources, deployments are
+// taken from it, otherwise they are discovered by label.
+func GetComponentStatus(ctx context.Context, c client.Client, name, appsNS, operatorNS string) (*ComponentStatusResult, error) {
 	kind, ok := KnownComponents[name]
 	if !ok {
 		names := make([]string, 0, len(KnownComponents))
@@ -66,16 +70,39 @@ func GetComponentStatus(ctx context.Context, c client.Client, name, appsNS strin
 		}
 	}
 
+	inventory, found, err := GetInventory(ctx, c, operatorNS, name)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("inventory lookup: %v", err))
+	}
+	result.Resources = inventory
+
 	// Labeled deployments and pods.
 	label := client.MatchingLabels{"app.opendatahub.io/" + name: "true"}
 	ns := client.InNamespace(appsNS)
 
-	var deps appsv1.DeploymentList
-	if err := c.List(ctx, &deps, ns, label); err != nil {
-		return nil, fmt.Errorf("listing deployments for component %q: %w", name, err)
-	}
-	for i := range deps.Items {
-		result.Deployments = append(result.Deployments, deploymentToInfo(&deps.Items[i]))
+	if found {
+		for _, r := range inventory {
+			if r.Group != "apps" || r.Kind != "Deployment" {
+				continue
+			}
+			var d appsv1.Deployment
+			if err := c.Get(ctx, client.ObjectKey{Namespace: r.Namespace, Name: r.Name}, &d); err != nil {
+				if k8serr.IsNotFound(err) {
+					result.Errors = append(result.Errors, fmt.Sprintf("deployment %s/%s not found", r.Namespace, r.Name))
+					continue
+				}
+				return nil, fmt.Errorf("getting deployment %s/%s for component %q: %w", r.Namespace, r.Name, name, err)
+			}
+			result.Deployments = append(result.Deployments, deploymentToInfo(&d))
+		}
+	} else {
+		var deps appsv1.DeploymentList
+		if err := c.List(ctx, &deps, ns, label); err != nil {
+			return nil, fmt.Errorf("listing deployments for component %q: %w", name, err)
+		}
+		for i := range deps.Items {
+			result.Deployments = append(result.Deployments, deploymentToInfo(&deps.Items[i]))
+		}
 	}
 
 	var pods corev1.PodList
@@ -0,0 +1,70 @@
+package clusterhealth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// The operator publishes the resources deployed for each component in the
+// kinds inventory ConfigMap of the component controller, living in the
+// operator namespace. These mirror the format written by the operator GC and
+// deploy actions.
+const (
+	InventoryLabel      = "platform.opendatahub.io/gvk-inventory"
+	InventoryDataKey    = "inventory.json"
+	InventoryNameSuffix = "-gvk-inventory"
+)
+
+// InventoryName returns the name of the inventory ConfigMap of the named component.
+func InventoryName(name string) string {
+	return name + InventoryNameSuffix
+}
+
+// InventoryResource is a resource deployed by the operator for a component.
+type InventoryResource struct {
+	Group           string `json:"group,omitempty"`
+	Version         string `json:"version"`
+	Kind            string `json:"kind"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// GetInventory returns the resources the operator deployed for the named
+// component, from the inventory ConfigMap in the operator namespace. The
+// boolean is false when no resources have been published, in which case
+// callers should fall back to label based discovery.
+func GetInventory(ctx context.Context, c client.Client, operatorNS, name string) ([]InventoryResource, bool, error) {
+	var cm corev1.ConfigMap
+	err := c.Get(ctx, client.ObjectKey{Namespace: operatorNS, Name: InventoryName(name)}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		return nil, false, nil
+	case err != nil:
+		return nil, false, fmt.Errorf("getting inventory for component %q: %w", name, err)
+	}
+
+	data, ok := cm.Data[InventoryDataKey]
+	if !ok {
+		return nil, false, nil
+	}
+
+	var inv struct {
+		Resources []InventoryResource `json:"resources"`
+	}
+	if err := json.Unmarshal([]byte(data), &inv); err != nil {
+		return nil, false, fmt.Errorf("decoding inventory for component %q: %w", name, err)
+	}
+
+	// the kinds inventory is also kept for controllers not publishing resources
+	if inv.Resources == nil {
+		return nil, false, nil
+	}
+
+	return inv.Resources, true, nil
+}
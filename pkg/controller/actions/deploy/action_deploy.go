@@ -7,6 +7,7 @@ import (
 	"maps"
 	"strconv"
 	"strings"
+	"time"
 
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -18,10 +19,12 @@ import (
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
 	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/flags"
 )
 
 type Mode string
@@ -44,6 +47,15 @@ func (s SortFn) Then(then SortFn) SortFn {
 	}
 }
 
+// deployState holds what is collected while deploying the resources of a
+// single reconciliation.
+type deployState struct {
+	conflicts   []Conflict
+	inventory   []inventoryEntry
+	maintenance *maintenanceState
+	deferred    []string
+}
+
 // Action deploys the resources that are included in the ReconciliationRequest using
 // the same create or patch machinery implemented as part of deploy.DeployManifestsFromPath.
 type Action struct {
@@ -56,6 +68,12 @@ type Action struct {
 	cache            *Cache
 	sortFn           SortFn
 	continueOnError  bool
+
+	conflictPolicies      map[string]ConflictPolicy
+	defaultConflictPolicy ConflictPolicy
+	inventory             bool
+	inventoryNamespace    string
+	disruptiveFields      map[schema.GroupKind][]string
 }
 
 type ActionOpts func(*Action)
@@ -195,6 +213,17 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 
 	igvk := rr.Instance.GetObjectKind().GroupVersionKind()
 
+	maintenance, err := a.resolveMaintenance(ctx, rr)
+	if err != nil {
+		return err
+	}
+
+	state := deployState{maintenance: maintenance}
+	defer func() {
+		a.reportConflicts(rr, controllerName, state.conflicts)
+		a.reportPendingMaintenance(rr, &state)
+	}()
+
 	var firstErr error
 	var failedResources []string
 
@@ -248,9 +277,9 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 
 		switch rr.Resources[i].GroupVersionKind() {
 		case gvk.CustomResourceDefinition:
-			ok, err = a.deployCRD(ctx, rr, res, current)
+			ok, err = a.deployCRD(ctx, rr, res, current, &state)
 		default:
-			ok, err = a.deploy(ctx, rr, res, current)
+			ok, err = a.deploy(ctx, rr, res, current, &state)
 		}
 
 		if err != nil {
@@ -285,6 +314,17 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 			firstErr, len(failedResources)-1, strings.Join(failedResources[1:], ", "))
 	}
 
+	if a.inventory {
+		if err := a.publishInventory(ctx, rr, state.inventory); err != nil {
+			return err
+		}
+	}
+
+	// reconcile again once the maintenance window opens to apply the deferred changes
+	if len(state.deferred) != 0 && !state.maintenance.next.IsZero() {
+		return odherrors.NewRequeueAfterError(time.Until(state.maintenance.next))
+	}
+
 	return nil
 }
 
@@ -323,6 +363,7 @@ func (a *Action) deployCRD(
 	rr *odhTypes.ReconciliationRequest,
 	obj unstructured.Unstructured,
 	current *unstructured.Unstructured,
+	state *deployState,
 ) (bool, error) {
 	resources.SetLabels(&obj, a.labels)
 	resources.SetAnnotations(&obj, a.annotations)
@@ -347,6 +388,7 @@ func (a *Action) deployCRD(
 		return false, err
 	}
 	if shouldSkip {
+		state.record(current, nil)
 		return false, nil
 	}
 
@@ -365,7 +407,6 @@ func (a *Action) deployCRD(
 		client.FieldOwner(resources.PlatformFieldOwner),
 	}
 	applyOps := []client.ApplyOption{
-		client.ForceOwnership,
 		// Since CRDs are not bound to a component, set the field
 		// owner to the platform itself
 		client.FieldOwner(resources.PlatformFieldOwner),
@@ -375,7 +416,7 @@ func (a *Action) deployCRD(
 	case ModePatch:
 		deployedObj, err = a.patch(ctx, rr.Client, &obj, current, patchOps...)
 	case ModeSSA:
-		deployedObj, err = a.apply(ctx, rr.Client, &obj, current, applyOps...)
+		deployedObj, err = a.apply(ctx, rr.Client, &obj, current, state, applyOps...)
 	default:
 		err = fmt.Errorf("unsupported deploy mode %s", a.deployMode)
 	}
@@ -391,6 +432,8 @@ func (a *Action) deployCRD(
 		}
 	}
 
+	state.record(&obj, deployedObj)
+
 	return true, nil
 }
 
@@ -399,6 +442,7 @@ func (a *Action) deploy(
 	rr *odhTypes.ReconciliationRequest,
 	obj unstructured.Unstructured,
 	current *unstructured.Unstructured,
+	state *deployState,
 ) (bool, error) {
 	fo, err := a.resolveFieldOwner(rr)
 	if err != nil {
@@ -422,6 +466,7 @@ func (a *Action) deploy(
 		return false, err
 	}
 	if shouldSkip {
+		state.record(current, nil)
 		return false, nil
 	}
 
@@ -431,6 +476,7 @@ func (a *Action) deploy(
 	origObj := obj.DeepCopy()
 
 	var deployedObj *unstructured.Unstructured
+	var deferred bool
 
 	switch {
 	// The object is explicitly marked as not owned by the operator in the manifests,
@@ -458,13 +504,17 @@ func (a *Action) deploy(
 			}
 		}
 
+		deferred, err = a.deferDisruptiveChanges(&obj, current, state)
+		if err != nil {
+			return false, err
+		}
+
 		// Prepare options for both patch and apply modes
 		patchOps := []client.PatchOption{
 			client.ForceOwnership,
 			client.FieldOwner(fo),
 		}
 		applyOps := []client.ApplyOption{
-			client.ForceOwnership,
 			client.FieldOwner(fo),
 		}
 
@@ -472,7 +522,7 @@ func (a *Action) deploy(
 		case ModePatch:
 			deployedObj, err = a.patch(ctx, rr.Client, &obj, current, patchOps...)
 		case ModeSSA:
-			deployedObj, err = a.apply(ctx, rr.Client, &obj, current, applyOps...)
+			deployedObj, err = a.apply(ctx, rr.Client, &obj, current, state, applyOps...)
 		default:
 			err = fmt.Errorf("unsupported deploy mode %s", a.deployMode)
 		}
@@ -482,13 +532,17 @@ func (a *Action) deploy(
 		}
 	}
 
-	if a.cache != nil {
+	// resources with deferred disruptive changes must not be cached or they
+	// would be skipped once the maintenance window opens
+	if a.cache != nil && !deferred {
 		err := a.cache.Add(deployedObj, origObj)
 		if err != nil {
 			return false, fmt.Errorf("failed to cache object: %w", err)
 		}
 	}
 
+	state.record(&obj, deployedObj)
+
 	return true, nil
 }
 
@@ -573,6 +627,7 @@ func (a *Action) apply(
 	cli client.Client,
 	obj *unstructured.Unstructured,
 	old *unstructured.Unstructured,
+	state *deployState,
 	opts ...client.ApplyOption,
 ) (*unstructured.Unstructured, error) {
 	logf.FromContext(ctx).V(3).Info("apply",
@@ -636,7 +691,38 @@ func (a *Action) apply(
 		break
 	}
 
+	// A resource which does not exist yet has no other field manager, it is
+	// applied forcing the ownership right away.
+	if old == nil {
+		err := resources.Apply(ctx, cli, obj, append(opts, client.ForceOwnership)...)
+		if err != nil {
+			return nil, fmt.Errorf("apply failed %s: %w", obj.GroupVersionKind(), err)
+		}
+
+		return obj, nil
+	}
+
+	// Otherwise apply without forcing the ownership first, so conflicts with
+	// other field managers are detected, reported, and resolved according to
+	// the configured policies, ConflictPolicyForce included.
 	err := resources.Apply(ctx, cli, obj, opts...)
+	if err == nil {
+		return obj, nil
+	}
+
+	detected := ParseConflicts(err, obj)
+	if len(detected) == 0 {
+		return nil, fmt.Errorf("apply failed %s: %w", obj.GroupVersionKind(), err)
+	}
+
+	err = a.resolveConflicts(obj, old, detected)
+	state.conflicts = append(state.conflicts, detected...)
+
+	if err != nil {
+		return nil, fmt.Errorf("apply failed %s: %w", obj.GroupVersionKind(), err)
+	}
+
+	err = resources.Apply(ctx, cli, obj, append(opts, client.ForceOwnership)...)
 	if err != nil {
 		return nil, fmt.Errorf("apply failed %s: %w", obj.GroupVersionKind(), err)
 	}
@@ -680,6 +766,14 @@ func NewAction(opts ...ActionOpts) actions.Fn {
 		partOfLabelKey:   labels.PlatformPartOf,
 		annotationPrefix: labels.ODHPlatformPrefix,
 		sortFn:           resources.SortByApplyOrder,
+		disruptiveFields: maps.Clone(defaultDisruptiveFields),
+	}
+
+	// The conflict policies configured for the operator are validated at
+	// startup, the options below take precedence over them.
+	if policies, defaultPolicy, err := ParseConflictPolicies(flags.GetDeployConflictPolicies()); err == nil {
+		action.conflictPolicies = policies
+		action.defaultConflictPolicy = defaultPolicy
 	}
 
 	for _, opt := range opts {
@@ -0,0 +1,346 @@
+package deploy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v6/value"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+// ConflictPolicy defines how the deploy action reacts when server-side apply
+// reports that a field it wants to set is owned by another field manager.
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyForce takes the ownership of the conflicting fields, this
+	// is the default behavior.
+	ConflictPolicyForce ConflictPolicy = "Force"
+	// ConflictPolicyYield leaves the conflicting fields to the other manager
+	// by removing them from the applied configuration.
+	ConflictPolicyYield ConflictPolicy = "Yield"
+	// ConflictPolicyFail stops deploying the resource and reports an error.
+	ConflictPolicyFail ConflictPolicy = "Fail"
+)
+
+var conflictManagerRe = regexp.MustCompile(`^conflict with "([^"]*)"`)
+
+// Conflict describes a single field owned by another field manager that
+// server-side apply refused to overwrite.
+type Conflict struct {
+	// Object identifies the conflicting resource, i.e. Deployment ns/name.
+	Object string
+	// Field is the path of the conflicting field, i.e. .spec.replicas.
+	Field string
+	// Manager is the name of the field manager owning the field.
+	Manager string
+	// Policy is the policy applied to resolve the conflict.
+	Policy ConflictPolicy
+}
+
+func (c Conflict) String() string {
+	return fmt.Sprintf("%s: %s managed by %q (%s)", c.Object, c.Field, c.Manager, c.Policy)
+}
+
+// ConflictError is returned when a conflict is resolved with ConflictPolicyFail.
+type ConflictError struct {
+	Conflicts []Conflict
+}
+
+func (e *ConflictError) Error() string {
+	msgs := make([]string, 0, len(e.Conflicts))
+	for _, c := range e.Conflicts {
+		msgs = append(msgs, c.String())
+	}
+
+	return "conflicting field managers: " + strings.Join(msgs, ", ")
+}
+
+// WithConflictPolicy sets the policy to apply when server-side apply reports
+// a conflict with the given field manager, i.e. ConflictPolicyYield for
+// "kube-controller-manager" lets an HPA manage the replicas of a Deployment.
+func WithConflictPolicy(manager string, policy ConflictPolicy) ActionOpts {
+	return func(action *Action) {
+		if action.conflictPolicies == nil {
+			action.conflictPolicies = map[string]ConflictPolicy{}
+		}
+
+		action.conflictPolicies[manager] = policy
+	}
+}
+
+// WithDefaultConflictPolicy sets the policy to apply when server-side apply
+// reports a conflict with a field manager without an explicit policy.
+func WithDefaultConflictPolicy(policy ConflictPolicy) ActionOpts {
+	return func(action *Action) {
+		action.defaultConflictPolicy = policy
+	}
+}
+
+// ParseConflictPolicies parses the conflict policies configured for the operator,
+// a comma separated list of <field manager>=<policy> entries, i.e.
+// "kube-controller-manager=Yield,*=Force". The "*" entry sets the policy of
+// the field managers without an explicit one.
+func ParseConflictPolicies(value string) (map[string]ConflictPolicy, ConflictPolicy, error) {
+	var policies map[string]ConflictPolicy
+	var defaultPolicy ConflictPolicy
+
+	for entry := range strings.SplitSeq(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		manager, policy, ok := strings.Cut(entry, "=")
+		manager = strings.TrimSpace(manager)
+		policy = strings.TrimSpace(policy)
+
+		if !ok || manager == "" {
+			return nil, "", fmt.Errorf("invalid conflict policy %q, expected <field manager>=<policy>", entry)
+		}
+
+		switch p := ConflictPolicy(policy); p {
+		case ConflictPolicyForce, ConflictPolicyYield, ConflictPolicyFail:
+			if manager == "*" {
+				defaultPolicy = p
+				continue
+			}
+
+			if policies == nil {
+				policies = map[string]ConflictPolicy{}
+			}
+
+			policies[manager] = p
+		default:
+			return nil, "", fmt.Errorf("unsupported conflict policy %q for field manager %q", policy, manager)
+		}
+	}
+
+	return policies, defaultPolicy, nil
+}
+
+func (a *Action) conflictPolicy(manager string) ConflictPolicy {
+	if p, ok := a.conflictPolicies[manager]; ok {
+		return p
+	}
+
+	if a.defaultConflictPolicy != "" {
+		return a.defaultConflictPolicy
+	}
+
+	return ConflictPolicyForce
+}
+
+// ParseConflicts extracts the field manager conflicts reported by the API
+// server in the given error. It returns nil if err is not an SSA conflict.
+func ParseConflicts(err error, obj *unstructured.Unstructured) []Conflict {
+	if !k8serr.IsConflict(err) {
+		return nil
+	}
+
+	var apiStatus k8serr.APIStatus
+	if !errors.As(err, &apiStatus) || apiStatus.Status().Details == nil {
+		return nil
+	}
+
+	object := fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+
+	var result []Conflict
+
+	for _, cause := range apiStatus.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		m := conflictManagerRe.FindStringSubmatch(cause.Message)
+		if m == nil {
+			continue
+		}
+
+		result = append(result, Conflict{
+			Object:  object,
+			Field:   cause.Field,
+			Manager: m[1],
+		})
+	}
+
+	return result
+}
+
+// yieldFields removes from obj the given fields, owned by the given manager
+// according to the managed fields of current, so that applying obj does not
+// take their ownership.
+func yieldFields(obj *unstructured.Unstructured, current *unstructured.Unstructured, manager string, fields []string) error {
+	owned := fieldpath.NewSet()
+
+	for _, mf := range current.GetManagedFields() {
+		if mf.Manager != manager || mf.FieldsV1 == nil {
+			continue
+		}
+
+		s := fieldpath.NewSet()
+		if err := s.FromJSON(bytes.NewReader(mf.FieldsV1.Raw)); err != nil {
+			return fmt.Errorf("failed to decode managed fields of %q: %w", manager, err)
+		}
+
+		owned = owned.Union(s)
+	}
+
+	for p := range owned.All() {
+		if slices.Contains(fields, p.String()) {
+			removePath(obj.Object, p)
+		}
+	}
+
+	return nil
+}
+
+// removePath removes the element identified by the given structured merge
+// diff path from an unstructured object.
+func removePath(node any, p fieldpath.Path) any {
+	if len(p) == 0 {
+		return node
+	}
+
+	pe := p[0]
+
+	switch n := node.(type) {
+	case map[string]any:
+		if pe.FieldName == nil {
+			return n
+		}
+
+		child, ok := n[*pe.FieldName]
+		if !ok {
+			return n
+		}
+
+		if len(p) == 1 {
+			delete(n, *pe.FieldName)
+		} else {
+			n[*pe.FieldName] = removePath(child, p[1:])
+		}
+
+		return n
+	case []any:
+		for i := range n {
+			if !elementMatches(n[i], i, pe) {
+				continue
+			}
+
+			if len(p) == 1 {
+				return slices.Delete(n, i, i+1)
+			}
+
+			n[i] = removePath(n[i], p[1:])
+
+			return n
+		}
+
+		return n
+	default:
+		return node
+	}
+}
+
+func elementMatches(item any, index int, pe fieldpath.PathElement) bool {
+	switch {
+	case pe.Index != nil:
+		return *pe.Index == index
+	case pe.Value != nil:
+		return value.Equals(value.NewValueInterface(item), *pe.Value)
+	case pe.Key != nil:
+		m, ok := item.(map[string]any)
+		if !ok {
+			return false
+		}
+
+		for _, f := range *pe.Key {
+			v, ok := m[f.Name]
+			if !ok || !value.Equals(value.NewValueInterface(v), f.Value) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
+// resolveConflicts sets the policy of each of the given conflicts and prepares
+// obj to be applied again with forced ownership: fields owned by managers the
+// action yields to are removed. A ConflictError is returned if any of the
+// conflicts must fail the deployment.
+func (a *Action) resolveConflicts(obj *unstructured.Unstructured, current *unstructured.Unstructured, conflicts []Conflict) error {
+	var failed []Conflict
+
+	yield := map[string][]string{}
+
+	for i := range conflicts {
+		conflicts[i].Policy = a.conflictPolicy(conflicts[i].Manager)
+
+		switch conflicts[i].Policy {
+		case ConflictPolicyFail:
+			failed = append(failed, conflicts[i])
+		case ConflictPolicyYield:
+			yield[conflicts[i].Manager] = append(yield[conflicts[i].Manager], conflicts[i].Field)
+		case ConflictPolicyForce:
+			// the ownership is forced when applying again
+		default:
+			return fmt.Errorf("unsupported conflict policy %q", conflicts[i].Policy)
+		}
+	}
+
+	if len(failed) != 0 {
+		return &ConflictError{Conflicts: failed}
+	}
+
+	for manager, fields := range yield {
+		if err := yieldFields(obj, current, manager, fields); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reportConflicts records the conflicts detected while deploying the resources
+// in the ResourceConflict condition and in the action_deploy_conflicts_total
+// metric. The condition is left unset when no conflicts are detected, so it is
+// removed from the status once the conflicts are gone.
+func (a *Action) reportConflicts(rr *odhTypes.ReconciliationRequest, controllerName string, conflicts []Conflict) {
+	if len(conflicts) == 0 {
+		return
+	}
+
+	msgs := make([]string, 0, len(conflicts))
+
+	for _, c := range conflicts {
+		DeployConflictsTotal.WithLabelValues(controllerName, c.Manager, string(c.Policy)).Inc()
+		msgs = append(msgs, c.String())
+	}
+
+	if rr.Conditions == nil {
+		return
+	}
+
+	rr.Conditions.MarkTrue(
+		status.ConditionResourceConflict,
+		conditions.WithReason(status.FieldManagerConflictReason),
+		conditions.WithSeverity(common.ConditionSeverityInfo),
+		conditions.WithMessage("%s", strings.Join(msgs, "; ")),
+	)
+}
@@ -0,0 +1,252 @@
+//nolint:testpackage
+package deploy
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func newConflictError(causes ...metav1.StatusCause) error {
+	return &k8serr.StatusError{ErrStatus: metav1.Status{
+		Status: metav1.StatusFailure,
+		Code:   409,
+		Reason: metav1.StatusReasonConflict,
+		Details: &metav1.StatusDetails{
+			Causes: causes,
+		},
+	}}
+}
+
+func newConflictDeployment(managedFields ...metav1.ManagedFieldsEntry) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      "test",
+			"namespace": "test-ns",
+		},
+		"spec": map[string]any{
+			"replicas": int64(1),
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "main", "image": "main:latest"},
+						map[string]any{"name": "proxy", "image": "proxy:latest"},
+					},
+				},
+			},
+		},
+	}}
+
+	obj.SetGroupVersionKind(gvk.Deployment)
+	obj.SetManagedFields(managedFields)
+
+	return obj
+}
+
+func TestParseConflicts(t *testing.T) {
+	g := NewWithT(t)
+
+	err := newConflictError(
+		metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kube-controller-manager" using apps/v1`,
+			Field:   ".spec.replicas",
+		},
+		metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueInvalid,
+			Message: "invalid",
+			Field:   ".spec.foo",
+		},
+	)
+
+	conflicts := ParseConflicts(err, newConflictDeployment())
+	g.Expect(conflicts).Should(Equal([]Conflict{{
+		Object:  "Deployment test-ns/test",
+		Field:   ".spec.replicas",
+		Manager: "kube-controller-manager",
+	}}))
+
+	g.Expect(ParseConflicts(k8serr.NewBadRequest("bad"), newConflictDeployment())).Should(BeEmpty())
+}
+
+func TestResolveConflicts(t *testing.T) {
+	current := newConflictDeployment(
+		metav1.ManagedFieldsEntry{
+			Manager:  "kube-controller-manager",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+		metav1.ManagedFieldsEntry{
+			Manager:  "kubectl-edit",
+			FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"proxy\"}":{"f:image":{}}}}}}}`)},
+		},
+	)
+
+	conflicts := func() []Conflict {
+		return []Conflict{
+			{Object: "Deployment test-ns/test", Field: ".spec.replicas", Manager: "kube-controller-manager"},
+			{Object: "Deployment test-ns/test", Field: `.spec.template.spec.containers[name="proxy"].image`, Manager: "kubectl-edit"},
+		}
+	}
+
+	t.Run("force by default", func(t *testing.T) {
+		g := NewWithT(t)
+
+		a := Action{}
+		obj := newConflictDeployment()
+		values := conflicts()
+
+		g.Expect(a.resolveConflicts(obj, current, values)).Should(Succeed())
+		g.Expect(values).Should(HaveEach(HaveField("Policy", ConflictPolicyForce)))
+		g.Expect(obj).Should(Equal(newConflictDeployment()))
+	})
+
+	t.Run("yield", func(t *testing.T) {
+		g := NewWithT(t)
+
+		a := Action{}
+		WithConflictPolicy("kube-controller-manager", ConflictPolicyYield)(&a)
+		WithConflictPolicy("kubectl-edit", ConflictPolicyYield)(&a)
+
+		obj := newConflictDeployment()
+		values := conflicts()
+
+		g.Expect(a.resolveConflicts(obj, current, values)).Should(Succeed())
+		g.Expect(values).Should(HaveEach(HaveField("Policy", ConflictPolicyYield)))
+		g.Expect(obj).Should(And(
+			jq.Match(`.spec | has("replicas") | not`),
+			jq.Match(`.spec.template.spec.containers | length == 2`),
+			jq.Match(`.spec.template.spec.containers[0].image == "main:latest"`),
+			jq.Match(`.spec.template.spec.containers[1] | has("image") | not`),
+		))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		g := NewWithT(t)
+
+		a := Action{}
+		WithDefaultConflictPolicy(ConflictPolicyFail)(&a)
+		WithConflictPolicy("kube-controller-manager", ConflictPolicyYield)(&a)
+
+		values := conflicts()
+
+		err := a.resolveConflicts(newConflictDeployment(), current, values)
+		g.Expect(err).Should(MatchError(ContainSubstring(`managed by "kubectl-edit" (Fail)`)))
+		g.Expect(err).ShouldNot(MatchError(ContainSubstring("kube-controller-manager")))
+		g.Expect(values[0].Policy).Should(Equal(ConflictPolicyYield))
+		g.Expect(values[1].Policy).Should(Equal(ConflictPolicyFail))
+	})
+}
+
+func TestParseConflictPolicies(t *testing.T) {
+	g := NewWithT(t)
+
+	policies, defaultPolicy, err := ParseConflictPolicies("")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(policies).Should(BeEmpty())
+	g.Expect(defaultPolicy).Should(BeEmpty())
+
+	policies, defaultPolicy, err = ParseConflictPolicies(" kube-controller-manager=Yield, kubectl-edit = Fail ,*=Force")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(policies).Should(Equal(map[string]ConflictPolicy{
+		"kube-controller-manager": ConflictPolicyYield,
+		"kubectl-edit":            ConflictPolicyFail,
+	}))
+	g.Expect(defaultPolicy).Should(Equal(ConflictPolicyForce))
+
+	_, _, err = ParseConflictPolicies("kube-controller-manager")
+	g.Expect(err).Should(MatchError(ContainSubstring("expected <field manager>=<policy>")))
+
+	_, _, err = ParseConflictPolicies("kube-controller-manager=Ignore")
+	g.Expect(err).Should(MatchError(ContainSubstring(`unsupported conflict policy "Ignore"`)))
+}
+
+func TestApplyReportsForcedConflicts(t *testing.T) {
+	g := NewWithT(t)
+
+	var forced []bool
+
+	cli, err := fakeclient.New(fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+		Apply: func(_ context.Context, _ client.WithWatch, _ runtime.ApplyConfiguration, opts ...client.ApplyOption) error {
+			force := slices.Contains(opts, client.ApplyOption(client.ForceOwnership))
+			forced = append(forced, force)
+
+			if force {
+				return nil
+			}
+
+			return newConflictError(metav1.StatusCause{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "kube-controller-manager" using apps/v1`,
+				Field:   ".spec.replicas",
+			})
+		},
+	}))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// no policy is configured, the conflicting fields are forced but the
+	// conflict is still reported
+	a := Action{}
+	state := deployState{}
+
+	current := newConflictDeployment(
+		metav1.ManagedFieldsEntry{Manager: "platform"},
+		metav1.ManagedFieldsEntry{Manager: "kube-controller-manager"},
+	)
+
+	_, err = a.apply(t.Context(), cli, newConflictDeployment(), current, &state, client.FieldOwner("platform"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	g.Expect(forced).Should(Equal([]bool{false, true}))
+	g.Expect(state.conflicts).Should(Equal([]Conflict{{
+		Object:  "Deployment test-ns/test",
+		Field:   ".spec.replicas",
+		Manager: "kube-controller-manager",
+		Policy:  ConflictPolicyForce,
+	}}))
+}
+
+func TestReportConflicts(t *testing.T) {
+	g := NewWithT(t)
+
+	rr := odhTypes.ReconciliationRequest{Instance: &componentApi.Dashboard{}}
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	a := Action{}
+
+	a.reportConflicts(&rr, "dashboard", nil)
+	g.Expect(rr.Conditions.GetCondition(status.ConditionResourceConflict)).Should(BeNil())
+
+	a.reportConflicts(&rr, "dashboard", []Conflict{{
+		Object:  "Deployment test-ns/test",
+		Field:   ".spec.replicas",
+		Manager: "kube-controller-manager",
+		Policy:  ConflictPolicyForce,
+	}})
+
+	c := rr.Conditions.GetCondition(status.ConditionResourceConflict)
+	g.Expect(c).ShouldNot(BeNil())
+	g.Expect(c.Status).Should(Equal(metav1.ConditionTrue))
+	g.Expect(c.Reason).Should(Equal(status.FieldManagerConflictReason))
+	g.Expect(c.Severity).Should(Equal(common.ConditionSeverityInfo))
+	g.Expect(c.Message).Should(Equal(`Deployment test-ns/test: .spec.replicas managed by "kube-controller-manager" (Force)`))
+
+	g.Expect(rr.Conditions.IsHappy()).Should(BeTrue())
+}
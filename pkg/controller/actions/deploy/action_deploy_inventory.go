@@ -0,0 +1,115 @@
+package deploy
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// inventoryEntry is a resource deployed by the action.
+type inventoryEntry struct {
+	gc.ResourceEntry
+
+	// skipped is set when the resource has not been applied in the current
+	// reconciliation, hence its resourceVersion is not the apply time one.
+	skipped bool
+}
+
+// WithResourceInventory makes the action record the deployed resources, once
+// all of them have been successfully deployed, in the inventory persisted for
+// the GC action (see gc.WithInventory).
+func WithResourceInventory() ActionOpts {
+	return func(action *Action) {
+		action.inventory = true
+	}
+}
+
+// WithResourceInventoryNamespace overrides the namespace of the inventory, it
+// must match the namespace of the GC action (see gc.InNamespace). Defaults to
+// the operator namespace.
+func WithResourceInventoryNamespace(ns string) ActionOpts {
+	return func(action *Action) {
+		action.inventoryNamespace = ns
+	}
+}
+
+// record adds an entry for the given resource to the inventory. The identity is
+// taken from obj, the resourceVersion from deployed if available.
+func (s *deployState) record(obj *unstructured.Unstructured, deployed *unstructured.Unstructured) {
+	if obj == nil {
+		return
+	}
+
+	objGVK := obj.GroupVersionKind()
+
+	e := inventoryEntry{
+		ResourceEntry: gc.ResourceEntry{
+			Group:           objGVK.Group,
+			Version:         objGVK.Version,
+			Kind:            objGVK.Kind,
+			Namespace:       obj.GetNamespace(),
+			Name:            obj.GetName(),
+			ResourceVersion: obj.GetResourceVersion(),
+		},
+		skipped: deployed == nil,
+	}
+
+	if deployed != nil {
+		e.ResourceVersion = deployed.GetResourceVersion()
+	}
+
+	s.inventory = append(s.inventory, e)
+}
+
+// publishInventory records the deployed resources in the inventory of the
+// reconciled instance. Resources that have not been applied in this
+// reconciliation keep the resourceVersion recorded when they were, and the
+// inventory is only updated when it changes.
+func (a *Action) publishInventory(ctx context.Context, rr *odhTypes.ReconciliationRequest, entries []inventoryEntry) error {
+	ns := a.inventoryNamespace
+	if ns == "" {
+		var err error
+		if ns, err = cluster.GetOperatorNamespace(); err != nil {
+			return err
+		}
+	}
+
+	igvk, err := resources.GetGroupVersionKindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return err
+	}
+
+	inv, err := gc.LoadInventory(ctx, rr.Client, ns, strings.ToLower(igvk.Kind))
+	if err != nil {
+		return err
+	}
+	if err := inv.SetOwner(rr.Instance, rr.Client.Scheme()); err != nil {
+		return err
+	}
+
+	deployed := make([]gc.ResourceEntry, 0, len(entries))
+
+	for _, e := range entries {
+		if e.skipped {
+			idx := slices.IndexFunc(inv.Resources, func(p gc.ResourceEntry) bool {
+				return p.Key() == e.Key()
+			})
+			if idx != -1 {
+				e.ResourceVersion = inv.Resources[idx].ResourceVersion
+			}
+		}
+
+		deployed = append(deployed, e.ResourceEntry)
+	}
+
+	inv.SetResources(deployed)
+
+	return inv.Save(ctx, rr.Client)
+}
@@ -0,0 +1,220 @@
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	odhTypes "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/maintenance"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// defaultDisruptiveFields are the fields whose changes restart the pods of a
+// workload, hence are only applied within the maintenance windows.
+var defaultDisruptiveFields = map[schema.GroupKind][]string{
+	gvk.Deployment.GroupKind():  {"spec.template"},
+	gvk.StatefulSet.GroupKind(): {"spec.template"},
+	gvk.DaemonSet.GroupKind():   {"spec.template"},
+}
+
+// maintenanceState tells the deploy action that disruptive changes must be
+// deferred, since the maintenance windows are currently closed.
+type maintenanceState struct {
+	// next is the time the next window opens, zero if unknown.
+	next time.Time
+	// invalid is set when the maintenance policy cannot be evaluated, in which
+	// case disruptive changes are deferred until the policy is fixed.
+	invalid error
+}
+
+// WithDisruptiveFields marks the given fields, expressed as dot separated
+// paths such as "spec.template", of the resources of the given kind as
+// disruptive, in addition to the pod templates of Deployments, StatefulSets
+// and DaemonSets. Changes to disruptive fields are deferred outside of the
+// maintenance windows configured on the DSCInitialization.
+func WithDisruptiveFields(gk schema.GroupKind, fields ...string) ActionOpts {
+	return func(action *Action) {
+		if action.disruptiveFields == nil {
+			action.disruptiveFields = map[schema.GroupKind][]string{}
+		}
+
+		action.disruptiveFields[gk] = append(action.disruptiveFields[gk], fields...)
+	}
+}
+
+// resolveMaintenance returns the maintenance state of the current
+// reconciliation, or nil if disruptive changes can be applied right away.
+func (a *Action) resolveMaintenance(ctx context.Context, rr *odhTypes.ReconciliationRequest) (*maintenanceState, error) {
+	if len(a.disruptiveFields) == 0 {
+		return nil, nil
+	}
+
+	if resources.GetAnnotation(rr.Instance, annotations.MaintenanceOverride) == "true" {
+		return nil, nil
+	}
+
+	dsci := rr.DSCI
+	if dsci == nil {
+		instances := dsciv2.DSCInitializationList{}
+
+		err := rr.Client.List(ctx, &instances)
+		switch {
+		case meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err):
+			return nil, nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to list resources of type %s: %w", gvk.DSCInitialization, err)
+		case len(instances.Items) != 1:
+			return nil, nil
+		}
+
+		dsci = &instances.Items[0]
+	}
+
+	if dsci.Spec.Maintenance == nil || resources.GetAnnotation(dsci, annotations.MaintenanceOverride) == "true" {
+		return nil, nil
+	}
+
+	windows, err := maintenance.NewWindows(dsci.Spec.Maintenance)
+	if err != nil {
+		return &maintenanceState{invalid: err}, nil
+	}
+
+	now := time.Now()
+	if windows.IsOpen(now) {
+		return nil, nil
+	}
+
+	return &maintenanceState{next: windows.NextOpen(now)}, nil
+}
+
+// deferDisruptiveChanges records the hash of the disruptive fields of obj and
+// returns true if, the maintenance windows being closed, those fields changed
+// since they were last applied. In that case obj gets the live values of the
+// disruptive fields and keeps their previous hash, so that its other changes
+// are applied right away while the disruptive ones wait for the next window.
+//
+// Resources deployed before the hash was recorded have nothing to compare
+// with: they are applied as they are, which records the baseline hash.
+func (a *Action) deferDisruptiveChanges(
+	obj *unstructured.Unstructured,
+	current *unstructured.Unstructured,
+	state *deployState,
+) (bool, error) {
+	fields := a.disruptiveFields[obj.GroupVersionKind().GroupKind()]
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	hash, err := disruptiveHash(obj, fields)
+	if err != nil {
+		return false, err
+	}
+
+	resources.SetAnnotation(obj, annotations.DisruptiveConfigHash, hash)
+
+	if state.maintenance == nil || current == nil {
+		return false, nil
+	}
+
+	applied := resources.GetAnnotation(current, annotations.DisruptiveConfigHash)
+	if applied == "" || applied == hash {
+		return false, nil
+	}
+
+	for _, f := range fields {
+		path := strings.Split(f, ".")
+
+		v, found, err := unstructured.NestedFieldCopy(current.Object, path...)
+		if err != nil {
+			return false, fmt.Errorf("failed to read field %s: %w", f, err)
+		}
+
+		if !found {
+			unstructured.RemoveNestedField(obj.Object, path...)
+			continue
+		}
+
+		if err := unstructured.SetNestedField(obj.Object, v, path...); err != nil {
+			return false, fmt.Errorf("failed to set field %s: %w", f, err)
+		}
+	}
+
+	resources.SetAnnotation(obj, annotations.DisruptiveConfigHash, applied)
+
+	state.deferred = append(state.deferred, fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName()))
+
+	return true, nil
+}
+
+func disruptiveHash(obj *unstructured.Unstructured, fields []string) (string, error) {
+	values := make(map[string]any, len(fields))
+
+	for _, f := range fields {
+		v, found, err := unstructured.NestedFieldNoCopy(obj.Object, strings.Split(f, ".")...)
+		if err != nil {
+			return "", fmt.Errorf("failed to read field %s: %w", f, err)
+		}
+
+		if found {
+			values[f] = v
+		}
+	}
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode disruptive fields: %w", err)
+	}
+
+	sum := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+// reportPendingMaintenance sets the PendingMaintenance condition listing the
+// resources whose disruptive changes have been deferred. As for conflicts, the
+// condition is left unset when nothing is pending.
+func (a *Action) reportPendingMaintenance(rr *odhTypes.ReconciliationRequest, state *deployState) {
+	if len(state.deferred) == 0 || rr.Conditions == nil {
+		return
+	}
+
+	if state.maintenance.invalid != nil {
+		rr.Conditions.MarkTrue(
+			status.ConditionPendingMaintenance,
+			conditions.WithReason(status.InvalidMaintenancePolicyReason),
+			conditions.WithSeverity(common.ConditionSeverityInfo),
+			conditions.WithMessage("disruptive changes deferred, invalid maintenance policy: %v; resources: %s",
+				state.maintenance.invalid, strings.Join(state.deferred, ", ")),
+		)
+
+		return
+	}
+
+	until := "the next maintenance window"
+	if !state.maintenance.next.IsZero() {
+		until = state.maintenance.next.Format(time.RFC3339)
+	}
+
+	rr.Conditions.MarkTrue(
+		status.ConditionPendingMaintenance,
+		conditions.WithReason(status.OutsideMaintenanceWindowReason),
+		conditions.WithSeverity(common.ConditionSeverityInfo),
+		conditions.WithMessage("disruptive changes deferred until %s: %s", until, strings.Join(state.deferred, ", ")),
+	)
+}
@@ -0,0 +1,264 @@
+package deploy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/mock"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	odherrors "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/errors"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/mocks"
+
+	. "github.com/onsi/gomega"
+)
+
+func newMaintenanceResources(t *testing.T, ns string, image string) []unstructured.Unstructured {
+	t.Helper()
+
+	d, err := resources.ToUnstructured(&appsv1.Deployment{
+		TypeMeta:   metav1.TypeMeta{APIVersion: appsv1.SchemeGroupVersion.String(), Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{Name: "workload", Namespace: ns, Labels: map[string]string{"image": image}},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "main", Image: image}}},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cm, err := resources.ToUnstructured(&corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: ns},
+		Data:       map[string]string{"image": image},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return []unstructured.Unstructured{*d, *cm}
+}
+
+func TestDeployMaintenanceWindow(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	dsci := &dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+		Spec: dsciv2.DSCInitializationSpec{
+			Maintenance: &dsciv2.MaintenancePolicy{
+				// only open on February 29th at midnight, so closed while testing
+				Windows: []dsciv2.MaintenanceWindow{{
+					Schedule: "0 0 29 2 *",
+					Duration: metav1.Duration{Duration: time.Minute},
+				}},
+			},
+		},
+	}
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(dsci))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	action := deploy.NewAction(
+		deploy.WithMode(deploy.ModePatch),
+	)
+
+	reconcile := func(image string) (*types.ReconciliationRequest, error) {
+		rr := types.ReconciliationRequest{
+			Client:    cl,
+			Instance:  &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+			Resources: newMaintenanceResources(t, ns, image),
+			Controller: mocks.NewMockController(func(m *mocks.MockController) {
+				m.On("Owns", mock.Anything).Return(false)
+			}),
+		}
+		rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+		return &rr, action(ctx, &rr)
+	}
+
+	deployment := resources.GvkToUnstructured(appsv1.SchemeGroupVersion.WithKind("Deployment"))
+	deployment.SetNamespace(ns)
+	deployment.SetName("workload")
+
+	configMap := resources.GvkToUnstructured(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+	configMap.SetNamespace(ns)
+	configMap.SetName("config")
+
+	t.Run("new resources are created outside the window", func(t *testing.T) {
+		g := NewWithT(t)
+
+		rr, err := reconcile("image:v1")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(rr.Conditions.GetCondition(status.ConditionPendingMaintenance)).Should(BeNil())
+
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+		g.Expect(deployment).Should(And(
+			jq.Match(`.spec.template.spec.containers[0].image == "image:v1"`),
+			jq.Match(`.metadata.annotations."%s" != null`, annotations.DisruptiveConfigHash),
+		))
+	})
+
+	t.Run("disruptive changes are deferred outside the window", func(t *testing.T) {
+		g := NewWithT(t)
+
+		hashed := deployment.DeepCopy()
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(hashed), hashed)).Should(Succeed())
+
+		rr, err := reconcile("image:v2")
+
+		requeue := odherrors.RequeueAfterError{}
+		g.Expect(errors.As(err, &requeue)).Should(BeTrue())
+		g.Expect(requeue.After).Should(BeNumerically(">", 0))
+
+		c := rr.Conditions.GetCondition(status.ConditionPendingMaintenance)
+		g.Expect(c).ShouldNot(BeNil())
+		g.Expect(c.Status).Should(Equal(metav1.ConditionTrue))
+		g.Expect(c.Reason).Should(Equal(status.OutsideMaintenanceWindowReason))
+		g.Expect(c.Message).Should(ContainSubstring("Deployment %s/workload", ns))
+		g.Expect(rr.Conditions.IsHappy()).Should(BeTrue())
+
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+		// only the pod template is deferred, the other changes are applied
+		g.Expect(deployment).Should(And(
+			jq.Match(`.spec.template.spec.containers[0].image == "image:v1"`),
+			jq.Match(`.metadata.labels.image == "image:v2"`),
+			jq.Match(`.metadata.annotations."%s" == "%s"`,
+				annotations.DisruptiveConfigHash, resources.GetAnnotation(hashed, annotations.DisruptiveConfigHash)),
+		))
+
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(configMap), configMap)).Should(Succeed())
+		g.Expect(configMap).Should(jq.Match(`.data.image == "image:v2"`))
+	})
+
+	t.Run("the override annotation forces disruptive changes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(dsci), dsci)).Should(Succeed())
+		resources.SetAnnotation(dsci, annotations.MaintenanceOverride, "true")
+		g.Expect(cl.Update(ctx, dsci)).Should(Succeed())
+
+		rr, err := reconcile("image:v2")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(rr.Conditions.GetCondition(status.ConditionPendingMaintenance)).Should(BeNil())
+
+		g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+		g.Expect(deployment).Should(jq.Match(`.spec.template.spec.containers[0].image == "image:v2"`))
+	})
+}
+
+func TestDeployMaintenanceWindowInvalidPolicy(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	dsci := &dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+		Spec: dsciv2.DSCInitializationSpec{
+			Maintenance: &dsciv2.MaintenancePolicy{
+				Windows: []dsciv2.MaintenanceWindow{{
+					Schedule: "every saturday",
+					Duration: metav1.Duration{Duration: time.Hour},
+				}},
+			},
+		},
+	}
+
+	existing := newMaintenanceResources(t, ns, "image:v1")[0]
+	resources.SetAnnotation(&existing, annotations.DisruptiveConfigHash, "previous")
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(dsci, &existing))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:    cl,
+		Instance:  &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+		Resources: newMaintenanceResources(t, ns, "image:v2"),
+		Controller: mocks.NewMockController(func(m *mocks.MockController) {
+			m.On("Owns", mock.Anything).Return(false)
+		}),
+	}
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	err = deploy.NewAction(deploy.WithMode(deploy.ModePatch))(ctx, &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	c := rr.Conditions.GetCondition(status.ConditionPendingMaintenance)
+	g.Expect(c).ShouldNot(BeNil())
+	g.Expect(c.Reason).Should(Equal(status.InvalidMaintenancePolicyReason))
+
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(&existing), &existing)).Should(Succeed())
+	g.Expect(&existing).Should(And(
+		jq.Match(`.spec.template.spec.containers[0].image == "image:v1"`),
+		jq.Match(`.metadata.labels.image == "image:v2"`),
+		jq.Match(`.metadata.annotations."%s" == "previous"`, annotations.DisruptiveConfigHash),
+	))
+}
+
+func TestDeployMaintenanceWindowWithoutBaseline(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	dsci := &dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+		Spec: dsciv2.DSCInitializationSpec{
+			Maintenance: &dsciv2.MaintenancePolicy{
+				Windows: []dsciv2.MaintenanceWindow{{
+					Schedule: "0 0 29 2 *",
+					Duration: metav1.Duration{Duration: time.Minute},
+				}},
+			},
+		},
+	}
+
+	// deployed by a release which did not record the hash of the disruptive fields
+	existing := newMaintenanceResources(t, ns, "image:v1")[0]
+
+	cl, err := fakeclient.New(fakeclient.WithObjects(dsci, &existing))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client:    cl,
+		Instance:  &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{Generation: 1}},
+		Resources: newMaintenanceResources(t, ns, "image:v2"),
+		Controller: mocks.NewMockController(func(m *mocks.MockController) {
+			m.On("Owns", mock.Anything).Return(false)
+		}),
+	}
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	err = deploy.NewAction(deploy.WithMode(deploy.ModePatch))(ctx, &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(rr.Conditions.GetCondition(status.ConditionPendingMaintenance)).Should(BeNil())
+
+	// nothing to compare with, the resource is applied and the hash recorded
+	// as the baseline
+	g.Expect(cl.Get(ctx, client.ObjectKeyFromObject(&existing), &existing)).Should(Succeed())
+	g.Expect(&existing).Should(And(
+		jq.Match(`.spec.template.spec.containers[0].image == "image:v2"`),
+		jq.Match(`.metadata.annotations."%s" != null`, annotations.DisruptiveConfigHash),
+	))
+}
@@ -18,6 +18,23 @@ var (
 			"controller",
 		},
 	)
+
+	// DeployConflictsTotal is a prometheus counter metrics which holds the total
+	// number of server-side apply conflicts detected by the action. It has three
+	// labels. controller label refers to the controller name, manager label to
+	// the competing field manager and policy label to the policy applied to
+	// resolve the conflict.
+	DeployConflictsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "action_deploy_conflicts_total",
+			Help: "Number of server-side apply conflicts with other field managers",
+		},
+		[]string{
+			"controller",
+			"manager",
+			"policy",
+		},
+	)
 )
 
 // init register metrics to the global registry from controller-runtime/pkg/metrics.
@@ -25,5 +42,5 @@ var (
 //
 //nolint:gochecknoinits
 func init() {
-	metrics.Registry.MustRegister(DeployedResourcesTotal)
+	metrics.Registry.MustRegister(DeployedResourcesTotal, DeployConflictsTotal)
 }
@@ -36,6 +36,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/deploy"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
@@ -110,6 +111,89 @@ func TestDeployAction(t *testing.T) {
 	))
 }
 
+func TestDeployResourceInventory(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	action := deploy.NewAction(
+		deploy.WithMode(deploy.ModePatch),
+		deploy.WithResourceInventory(),
+		deploy.WithResourceInventoryNamespace(ns),
+	)
+
+	obj1, err := resources.ToUnstructured(&appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dashboard",
+			Namespace: ns,
+		},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	obj2, err := resources.ToUnstructured(&rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: rbacv1.SchemeGroupVersion.String(),
+			Kind:       "ClusterRole",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "dashboard",
+		},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	rr := types.ReconciliationRequest{
+		Client: cl,
+		Instance: &componentApi.Dashboard{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       componentApi.DashboardInstanceName,
+				UID:        apimachinery.UID(xid.New().String()),
+				Generation: 1,
+			},
+		},
+		Release: common.Release{
+			Name: cluster.OpenDataHub,
+			Version: version.OperatorVersion{Version: semver.Version{
+				Major: 1, Minor: 2, Patch: 3,
+			}}},
+		Resources: []unstructured.Unstructured{*obj1, *obj2},
+		Controller: mocks.NewMockController(func(m *mocks.MockController) {
+			m.On("Owns", mock.Anything).Return(false)
+		}),
+	}
+
+	err = action(ctx, &rr)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	err = cl.Get(ctx, client.ObjectKeyFromObject(obj1), obj1)
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// the resources are recorded in the kinds inventory of the GC action
+	inv, err := gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.Resources).Should(HaveExactElements(
+		And(
+			HaveField("Group", "apps"),
+			HaveField("Kind", "Deployment"),
+			HaveField("Namespace", ns),
+			HaveField("Name", "dashboard"),
+			HaveField("ResourceVersion", obj1.GetResourceVersion()),
+		),
+		And(
+			HaveField("Group", "rbac.authorization.k8s.io"),
+			HaveField("Kind", "ClusterRole"),
+			HaveField("Namespace", ""),
+		),
+	))
+}
+
 func TestDeployNotOwnedSkip(t *testing.T) {
 	g := NewWithT(t)
 
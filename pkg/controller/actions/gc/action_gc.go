@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
@@ -25,11 +26,14 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/rules"
 )
 
-// DefaultUnremovables lists GVKs that GC never deletes by default.
+// DefaultUnremovables lists GVKs that GC never deletes by default, unless a
+// controller opts in with WithRemovables: deleting a CRD deletes every custom
+// resource of its kind, and deleting a Namespace every resource it contains.
 // Consumers outside the gc package (e.g. cleanup actions) can reference
 // this slice to apply the same rules.
 var DefaultUnremovables = []schema.GroupVersionKind{
 	gvk.CustomResourceDefinition,
+	gvk.Namespace,
 	gvk.Lease,
 }
 
@@ -47,6 +51,7 @@ type Action struct {
 	typePredicateFn   TypePredicateFn
 	onlyOwned         bool
 	namespaceFn       actions.Getter[string]
+	inventory         bool
 }
 
 func WithLabel(name string, value string) ActionOpts {
@@ -85,6 +90,16 @@ func WithUnremovables(items ...schema.GroupVersionKind) ActionOpts {
 	}
 }
 
+// WithRemovables lets GC delete the given GVKs, even if they are listed in
+// DefaultUnremovables.
+func WithRemovables(items ...schema.GroupVersionKind) ActionOpts {
+	return func(action *Action) {
+		for _, item := range items {
+			delete(action.unremovables, item)
+		}
+	}
+}
+
 func WithObjectPredicate(value ObjectPredicateFn) ActionOpts {
 	return func(action *Action) {
 		if value == nil {
@@ -127,6 +142,20 @@ func InNamespaceFn(fn actions.Getter[string]) ActionOpts {
 		action.namespaceFn = fn
 	}
 }
+
+// WithInventory enables the persisted inventory of the kinds deployed by the
+// controller. Kinds recorded in the inventory but no longer rendered are
+// still collected, with the part-of label they were deployed with, so
+// resources of kinds dropped by a new release do not need dedicated cleanup
+// code. They are subject to the unremovables and to the type predicate as any
+// other kind. Kinds are removed from the inventory once no resources are left
+// to collect.
+func WithInventory() ActionOpts {
+	return func(action *Action) {
+		action.inventory = true
+	}
+}
+
 func WithDeletePropagationPolicy(policy metav1.DeletionPropagation) ActionOpts {
 	return func(action *Action) {
 		action.propagationPolicy = client.PropagationPolicy(policy)
@@ -168,22 +197,85 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 
 	l.V(3).Info("run", "selector", lo.LabelSelector)
 
+	var inv *Inventory
+	var rendered map[schema.GroupKind]struct{}
+
+	if a.inventory {
+		ns, err := a.namespaceFn(ctx, rr)
+		if err != nil {
+			return fmt.Errorf("unable to compute namespace: %w", err)
+		}
+
+		inv, err = LoadInventory(ctx, rr.Client, ns, controllerName)
+		if err != nil {
+			return err
+		}
+		if err := inv.SetOwner(rr.Instance, rr.Client.Scheme()); err != nil {
+			return err
+		}
+
+		rendered = inv.record(rr.Resources, a.partOfLabelKey, controllerName)
+	}
+
+	served := make(map[schema.GroupKind]struct{}, len(items))
+
+	// the downgrade is over once the running release is recorded as the
+	// deployed one, it is checked on each run
+	_, downgrade, err := cluster.GetDowngradeRelease(ctx, rr.Client, rr.Release)
+	if err != nil {
+		return fmt.Errorf("unable to detect a release downgrade: %w", err)
+	}
+
 	for _, res := range items {
-		canBeDeleted, err := a.isTypeDeletable(rr, res.GroupVersionKind())
+		resGVK := res.GroupVersionKind()
+		served[resGVK.GroupKind()] = struct{}{}
+
+		// kinds deployed in the past but not rendered anymore
+		var removed *InventoryEntry
+		if inv != nil {
+			if e, ok := inv.Get(resGVK.GroupKind()); ok {
+				if _, ok := rendered[resGVK.GroupKind()]; !ok {
+					removed = &e
+				}
+			}
+		}
+
+		// in release-downgrade mode the kinds the running release does not
+		// render may have been introduced by the newer one, they are left
+		// alone until the downgrade is over.
+		if removed != nil && downgrade {
+			l.V(3).Info("release downgrade, skipping kind", "gvk", resGVK)
+			continue
+		}
+
+		canBeDeleted, err := a.isTypeDeletable(rr, resGVK)
 		if err != nil {
 			return fmt.Errorf("cannot determine if resource %s can be deleted: %w", res.String(), err)
 		}
 
 		if !canBeDeleted {
+			// the kind is not collected by this controller, there is nothing
+			// to track anymore
+			if removed != nil {
+				inv.Remove(resGVK.GroupKind())
+			}
+
 			continue
 		}
 
-		items, err := a.listResources(ctx, rr.Controller.GetDynamicClient(), res, lo)
+		opts := lo
+		if removed != nil {
+			opts = metav1.ListOptions{
+				LabelSelector: a.getOrComputeSelector(removed.PartOf).String(),
+			}
+		}
+
+		items, err := a.listResources(ctx, rr.Controller.GetDynamicClient(), res, opts)
 		if err != nil {
 			return fmt.Errorf("cannot list child resources %s: %w", res.String(), err)
 		}
 
-		deleted, err := a.deleteResources(ctx, rr, igvk, items)
+		deleted, err := a.deleteResources(ctx, rr, igvk, items, downgrade)
 		if err != nil {
 			return fmt.Errorf("error processing items to delete: %w", err)
 		}
@@ -191,9 +283,31 @@ func (a *Action) run(ctx context.Context, rr *odhTypes.ReconciliationRequest) er
 		if deleted > 0 {
 			DeletedTotal.WithLabelValues(controllerName).Add(float64(deleted))
 		}
+
+		// nothing left to collect, forget about the kind
+		if removed != nil && deleted == 0 && !hasTerminating(items) {
+			inv.Remove(resGVK.GroupKind())
+		}
 	}
 
-	return nil
+	if inv == nil {
+		return nil
+	}
+
+	// kinds not served anymore by the cluster cannot have any resource left
+	for _, e := range slices.Clone(inv.Kinds) {
+		if _, ok := served[e.GroupKind()]; !ok {
+			inv.Remove(e.GroupKind())
+		}
+	}
+
+	return inv.Save(ctx, rr.Client)
+}
+
+func hasTerminating(items []unstructured.Unstructured) bool {
+	return slices.ContainsFunc(items, func(item unstructured.Unstructured) bool {
+		return !item.GetDeletionTimestamp().IsZero()
+	})
 }
 
 func (a *Action) computeDeletableTypes(ctx context.Context, rr *odhTypes.ReconciliationRequest) ([]resources.Resource, error) {
@@ -253,6 +367,7 @@ func (a *Action) isObjectDeletable(
 	rr *odhTypes.ReconciliationRequest,
 	igvk schema.GroupVersionKind,
 	obj unstructured.Unstructured,
+	downgrade bool,
 ) (bool, error) {
 	if a.isUnremovable(obj.GroupVersionKind()) {
 		return false, nil
@@ -260,6 +375,9 @@ func (a *Action) isObjectDeletable(
 	if resources.HasAnnotation(&obj, annotations.ManagedByODHOperator, "false") {
 		return false, nil
 	}
+	if downgrade && IsFromNewerRelease(rr, obj) {
+		return false, nil
+	}
 
 	if a.onlyOwned {
 		o, err := resources.IsOwnedByType(&obj, igvk)
@@ -279,11 +397,12 @@ func (a *Action) deleteResources(
 	rr *odhTypes.ReconciliationRequest,
 	igvk schema.GroupVersionKind,
 	items []unstructured.Unstructured,
+	downgrade bool,
 ) (int, error) {
 	deleted := 0
 
 	for i := range items {
-		canBeDeleted, err := a.isObjectDeletable(rr, igvk, items[i])
+		canBeDeleted, err := a.isObjectDeletable(rr, igvk, items[i], downgrade)
 		if err != nil {
 			return 0, fmt.Errorf("cannot determine if object %s in namespace %q can be deleted: %w",
 				items[i].GetName(),
@@ -0,0 +1,316 @@
+package gc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	odhLabels "github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+const (
+	inventoryNameSuffix = "-gvk-inventory"
+	inventoryDataKey    = "inventory.json"
+)
+
+// InventoryEntry is a resource kind that has been deployed by a controller,
+// along with the value of the part-of label set on its resources.
+type InventoryEntry struct {
+	Group   string `json:"group"`
+	Version string `json:"version"`
+	Kind    string `json:"kind"`
+	PartOf  string `json:"partOf"`
+}
+
+func (e InventoryEntry) GroupKind() schema.GroupKind {
+	return schema.GroupKind{Group: e.Group, Kind: e.Kind}
+}
+
+// ResourceEntry identifies a resource deployed by a controller, along with its
+// resourceVersion at apply time.
+type ResourceEntry struct {
+	Group           string `json:"group,omitempty"`
+	Version         string `json:"version"`
+	Kind            string `json:"kind"`
+	Namespace       string `json:"namespace,omitempty"`
+	Name            string `json:"name"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// Key identifies the resource regardless of its version.
+func (e ResourceEntry) Key() string {
+	return strings.Join([]string{e.Group, e.Kind, e.Namespace, e.Name}, "/")
+}
+
+// Inventory is the set of resource kinds ever deployed by a controller. It is
+// persisted in a ConfigMap so that, once a release stops rendering a kind, the
+// GC action still knows the kind has to be collected.
+//
+// The same ConfigMap publishes the resources deployed in the last
+// reconciliation when the deploy action is configured to record them, so
+// consumers do not need to scan the cluster to find what the operator deployed.
+// Kinds are written by the GC action and resources by the deploy action, Save
+// only overwrites the part that has been changed.
+type Inventory struct {
+	Kinds     []InventoryEntry `json:"kinds"`
+	Resources []ResourceEntry  `json:"resources,omitempty"`
+
+	cm               *corev1.ConfigMap
+	owner            *metav1.OwnerReference
+	kindsChanged     bool
+	resourcesChanged bool
+}
+
+// InventoryName returns the name of the ConfigMap holding the inventory of the
+// given controller.
+func InventoryName(controllerName string) string {
+	return controllerName + inventoryNameSuffix
+}
+
+// LoadInventory reads the inventory of the given controller, an empty inventory
+// is returned if it has not been persisted yet.
+func LoadInventory(ctx context.Context, cli client.Client, ns string, controllerName string) (*Inventory, error) {
+	inv := Inventory{}
+
+	if err := inv.load(ctx, cli, ns, controllerName); err != nil {
+		return nil, err
+	}
+
+	return &inv, nil
+}
+
+func (inv *Inventory) load(ctx context.Context, cli client.Client, ns string, controllerName string) error {
+	cm := corev1.ConfigMap{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: ns, Name: InventoryName(controllerName)}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		cm.Name = InventoryName(controllerName)
+		cm.Namespace = ns
+		cm.Labels = map[string]string{odhLabels.GVKInventory: controllerName}
+	case err != nil:
+		return fmt.Errorf("failed to get inventory %s/%s: %w", ns, InventoryName(controllerName), err)
+	}
+
+	loaded := Inventory{}
+
+	if data := cm.Data[inventoryDataKey]; data != "" {
+		if err := json.Unmarshal([]byte(data), &loaded); err != nil {
+			return fmt.Errorf("failed to decode inventory %s/%s: %w", ns, cm.Name, err)
+		}
+	}
+
+	if !inv.kindsChanged {
+		inv.Kinds = loaded.Kinds
+	}
+	if !inv.resourcesChanged {
+		inv.Resources = loaded.Resources
+	}
+
+	inv.cm = &cm
+
+	return nil
+}
+
+// SetOwner sets the instance reconciled by the controller as owner of the
+// inventory, so that the inventory is deleted along with the instance. The
+// reference is not a controller one, updating the inventory does not trigger
+// a reconciliation of the instance.
+func (inv *Inventory) SetOwner(owner client.Object, scheme *runtime.Scheme) error {
+	// instances which have not been persisted cannot be referenced
+	if owner.GetUID() == "" {
+		return nil
+	}
+
+	ogvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return fmt.Errorf("failed to get the GVK of the inventory owner: %w", err)
+	}
+
+	inv.owner = &metav1.OwnerReference{
+		APIVersion: ogvk.GroupVersion().String(),
+		Kind:       ogvk.Kind,
+		Name:       owner.GetName(),
+		UID:        owner.GetUID(),
+	}
+
+	return nil
+}
+
+// ownerChanged reports whether the owner reference of the inventory has to be
+// set on the ConfigMap.
+func (inv *Inventory) ownerChanged() bool {
+	if inv.owner == nil {
+		return false
+	}
+
+	return !slices.ContainsFunc(inv.cm.OwnerReferences, func(r metav1.OwnerReference) bool {
+		return r.UID == inv.owner.UID
+	})
+}
+
+// Save persists the inventory if it has been changed since it was loaded. On
+// conflicts, the inventory is read again and only the changed part is
+// overwritten.
+func (inv *Inventory) Save(ctx context.Context, cli client.Client) error {
+	if !inv.kindsChanged && !inv.resourcesChanged && !inv.ownerChanged() {
+		return nil
+	}
+
+	controllerName := inv.cm.Labels[odhLabels.GVKInventory]
+
+	retriable := func(err error) bool {
+		return k8serr.IsConflict(err) || k8serr.IsAlreadyExists(err)
+	}
+
+	attempt := 0
+
+	err := retry.OnError(retry.DefaultBackoff, retriable, func() error {
+		attempt++
+		if attempt > 1 {
+			if err := inv.load(ctx, cli, inv.cm.Namespace, controllerName); err != nil {
+				return err
+			}
+		}
+
+		return inv.write(ctx, cli)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save inventory %s/%s: %w", inv.cm.Namespace, inv.cm.Name, err)
+	}
+
+	inv.kindsChanged = false
+	inv.resourcesChanged = false
+
+	return nil
+}
+
+func (inv *Inventory) write(ctx context.Context, cli client.Client) error {
+	slices.SortFunc(inv.Kinds, func(a, b InventoryEntry) int {
+		return strings.Compare(a.GroupKind().String(), b.GroupKind().String())
+	})
+
+	data, err := json.Marshal(inv)
+	if err != nil {
+		return fmt.Errorf("failed to encode inventory: %w", err)
+	}
+
+	if inv.cm.Data == nil {
+		inv.cm.Data = map[string]string{}
+	}
+
+	inv.cm.Data[inventoryDataKey] = string(data)
+
+	if inv.ownerChanged() {
+		// a recreated instance replaces the reference to the previous one
+		inv.cm.OwnerReferences = slices.DeleteFunc(inv.cm.OwnerReferences, func(r metav1.OwnerReference) bool {
+			return r.APIVersion == inv.owner.APIVersion && r.Kind == inv.owner.Kind && r.Name == inv.owner.Name
+		})
+		inv.cm.OwnerReferences = append(inv.cm.OwnerReferences, *inv.owner)
+	}
+
+	if inv.cm.ResourceVersion == "" {
+		return cli.Create(ctx, inv.cm)
+	}
+
+	return cli.Update(ctx, inv.cm)
+}
+
+// SetResources replaces the resources recorded in the inventory.
+func (inv *Inventory) SetResources(entries []ResourceEntry) {
+	entries = slices.Clone(entries)
+	slices.SortFunc(entries, func(a, b ResourceEntry) int {
+		return strings.Compare(a.Key(), b.Key())
+	})
+
+	if slices.Equal(inv.Resources, entries) {
+		return
+	}
+
+	inv.Resources = entries
+	inv.resourcesChanged = true
+}
+
+// Get returns the entry matching the given kind, regardless of its version.
+func (inv *Inventory) Get(gk schema.GroupKind) (InventoryEntry, bool) {
+	idx := slices.IndexFunc(inv.Kinds, func(e InventoryEntry) bool {
+		return e.GroupKind() == gk
+	})
+	if idx == -1 {
+		return InventoryEntry{}, false
+	}
+
+	return inv.Kinds[idx], true
+}
+
+// Add records the given kind, updating the version and part-of label of an
+// existing entry if needed.
+func (inv *Inventory) Add(entry InventoryEntry) {
+	idx := slices.IndexFunc(inv.Kinds, func(e InventoryEntry) bool {
+		return e.GroupKind() == entry.GroupKind()
+	})
+
+	switch {
+	case idx == -1:
+		inv.Kinds = append(inv.Kinds, entry)
+		inv.kindsChanged = true
+	case inv.Kinds[idx] != entry:
+		inv.Kinds[idx] = entry
+		inv.kindsChanged = true
+	}
+}
+
+// Remove drops the given kind from the inventory.
+func (inv *Inventory) Remove(gk schema.GroupKind) {
+	n := len(inv.Kinds)
+
+	inv.Kinds = slices.DeleteFunc(inv.Kinds, func(e InventoryEntry) bool {
+		return e.GroupKind() == gk
+	})
+
+	if len(inv.Kinds) != n {
+		inv.kindsChanged = true
+	}
+}
+
+// record adds to the inventory the kinds of the given resources and returns
+// the set of rendered kinds.
+func (inv *Inventory) record(items []unstructured.Unstructured, partOfLabelKey string, controllerName string) map[schema.GroupKind]struct{} {
+	rendered := make(map[schema.GroupKind]struct{}, len(items))
+
+	for i := range items {
+		objGVK := items[i].GroupVersionKind()
+		if _, ok := rendered[objGVK.GroupKind()]; ok {
+			continue
+		}
+
+		partOf := resources.GetLabel(&items[i], partOfLabelKey)
+		if partOf == "" {
+			partOf = controllerName
+		}
+
+		inv.Add(InventoryEntry{
+			Group:   objGVK.Group,
+			Version: objGVK.Version,
+			Kind:    objGVK.Kind,
+			PartOf:  partOf,
+		})
+
+		rendered[objGVK.GroupKind()] = struct{}{}
+	}
+
+	return rendered
+}
@@ -0,0 +1,142 @@
+package gc_test
+
+import (
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	apimachinery "k8s.io/apimachinery/pkg/types"
+	ctrlCli "sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestInventory(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	inv, err := gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.Kinds).Should(BeEmpty())
+
+	// nothing changed, nothing persisted
+	g.Expect(inv.Save(ctx, cl)).Should(Succeed())
+
+	cm := corev1.ConfigMap{}
+	err = cl.Get(ctx, ctrlCli.ObjectKey{Namespace: ns, Name: gc.InventoryName("dashboard")}, &cm)
+	g.Expect(err).Should(HaveOccurred())
+
+	inv.Add(gc.InventoryEntry{Group: "apps", Version: "v1", Kind: "Deployment", PartOf: "dashboard"})
+	inv.Add(gc.InventoryEntry{Group: "", Version: "v1", Kind: "ConfigMap", PartOf: "dashboard"})
+	g.Expect(inv.Save(ctx, cl)).Should(Succeed())
+
+	err = cl.Get(ctx, ctrlCli.ObjectKey{Namespace: ns, Name: gc.InventoryName("dashboard")}, &cm)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cm.Labels).Should(HaveKeyWithValue(labels.GVKInventory, "dashboard"))
+	g.Expect(cm.Data["inventory.json"]).Should(And(
+		jq.Match(`.kinds | length == 2`),
+		jq.Match(`.kinds[0].kind == "ConfigMap"`),
+		jq.Match(`.kinds[1].kind == "Deployment"`),
+	))
+
+	inv, err = gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.Kinds).Should(HaveLen(2))
+
+	e, ok := inv.Get(schema.GroupKind{Group: "apps", Kind: "Deployment"})
+	g.Expect(ok).Should(BeTrue())
+	g.Expect(e.PartOf).Should(Equal("dashboard"))
+
+	// versions are not relevant to identify kinds
+	inv.Add(gc.InventoryEntry{Group: "apps", Version: "v2", Kind: "Deployment", PartOf: "dashboard"})
+	inv.Remove(schema.GroupKind{Kind: "ConfigMap"})
+	g.Expect(inv.Save(ctx, cl)).Should(Succeed())
+
+	inv, err = gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.Kinds).Should(Equal([]gc.InventoryEntry{
+		{Group: "apps", Version: "v2", Kind: "Deployment", PartOf: "dashboard"},
+	}))
+}
+
+func TestInventoryOwner(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	owner := &componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{
+		Name: componentApi.DashboardInstanceName,
+		UID:  apimachinery.UID(xid.New().String()),
+	}}
+
+	inv, err := gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.SetOwner(owner, cl.Scheme())).Should(Succeed())
+
+	// the owner alone is persisted
+	g.Expect(inv.Save(ctx, cl)).Should(Succeed())
+
+	cm := corev1.ConfigMap{}
+	err = cl.Get(ctx, ctrlCli.ObjectKey{Namespace: ns, Name: gc.InventoryName("dashboard")}, &cm)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cm.OwnerReferences).Should(HaveExactElements(And(
+		HaveField("Kind", "Dashboard"),
+		HaveField("Name", componentApi.DashboardInstanceName),
+		HaveField("UID", owner.UID),
+		HaveField("Controller", BeNil()),
+	)))
+
+	// a recreated instance replaces the previous owner
+	owner.UID = apimachinery.UID(xid.New().String())
+
+	inv, err = gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.SetOwner(owner, cl.Scheme())).Should(Succeed())
+	g.Expect(inv.Save(ctx, cl)).Should(Succeed())
+
+	err = cl.Get(ctx, ctrlCli.ObjectKey{Namespace: ns, Name: gc.InventoryName("dashboard")}, &cm)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(cm.OwnerReferences).Should(HaveExactElements(HaveField("UID", owner.UID)))
+}
+
+func TestInventoryConcurrentWriters(t *testing.T) {
+	g := NewWithT(t)
+	ctx := t.Context()
+	ns := xid.New().String()
+
+	cl, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	kinds, err := gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	res, err := gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	res.SetResources([]gc.ResourceEntry{{Group: "apps", Version: "v1", Kind: "Deployment", Namespace: ns, Name: "dashboard"}})
+	g.Expect(res.Save(ctx, cl)).Should(Succeed())
+
+	// the kinds are saved on top of the resources written in the meantime
+	kinds.Add(gc.InventoryEntry{Group: "apps", Version: "v1", Kind: "Deployment", PartOf: "dashboard"})
+	g.Expect(kinds.Save(ctx, cl)).Should(Succeed())
+
+	inv, err := gc.LoadInventory(ctx, cl, ns, "dashboard")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(inv.Kinds).Should(HaveLen(1))
+	g.Expect(inv.Resources).Should(HaveExactElements(HaveField("Name", "dashboard")))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/blang/semver/v4"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -46,6 +47,17 @@ func DefaultObjectPredicate(rr *odhTypes.ReconciliationRequest, obj unstructured
 	return rr.Instance.GetGeneration() != int64(g), nil
 }
 
+// IsFromNewerRelease reports whether obj has been deployed by a release newer
+// than the one being reconciled, according to its platform version annotation.
+func IsFromNewerRelease(rr *odhTypes.ReconciliationRequest, obj unstructured.Unstructured) bool {
+	pv, err := semver.ParseTolerant(resources.GetAnnotation(&obj, odhAnnotations.PlatformVersion))
+	if err != nil {
+		return false
+	}
+
+	return pv.GT(rr.Release.Version.Version)
+}
+
 func DefaultTypePredicate(_ *odhTypes.ReconciliationRequest, _ schema.GroupVersionKind) (bool, error) {
 	return true, nil
 }
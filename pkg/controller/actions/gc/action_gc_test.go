@@ -22,6 +22,7 @@ import (
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrlCli "sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/gc"
@@ -78,6 +80,8 @@ func TestGcAction(t *testing.T) {
 		annotations    map[string]string
 		options        []gc.ActionOpts
 		uidFn          func(request *types.ReconciliationRequest) string
+		downgrade      bool
+		inventory      bool
 	}{
 		{
 			name:           "should delete leftovers",
@@ -144,6 +148,48 @@ func TestGcAction(t *testing.T) {
 			)},
 			uidFn: func(rr *types.ReconciliationRequest) string { return string(rr.Instance.GetUID()) },
 		},
+		{
+			name:           "should not delete resources of a newer release in downgrade mode",
+			version:        semver.Version{Major: 0, Minor: 0, Patch: 1},
+			generated:      true,
+			matcher:        Not(HaveOccurred()),
+			metricsMatcher: BeNumerically("==", 1),
+			uidFn:          func(rr *types.ReconciliationRequest) string { return string(rr.Instance.GetUID()) },
+			downgrade:      true,
+		},
+		{
+			name:           "should delete leftovers of a kind not rendered anymore",
+			version:        semver.Version{Major: 0, Minor: 0, Patch: 1},
+			generated:      true,
+			matcher:        Satisfy(k8serr.IsNotFound),
+			metricsMatcher: BeNumerically("==", 1),
+			uidFn:          func(rr *types.ReconciliationRequest) string { return string(rr.Instance.GetUID()) },
+			inventory:      true,
+		},
+		{
+			name:           "should not delete leftovers of a kind not rendered anymore because of unremovable type",
+			version:        semver.Version{Major: 0, Minor: 0, Patch: 1},
+			generated:      true,
+			matcher:        Not(HaveOccurred()),
+			metricsMatcher: BeNumerically("==", 1),
+			options:        []gc.ActionOpts{gc.WithUnremovables(gvk.ConfigMap)},
+			uidFn:          func(rr *types.ReconciliationRequest) string { return string(rr.Instance.GetUID()) },
+			inventory:      true,
+		},
+		{
+			name:           "should not delete leftovers of a kind not rendered anymore because of type predicate",
+			version:        semver.Version{Major: 0, Minor: 0, Patch: 1},
+			generated:      true,
+			matcher:        Not(HaveOccurred()),
+			metricsMatcher: BeNumerically("==", 1),
+			options: []gc.ActionOpts{gc.WithTypePredicate(
+				func(_ *types.ReconciliationRequest, objGVK schema.GroupVersionKind) (bool, error) {
+					return objGVK != gvk.ConfigMap, nil
+				},
+			)},
+			uidFn:     func(rr *types.ReconciliationRequest) string { return string(rr.Instance.GetUID()) },
+			inventory: true,
+		},
 		{
 			name:           "should delete leftovers because of UID",
 			version:        semver.Version{Major: 0, Minor: 1, Patch: 0},
@@ -171,6 +217,33 @@ func TestGcAction(t *testing.T) {
 			id := xid.New().String()
 			nsn := xid.New().String()
 
+			if tt.downgrade {
+				// a newer release is recorded as the deployed one
+				dsci := dsciv2.DSCInitialization{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "default-dsci",
+					},
+				}
+
+				g.Expect(cli.Create(ctx, &dsci)).
+					NotTo(HaveOccurred())
+
+				t.Cleanup(func() {
+					g.Expect(cli.Delete(ctx, &dsci)).Should(Or(
+						Not(HaveOccurred()),
+						MatchError(k8serr.IsNotFound, "IsNotFound"),
+					))
+				})
+
+				dsci.Status.Release = common.Release{
+					Name:    cluster.OpenDataHub,
+					Version: version.OperatorVersion{Version: semver.Version{Major: 0, Minor: 1, Patch: 0}},
+				}
+
+				g.Expect(cli.Status().Update(ctx, &dsci)).
+					NotTo(HaveOccurred())
+			}
+
 			ns := corev1.Namespace{
 				ObjectMeta: metav1.ObjectMeta{
 					Name: nsn,
@@ -316,11 +389,22 @@ func TestGcAction(t *testing.T) {
 			g.Expect(cli.Create(ctx, &cm)).
 				NotTo(HaveOccurred())
 
-			opts := make([]gc.ActionOpts, 0, len(tt.options)+1)
+			opts := make([]gc.ActionOpts, 0, len(tt.options)+3)
 			opts = append(opts, gc.WithDeletePropagationPolicy(metav1.DeletePropagationBackground))
 			opts = append(opts, gc.InNamespace(nsn))
 			opts = append(opts, tt.options...)
 
+			if tt.inventory {
+				// the kind has been deployed in the past, it is not rendered anymore
+				inv, err := gc.LoadInventory(ctx, cli, nsn, strings.ToLower(componentApi.DashboardKind))
+				g.Expect(err).NotTo(HaveOccurred())
+
+				inv.Add(gc.InventoryEntry{Version: "v1", Kind: "ConfigMap", PartOf: strings.ToLower(componentApi.DashboardKind)})
+				g.Expect(inv.Save(ctx, cli)).To(Succeed())
+
+				opts = append(opts, gc.WithInventory())
+			}
+
 			a := gc.NewAction(opts...)
 
 			g.Expect(a(ctx, &rr)).NotTo(HaveOccurred())
@@ -0,0 +1,188 @@
+package patches
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/handlers"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/predicates/component"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/reconciler"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
+)
+
+// Action loads the user supplied patches targeting the reconciled component
+// and stores them in the ReconciliationRequest, so that the render actions
+// can apply them to the rendered resources.
+//
+// Patches are read from the ConfigMaps in the applications namespace labelled
+// with labels.ComponentPatches=<component>. Invalid patches are skipped and
+// reported through the PatchesValid condition, so a malformed patch does not
+// prevent the component from being deployed. The same goes for valid patches
+// that cannot be applied to the rendered resources, see MarkNotApplied.
+type Action struct {
+	componentName string
+}
+
+type ActionOpts func(*Action)
+
+// WithComponentName overrides the name used to select the patches ConfigMaps,
+// by default the lower-cased kind of the reconciled instance is used.
+func WithComponentName(value string) ActionOpts {
+	return func(a *Action) {
+		a.componentName = value
+	}
+}
+
+func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error {
+	rr.Patches = nil
+
+	if rr.SkipDeploy {
+		return nil
+	}
+
+	name, err := a.resolveComponentName(rr)
+	if err != nil {
+		return err
+	}
+
+	appNamespace, err := cluster.ApplicationNamespace(ctx, rr.Client)
+	if err != nil {
+		return err
+	}
+
+	items := corev1.ConfigMapList{}
+	err = rr.Client.List(ctx, &items,
+		client.InNamespace(appNamespace),
+		client.MatchingLabels{labels.ComponentPatches: name},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to list patches for component %s: %w", name, err)
+	}
+
+	if len(items.Items) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(items.Items, func(a, b corev1.ConfigMap) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var errs []error
+
+	for i := range items.Items {
+		values, err := patches.FromConfigMap(&items.Items[i])
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		rr.Patches = append(rr.Patches, values...)
+	}
+
+	if rr.Conditions == nil {
+		return nil
+	}
+
+	if len(errs) != 0 {
+		rr.Conditions.MarkFalse(
+			status.ConditionPatchesValid,
+			conditions.WithReason(status.InvalidPatchesReason),
+			conditions.WithSeverity(common.ConditionSeverityInfo),
+			conditions.WithMessage("%s", strings.ReplaceAll(errors.Join(errs...).Error(), "\n", "; ")),
+		)
+
+		return nil
+	}
+
+	rr.Conditions.MarkTrue(
+		status.ConditionPatchesValid,
+		conditions.WithReason(status.PatchesValidReason),
+		conditions.WithMessage("%d patches applied", len(rr.Patches)),
+	)
+
+	return nil
+}
+
+// MarkNotApplied reports through the PatchesValid condition the patches the
+// render actions skipped, as they could not be applied to the rendered
+// resources. The component is still deployed with the other patches.
+func MarkNotApplied(rr *types.ReconciliationRequest, err error) {
+	if err == nil || rr.Conditions == nil {
+		return
+	}
+
+	message := strings.ReplaceAll(err.Error(), "\n", "; ")
+
+	// keep the errors of the invalid patches reported by the patches action
+	if c := rr.Conditions.GetCondition(status.ConditionPatchesValid); c != nil && c.Status == metav1.ConditionFalse && c.Message != "" {
+		message = c.Message + "; " + message
+	}
+
+	rr.Conditions.MarkFalse(
+		status.ConditionPatchesValid,
+		conditions.WithReason(status.InvalidPatchesReason),
+		conditions.WithSeverity(common.ConditionSeverityInfo),
+		conditions.WithMessage("%s", message),
+	)
+}
+
+func (a *Action) resolveComponentName(rr *types.ReconciliationRequest) (string, error) {
+	if a.componentName != "" {
+		return a.componentName, nil
+	}
+
+	kind, err := resources.KindForObject(rr.Client.Scheme(), rr.Instance)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.ToLower(kind), nil
+}
+
+// NewAction creates an action loading the user supplied patches for the
+// reconciled component. It must be added before any render action.
+func NewAction(opts ...ActionOpts) actions.Fn {
+	action := Action{}
+
+	for _, opt := range opts {
+		opt(&action)
+	}
+
+	return action.run
+}
+
+// ComponentPatches wires the user supplied patches support into a component
+// reconciler: a watch on the patches ConfigMaps routed to the component
+// singleton instance, and the action loading them. It must be composed
+// before any render action.
+//
+// Use with [reconciler.ComposeWith]:
+//
+//	b.ComposeWith(patches.ComponentPatches[*componentApi.Dashboard](
+//	    componentApi.DashboardInstanceName,
+//	    strings.ToLower(componentApi.DashboardKind),
+//	))
+func ComponentPatches[T common.PlatformObject](instanceName string, componentName string) func(*reconciler.ReconcilerBuilder[T]) {
+	return func(b *reconciler.ReconcilerBuilder[T]) {
+		b.Watches(
+			&corev1.ConfigMap{},
+			reconciler.WithEventHandler(handlers.ToNamed(instanceName)),
+			reconciler.WithPredicates(component.ForLabelAllEvents(labels.ComponentPatches, componentName)),
+		).
+			WithAction(NewAction(WithComponentName(componentName)))
+	}
+}
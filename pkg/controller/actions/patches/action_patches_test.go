@@ -0,0 +1,161 @@
+package patches_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/rs/xid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/patches"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+const testPatch = `
+target:
+  kind: Deployment
+  name: odh-dashboard
+patch: '{"spec": {"replicas": 2}}'
+`
+
+func newPatchesConfigMap(ns string, name string, component string, data map[string]string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				labels.ComponentPatches: component,
+			},
+		},
+		Data: data,
+	}
+}
+
+func TestPatchesAction(t *testing.T) {
+	ns := xid.New().String()
+
+	dsci := &dsciv2.DSCInitialization{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-dsci"},
+		Spec:       dsciv2.DSCInitializationSpec{ApplicationsNamespace: ns},
+	}
+
+	t.Run("no patches", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cl, err := fakeclient.New(fakeclient.WithObjects(dsci))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rr := types.ReconciliationRequest{Client: cl, Instance: &componentApi.Dashboard{}}
+		rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+		g.Expect(patches.NewAction()(t.Context(), &rr)).Should(Succeed())
+		g.Expect(rr.Patches).Should(BeEmpty())
+		g.Expect(rr.Conditions.GetCondition(status.ConditionPatchesValid)).Should(BeNil())
+	})
+
+	t.Run("valid patches for the component", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cl, err := fakeclient.New(fakeclient.WithObjects(
+			dsci,
+			newPatchesConfigMap(ns, "b-patches", "dashboard", map[string]string{"replicas": testPatch}),
+			newPatchesConfigMap(ns, "a-patches", "dashboard", map[string]string{"replicas": testPatch}),
+			newPatchesConfigMap(ns, "other-patches", "kserve", map[string]string{"replicas": testPatch}),
+		))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rr := types.ReconciliationRequest{Client: cl, Instance: &componentApi.Dashboard{}}
+		rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+		g.Expect(patches.NewAction()(t.Context(), &rr)).Should(Succeed())
+		g.Expect(rr.Patches).Should(HaveLen(2))
+		g.Expect(rr.Patches[0].Source).Should(Equal(ns + "/a-patches[replicas]"))
+		g.Expect(rr.Patches[1].Source).Should(Equal(ns + "/b-patches[replicas]"))
+
+		c := rr.Conditions.GetCondition(status.ConditionPatchesValid)
+		g.Expect(c).ShouldNot(BeNil())
+		g.Expect(c.Status).Should(Equal(metav1.ConditionTrue))
+	})
+
+	t.Run("invalid patches are reported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cl, err := fakeclient.New(fakeclient.WithObjects(
+			dsci,
+			newPatchesConfigMap(ns, "patches", "dashboard", map[string]string{
+				"replicas": testPatch,
+				"invalid":  "patch: ''",
+			}),
+		))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rr := types.ReconciliationRequest{Client: cl, Instance: &componentApi.Dashboard{}}
+		rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+		g.Expect(patches.NewAction()(t.Context(), &rr)).Should(Succeed())
+		g.Expect(rr.Patches).Should(HaveLen(1))
+
+		c := rr.Conditions.GetCondition(status.ConditionPatchesValid)
+		g.Expect(c).ShouldNot(BeNil())
+		g.Expect(c.Status).Should(Equal(metav1.ConditionFalse))
+		g.Expect(c.Reason).Should(Equal(status.InvalidPatchesReason))
+		g.Expect(c.Severity).Should(Equal(common.ConditionSeverityInfo))
+		g.Expect(strings.Contains(c.Message, ns+"/patches[invalid]")).Should(BeTrue())
+	})
+
+	t.Run("skip deploy", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cl, err := fakeclient.New(fakeclient.WithObjects(
+			dsci,
+			newPatchesConfigMap(ns, "patches", "dashboard", map[string]string{"replicas": testPatch}),
+		))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		rr := types.ReconciliationRequest{Client: cl, Instance: &componentApi.Dashboard{}, SkipDeploy: true}
+
+		g.Expect(patches.NewAction()(t.Context(), &rr)).Should(Succeed())
+		g.Expect(rr.Patches).Should(BeEmpty())
+	})
+}
+
+func TestMarkNotApplied(t *testing.T) {
+	g := NewWithT(t)
+
+	rr := types.ReconciliationRequest{Instance: &componentApi.Dashboard{}}
+	rr.Conditions = conditions.NewManager(rr.Instance, status.ConditionTypeReady)
+
+	patches.MarkNotApplied(&rr, nil)
+	g.Expect(rr.Conditions.GetCondition(status.ConditionPatchesValid)).Should(BeNil())
+
+	rr.Conditions.MarkFalse(
+		status.ConditionPatchesValid,
+		conditions.WithReason(status.InvalidPatchesReason),
+		conditions.WithMessage("ns/patches[invalid]: invalid patch definition"),
+	)
+
+	patches.MarkNotApplied(&rr, errors.Join(
+		errors.New("failed to apply patch ns/patches[a] to Deployment ns/odh-dashboard: missing path"),
+		errors.New("failed to apply patch ns/patches[b] to Service ns/odh-dashboard: missing path"),
+	))
+
+	c := rr.Conditions.GetCondition(status.ConditionPatchesValid)
+	g.Expect(c).ShouldNot(BeNil())
+	g.Expect(c.Status).Should(Equal(metav1.ConditionFalse))
+	g.Expect(c.Reason).Should(Equal(status.InvalidPatchesReason))
+	g.Expect(c.Severity).Should(Equal(common.ConditionSeverityInfo))
+	g.Expect(c.Message).Should(Equal("ns/patches[invalid]: invalid patch definition; " +
+		"failed to apply patch ns/patches[a] to Deployment ns/odh-dashboard: missing path; " +
+		"failed to apply patch ns/patches[b] to Service ns/odh-dashboard: missing path"))
+}
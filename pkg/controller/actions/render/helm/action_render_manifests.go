@@ -12,8 +12,10 @@ import (
 	helm "github.com/k8s-manifest-kit/renderer-helm/pkg"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	patchesAction "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/resourcecacher"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 )
 
@@ -28,6 +30,10 @@ type Action struct {
 	labels       map[string]string
 	annotations  map[string]string
 	transformers []engineTypes.Transformer
+
+	// patchFailures holds the patches skipped by the last render, reported
+	// again when the cached resources are used.
+	patchFailures error
 }
 
 type ActionOpts func(*Action)
@@ -96,7 +102,15 @@ func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error
 		return nil
 	}
 
-	return a.cacher.Render(ctx, rr, a.render)
+	if err := a.cacher.Render(ctx, rr, a.render); err != nil {
+		return err
+	}
+
+	if len(rr.Patches) != 0 {
+		patchesAction.MarkNotApplied(rr, a.patchFailures)
+	}
+
+	return nil
 }
 
 func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (resources.UnstructuredList, error) {
@@ -120,6 +134,10 @@ func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (r
 	if a.labels != nil {
 		helmOptions.Transformers = append(helmOptions.Transformers, labels.Set(a.labels))
 	}
+	failures := patches.Failures{}
+	if len(rr.Patches) != 0 {
+		helmOptions.Transformers = append(helmOptions.Transformers, patches.Transformer(rr.Client.Scheme(), &failures, rr.Patches...))
+	}
 
 	renderer, err := helm.New(charts, helmOptions)
 	if err != nil {
@@ -127,7 +145,14 @@ func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (r
 	}
 
 	// TODO: manage render time values
-	return renderer.Process(ctx, map[string]any{})
+	result, err := renderer.Process(ctx, map[string]any{})
+	if err != nil {
+		return nil, err
+	}
+
+	a.patchFailures = failures.Err()
+
+	return result, nil
 }
 
 // NewAction creates a new Helm rendering action.
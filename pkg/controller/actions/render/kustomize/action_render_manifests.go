@@ -7,9 +7,11 @@ import (
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions"
+	patchesAction "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/actions/resourcecacher"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/kustomize"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 )
 
@@ -24,6 +26,10 @@ type Action struct {
 
 	keOpts []kustomize.EngineOptsFn
 	ke     *kustomize.Engine
+
+	// patchFailures holds the patches skipped by the last render, reported
+	// again when the cached resources are used.
+	patchFailures error
 }
 
 type ActionOpts func(*Action)
@@ -75,11 +81,20 @@ func (a *Action) run(ctx context.Context, rr *types.ReconciliationRequest) error
 		return nil
 	}
 
-	return a.cacher.Render(ctx, rr, a.render)
+	if err := a.cacher.Render(ctx, rr, a.render); err != nil {
+		return err
+	}
+
+	if len(rr.Patches) != 0 {
+		patchesAction.MarkNotApplied(rr, a.patchFailures)
+	}
+
+	return nil
 }
 
 func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (resources.UnstructuredList, error) {
 	result := make(resources.UnstructuredList, 0)
+	failures := patches.Failures{}
 
 	// Fetch application namespace from DSCI.
 	appNamespace, err := cluster.ApplicationNamespace(ctx, rr.Client)
@@ -96,6 +111,7 @@ func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (r
 		renderedResources, err := a.ke.Render(
 			rr.Manifests[i].String(),
 			kustomize.WithNamespace(ns),
+			kustomize.WithPatches(rr.Client.Scheme(), &failures, rr.Patches...),
 		)
 
 		if err != nil {
@@ -105,6 +121,8 @@ func (a *Action) render(ctx context.Context, rr *types.ReconciliationRequest) (r
 		result = append(result, renderedResources...)
 	}
 
+	a.patchFailures = failures.Err()
+
 	return result, nil
 }
 
@@ -0,0 +1,128 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/transformers"
+)
+
+const imagesNotMirroredReason = "ImagesNotMirrored"
+
+// imageMirrors returns the image mirror rules of the instance, followed by the
+// ones of the ImageDigestMirrorSets of the cluster, if the API exists. Only the
+// first mirror of each ImageDigestMirrorSet source is used, as the rendered
+// images reference a single registry.
+func imageMirrors(ctx context.Context, reader client.Reader, instance ccmcommon.KubernetesEngineInstance) ([]transformers.ImageMirror, error) {
+	var mirrors []transformers.ImageMirror
+
+	for _, m := range instance.GetImageMirrors() {
+		mirrors = append(mirrors, transformers.ImageMirror{Source: m.Source, Mirror: m.Mirror})
+	}
+
+	sets := &unstructured.UnstructuredList{}
+	sets.SetGroupVersionKind(gvk.ImageDigestMirrorSet)
+
+	if err := reader.List(ctx, sets); err != nil {
+		if meta.IsNoMatchError(err) {
+			return mirrors, nil
+		}
+
+		return nil, fmt.Errorf("failed to list ImageDigestMirrorSets: %w", err)
+	}
+
+	for _, set := range sets.Items {
+		rules, _, err := unstructured.NestedSlice(set.Object, "spec", "imageDigestMirrors")
+		if err != nil {
+			return nil, fmt.Errorf("invalid ImageDigestMirrorSet %s: %w", set.GetName(), err)
+		}
+
+		for _, r := range rules {
+			rule, ok := r.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			source, _, _ := unstructured.NestedString(rule, "source")
+			targets, _, _ := unstructured.NestedStringSlice(rule, "mirrors")
+
+			if source == "" || len(targets) == 0 {
+				continue
+			}
+
+			mirrors = append(mirrors, transformers.ImageMirror{Source: source, Mirror: targets[0], DigestOnly: true})
+		}
+	}
+
+	return mirrors, nil
+}
+
+// mirrorImages rewrites the images of the rendered resources according to the
+// given mirror rules, and returns the images no rule matches. It runs on the
+// rendered resources rather than within the Helm render, since the
+// ImageDigestMirrorSets of the cluster are not part of the render cache key.
+func mirrorImages(resources []unstructured.Unstructured, mirrors []transformers.ImageMirror) ([]string, error) {
+	if len(mirrors) == 0 {
+		return nil, nil
+	}
+
+	mirror := transformers.ImageMirrors(mirrors)
+
+	var unmirrored []string
+
+	for i := range resources {
+		images, err := transformers.Images(&resources[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read images of %s %s: %w", resources[i].GetKind(), resources[i].GetName(), err)
+		}
+
+		for _, image := range images {
+			if _, ok := transformers.MirrorImage(mirrors, image); !ok && !slices.Contains(unmirrored, image) {
+				unmirrored = append(unmirrored, image)
+			}
+		}
+
+		if err := mirror(&resources[i]); err != nil {
+			return nil, fmt.Errorf("failed to mirror images of %s %s: %w", resources[i].GetKind(), resources[i].GetName(), err)
+		}
+	}
+
+	slices.Sort(unmirrored)
+
+	return unmirrored, nil
+}
+
+// reportUnmirroredImages reports the rendered images that no mirror rule
+// covers, which a disconnected cluster is unable to pull. The condition is
+// informational and does not prevent the deployment, as the images may be
+// mirrored by other means. It is only set when mirror rules are defined.
+func reportUnmirroredImages(rr *types.ReconciliationRequest, mirrors []transformers.ImageMirror, unmirrored []string) {
+	if len(mirrors) == 0 {
+		return
+	}
+
+	if len(unmirrored) > 0 {
+		rr.Conditions.MarkFalse(
+			status.ConditionImagesMirrored,
+			conditions.WithReason(imagesNotMirroredReason),
+			conditions.WithMessage("Images not covered by a mirror rule: %s", strings.Join(unmirrored, ", ")),
+			conditions.WithSeverity(common.ConditionSeverityInfo),
+		)
+
+		return
+	}
+
+	rr.Conditions.MarkTrue(status.ConditionImagesMirrored)
+}
@@ -0,0 +1,174 @@
+//nolint:testpackage // white-box tests for unexported image mirroring helpers
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/azure/v1alpha1"
+	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/transformers"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func newImageDeployment(name string, images ...string) unstructured.Unstructured {
+	containers := make([]any, 0, len(images))
+	for _, image := range images {
+		containers = append(containers, map[string]any{"name": name, "image": image})
+	}
+
+	obj := unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{"containers": containers},
+			},
+		},
+	}}
+	obj.SetGroupVersionKind(gvk.Deployment)
+	obj.SetName(name)
+
+	return obj
+}
+
+func TestImageMirrors(t *testing.T) {
+	ctx := context.Background()
+
+	instance := &ccmv1alpha1.AzureKubernetesEngine{}
+	instance.Spec.ImageMirrors = []ccmcommon.ImageMirror{
+		{Source: "quay.io/jetstack", Mirror: "registry.example.com/jetstack"},
+	}
+
+	t.Run("clusters without ImageDigestMirrorSet use the instance rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New()
+		g.Expect(err).NotTo(HaveOccurred())
+
+		mirrors, err := imageMirrors(ctx, cli, instance)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(mirrors).To(Equal([]transformers.ImageMirror{
+			{Source: "quay.io/jetstack", Mirror: "registry.example.com/jetstack"},
+		}))
+	})
+
+	t.Run("ImageDigestMirrorSet rules follow the instance rules", func(t *testing.T) {
+		g := NewWithT(t)
+
+		set := &unstructured.Unstructured{Object: map[string]any{
+			"spec": map[string]any{
+				"imageDigestMirrors": []any{
+					map[string]any{
+						"source":  "registry.redhat.io",
+						"mirrors": []any{"mirror.example.com/redhat", "backup.example.com/redhat"},
+					},
+					map[string]any{
+						"source": "quay.io/no-mirrors",
+					},
+				},
+			},
+		}}
+		set.SetGroupVersionKind(gvk.ImageDigestMirrorSet)
+		set.SetName("cluster")
+
+		cli, err := fakeclient.New(
+			fakeclient.WithGVKs(fakeclient.GVKMapping{GVK: gvk.ImageDigestMirrorSet, Scope: meta.RESTScopeRoot}),
+			fakeclient.WithObjects(set),
+		)
+		g.Expect(err).NotTo(HaveOccurred())
+
+		mirrors, err := imageMirrors(ctx, cli, instance)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(mirrors).To(Equal([]transformers.ImageMirror{
+			{Source: "quay.io/jetstack", Mirror: "registry.example.com/jetstack"},
+			{Source: "registry.redhat.io", Mirror: "mirror.example.com/redhat", DigestOnly: true},
+		}))
+	})
+}
+
+func TestMirrorImages(t *testing.T) {
+	mirrors := []transformers.ImageMirror{
+		{Source: "quay.io/jetstack", Mirror: "registry.example.com/jetstack"},
+	}
+
+	newRequest := func() *types.ReconciliationRequest {
+		instance := &ccmv1alpha1.AzureKubernetesEngine{}
+		rr := &types.ReconciliationRequest{Instance: instance}
+		rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady, ConditionsTypes...)
+
+		return rr
+	}
+
+	t.Run("images are rewritten and the unmirrored ones reported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resources := []unstructured.Unstructured{
+			newImageDeployment("cert-manager", "quay.io/jetstack/cert-manager-controller:v1.15.2"),
+			newImageDeployment("keda", "ghcr.io/kedacore/keda:2.16.0", "ghcr.io/kedacore/keda:2.16.0"),
+		}
+
+		unmirrored, err := mirrorImages(resources, mirrors)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(unmirrored).To(Equal([]string{"ghcr.io/kedacore/keda:2.16.0"}))
+
+		images, err := transformers.Images(&resources[0])
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(images).To(Equal([]string{"registry.example.com/jetstack/cert-manager-controller:v1.15.2"}))
+
+		rr := newRequest()
+		reportUnmirroredImages(rr, mirrors, unmirrored)
+
+		cond := rr.Conditions.GetCondition(status.ConditionImagesMirrored)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		g.Expect(cond.Reason).To(Equal(imagesNotMirroredReason))
+		g.Expect(cond.Severity).To(Equal(common.ConditionSeverityInfo))
+		g.Expect(cond.Message).To(Equal("Images not covered by a mirror rule: ghcr.io/kedacore/keda:2.16.0"))
+	})
+
+	t.Run("all images mirrored", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resources := []unstructured.Unstructured{
+			newImageDeployment("cert-manager", "quay.io/jetstack/cert-manager-controller:v1.15.2"),
+		}
+
+		unmirrored, err := mirrorImages(resources, mirrors)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(unmirrored).To(BeEmpty())
+
+		rr := newRequest()
+		reportUnmirroredImages(rr, mirrors, unmirrored)
+
+		cond := rr.Conditions.GetCondition(status.ConditionImagesMirrored)
+		g.Expect(cond).NotTo(BeNil())
+		g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	t.Run("no mirror rules leave images untouched and report nothing", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resources := []unstructured.Unstructured{
+			newImageDeployment("keda", "ghcr.io/kedacore/keda:2.16.0"),
+		}
+
+		unmirrored, err := mirrorImages(resources, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(unmirrored).To(BeEmpty())
+
+		rr := newRequest()
+		reportUnmirroredImages(rr, nil, unmirrored)
+
+		g.Expect(rr.Conditions.GetCondition(status.ConditionImagesMirrored)).To(BeNil())
+	})
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"strings"
 
+	appsv1 "k8s.io/api/apps/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
@@ -18,7 +21,12 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
-const dependencyDegradedReason = "DependencyDegraded"
+const (
+	dependencyDegradedReason  = "DependencyDegraded"
+	upgradeAvailableReason    = "UpgradeAvailable"
+	externalReason            = "External"
+	incompatibleVersionReason = "IncompatibleVersion"
+)
 
 func defaultDegradedConditionFilter(condType, condStatus string) bool {
 	if condType == "Degraded" && condStatus == string(metav1.ConditionTrue) {
@@ -31,62 +39,280 @@ func defaultDegradedConditionFilter(condType, condStatus string) bool {
 	return false
 }
 
-func monitorDependencies(ctx context.Context, rr *types.ReconciliationRequest, resourceID string, configs []ccmcharts.DependencyMonitorConfig) error {
+func monitorDependencies(
+	ctx context.Context,
+	rr *types.ReconciliationRequest,
+	reader client.Reader,
+	resourceID string,
+	configs []ccmcharts.DependencyMonitorConfig,
+) error {
 	for _, cfg := range configs {
-		if cfg.Policy == ccmcommon.Unmanaged {
-			rr.Conditions.MarkTrue(
-				cfg.ConditionType,
-				conditions.WithReason(status.UnmanagedReason),
-			)
+		if err := monitorDependency(ctx, rr, reader, resourceID, cfg); err != nil {
+			// the status is reported even when a check fails, so that it
+			// records the error of the dependency being checked
+			reportDependenciesStatus(rr, configs, cfg.Name, err)
 
-			continue
+			return err
 		}
+	}
+
+	reportDependenciesStatus(rr, configs, "", nil)
+
+	return nil
+}
+
+// monitorDependency reports the health of a dependency on its condition.
+func monitorDependency(
+	ctx context.Context,
+	rr *types.ReconciliationRequest,
+	reader client.Reader,
+	resourceID string,
+	cfg ccmcharts.DependencyMonitorConfig,
+) error {
+	if cfg.Policy == ccmcommon.Unmanaged {
+		rr.Conditions.MarkTrue(
+			cfg.ConditionType,
+			conditions.WithReason(status.UnmanagedReason),
+		)
+
+		return nil
+	}
 
-		// Tier 1: operator deployment health
-		if cfg.HasDeployments {
-			depAction := deployments.NewAction(
-				deployments.WithConditionType(cfg.ConditionType),
-				deployments.InNamespace(cfg.Namespace),
-				deployments.WithPartOfLabel(labels.InfrastructurePartOf),
-				deployments.WithSelectorLabel(labels.InfrastructurePartOf, resourceID),
+	if cfg.External != nil {
+		return monitorExternalDependency(ctx, rr, reader, cfg)
+	}
+
+	// Tier 1: operator deployment health
+	if cfg.HasDeployments {
+		depAction := deployments.NewAction(
+			deployments.WithConditionType(cfg.ConditionType),
+			deployments.InNamespace(cfg.Namespace),
+			deployments.WithPartOfLabel(labels.InfrastructurePartOf),
+			deployments.WithSelectorLabel(labels.InfrastructurePartOf, resourceID),
+		)
+
+		if err := depAction(ctx, rr); err != nil {
+			return fmt.Errorf("deployment check for %s failed: %w", cfg.ReleaseName, err)
+		}
+	}
+
+	// Tier 2: operator CR health (skip if Tier 1 already marked unhealthy)
+	cond := rr.Conditions.GetCondition(cfg.ConditionType)
+	if cfg.OperatorCR != nil && (cond == nil || cond.Status != metav1.ConditionFalse) {
+		result, err := monitor.CheckOperatorHealth(ctx, rr.Client, monitor.OperatorConfig{
+			OperatorGVK: cfg.OperatorCR.GVK,
+			CRName:      cfg.OperatorCR.Name,
+			CRNamespace: cfg.OperatorCR.Namespace,
+			Filter:      defaultDegradedConditionFilter,
+		})
+		if err != nil {
+			return fmt.Errorf("operator CR check for %s failed: %w", cfg.ReleaseName, err)
+		}
+
+		if !result.Pass {
+			rr.Conditions.MarkFalse(
+				cfg.ConditionType,
+				conditions.WithReason(dependencyDegradedReason),
+				conditions.WithMessage("%s", result.Message),
 			)
+		}
+	}
 
-			if err := depAction(ctx, rr); err != nil {
-				return fmt.Errorf("deployment check for %s failed: %w", cfg.ReleaseName, err)
-			}
+	// No deployments and no CR (e.g. GatewayAPI): mark available after successful deploy
+	if !cfg.HasDeployments && cfg.OperatorCR == nil {
+		rr.Conditions.MarkTrue(cfg.ConditionType)
+	}
+
+	reportDependencyVersion(rr, cfg)
+
+	if cfg.Policy == ccmcommon.Auto {
+		reportAutoInstall(rr, cfg)
+	}
+
+	return nil
+}
+
+// reportDependenciesStatus records the observed state of each dependency on
+// the status of the instance, from its monitoring config and its condition.
+// When checkErr is not nil, the health of the dependency named failed could
+// not be checked: its readiness is unknown and checkErr is its last error.
+func reportDependenciesStatus(
+	rr *types.ReconciliationRequest,
+	configs []ccmcharts.DependencyMonitorConfig,
+	failed string,
+	checkErr error,
+) {
+	ke, ok := rr.Instance.(ccmcommon.KubernetesEngineInstance)
+	if !ok {
+		return
+	}
+
+	deps := make(map[string]ccmcommon.DependencyStatus, len(configs))
+
+	for _, cfg := range configs {
+		ds := ccmcommon.DependencyStatus{
+			ManagementPolicy: cfg.Policy,
+			ChartState:       cfg.ChartState,
+			Namespace:        cfg.Namespace,
+			Version:          cfg.Version,
+			AvailableVersion: cfg.AvailableVersion,
 		}
 
-		// Tier 2: operator CR health (skip if Tier 1 already marked unhealthy)
-		cond := rr.Conditions.GetCondition(cfg.ConditionType)
-		if cfg.OperatorCR != nil && (cond == nil || cond.Status != metav1.ConditionFalse) {
-			result, err := monitor.CheckOperatorHealth(ctx, rr.Client, monitor.OperatorConfig{
-				OperatorGVK: cfg.OperatorCR.GVK,
-				CRName:      cfg.OperatorCR.Name,
-				CRNamespace: cfg.OperatorCR.Namespace,
-				Filter:      defaultDegradedConditionFilter,
-			})
-			if err != nil {
-				return fmt.Errorf("operator CR check for %s failed: %w", cfg.ReleaseName, err)
+		if cond := rr.Conditions.GetCondition(cfg.ConditionType); cond != nil {
+			ds.Ready = cond.Status
+			ds.Reason = cond.Reason
+			if cond.Status != metav1.ConditionTrue {
+				ds.LastError = cond.Message
 			}
+		}
+
+		if checkErr != nil && cfg.Name == failed {
+			ds.Ready = metav1.ConditionUnknown
+			ds.Reason = ""
+			ds.LastError = checkErr.Error()
+		}
+
+		deps[cfg.Name] = ds
+	}
+
+	ke.SetDependenciesStatus(deps)
+}
+
+// monitorExternalDependency reports the health of an existing installation
+// adopted by the Auto policy. The installation is not labeled by the cloud
+// manager, hence its deployments are the ones found at detection, read with
+// the uncached reader, and its operator CR, if any, is the first one found
+// on the cluster.
+func monitorExternalDependency(
+	ctx context.Context,
+	rr *types.ReconciliationRequest,
+	reader client.Reader,
+	cfg ccmcharts.DependencyMonitorConfig,
+) error {
+	ext := cfg.External
 
-			if !result.Pass {
-				rr.Conditions.MarkFalse(
-					cfg.ConditionType,
-					conditions.WithReason(dependencyDegradedReason),
-					conditions.WithMessage("%s", result.Message),
-				)
+	if ext.Incompatible != "" {
+		rr.Conditions.MarkFalse(
+			cfg.ConditionType,
+			conditions.WithReason(incompatibleVersionReason),
+			conditions.WithMessage("Existing installation cannot be adopted: %s", ext.Incompatible),
+		)
+
+		return nil
+	}
+
+	var notReady []string
+
+	for _, key := range ext.Deployments {
+		d := &appsv1.Deployment{}
+		if err := reader.Get(ctx, key, d); err != nil {
+			if k8serr.IsNotFound(err) {
+				notReady = append(notReady, key.String())
+				continue
 			}
+
+			return fmt.Errorf("deployment check for %s failed: %w", cfg.ReleaseName, err)
 		}
 
-		// No deployments and no CR (e.g. GatewayAPI): mark available after successful deploy
-		if !cfg.HasDeployments && cfg.OperatorCR == nil {
-			rr.Conditions.MarkTrue(cfg.ConditionType)
+		if d.Status.Replicas == 0 || d.Status.ReadyReplicas != d.Status.Replicas {
+			notReady = append(notReady, key.String())
 		}
 	}
 
+	if len(notReady) > 0 {
+		rr.Conditions.MarkFalse(
+			cfg.ConditionType,
+			conditions.WithReason(dependencyDegradedReason),
+			conditions.WithMessage("Existing installation deployments not ready: %s", strings.Join(notReady, ", ")),
+		)
+
+		return nil
+	}
+
+	if cfg.OperatorCR != nil {
+		result, err := monitor.CheckOperatorHealth(ctx, reader, monitor.OperatorConfig{
+			OperatorGVK: cfg.OperatorCR.GVK,
+			Filter:      defaultDegradedConditionFilter,
+		})
+		if err != nil {
+			return fmt.Errorf("operator CR check for %s failed: %w", cfg.ReleaseName, err)
+		}
+
+		if !result.Pass {
+			rr.Conditions.MarkFalse(
+				cfg.ConditionType,
+				conditions.WithReason(dependencyDegradedReason),
+				conditions.WithMessage("%s", result.Message),
+			)
+
+			return nil
+		}
+	}
+
+	version := ext.Version
+	if version == "" {
+		version = "unknown"
+	}
+
+	rr.Conditions.MarkTrue(
+		cfg.ConditionType,
+		conditions.WithReason(externalReason),
+		conditions.WithMessage("Existing installation adopted (version %s): %s", version, strings.Join(ext.Resources, ", ")),
+	)
+
 	return nil
 }
 
+// reportAutoInstall records on the condition of a healthy dependency that the
+// Auto policy installed it, as no existing installation was found.
+func reportAutoInstall(rr *types.ReconciliationRequest, cfg ccmcharts.DependencyMonitorConfig) {
+	cond := rr.Conditions.GetCondition(cfg.ConditionType)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return
+	}
+
+	message := "No existing installation found, installed by the operator"
+	if cond.Message != "" {
+		message += ": " + cond.Message
+	}
+
+	rr.Conditions.MarkTrue(
+		cfg.ConditionType,
+		conditions.WithReason(cond.Reason),
+		conditions.WithMessage("%s", message),
+	)
+}
+
+// reportDependencyVersion adds the installed and the newest available chart
+// versions to the condition of a healthy dependency. A dependency held back
+// from the newest bundled version is flagged with the UpgradeAvailable reason.
+func reportDependencyVersion(rr *types.ReconciliationRequest, cfg ccmcharts.DependencyMonitorConfig) {
+	if cfg.Version == "" {
+		return
+	}
+
+	cond := rr.Conditions.GetCondition(cfg.ConditionType)
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return
+	}
+
+	if cfg.AvailableVersion != "" && cfg.AvailableVersion != cfg.Version {
+		rr.Conditions.MarkTrue(
+			cfg.ConditionType,
+			conditions.WithReason(upgradeAvailableReason),
+			conditions.WithMessage("Version %s installed, version %s available", cfg.Version, cfg.AvailableVersion),
+		)
+
+		return
+	}
+
+	rr.Conditions.MarkTrue(
+		cfg.ConditionType,
+		conditions.WithReason(cond.Reason),
+		conditions.WithMessage("Version %s installed", cfg.Version),
+	)
+}
+
 func summarizeDependencyStatus(rr *types.ReconciliationRequest, configs []ccmcharts.DependencyMonitorConfig) {
 	var notReady []string
 
@@ -3,6 +3,7 @@ package cloudmanager
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -13,7 +14,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	ccmv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/azure/v1alpha1"
 	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
@@ -66,31 +69,43 @@ func TestMonitorDependencies(t *testing.T) {
 		{
 			name: "unmanaged dependency is True with Unmanaged reason",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			expectedStatus: map[string]metav1.ConditionStatus{
-				status.ConditionCertManagerReady:  metav1.ConditionTrue,
-				status.ConditionGatewayAPIReady:   metav1.ConditionTrue,
-				status.ConditionLWSReady:          metav1.ConditionTrue,
-				status.ConditionSailOperatorReady: metav1.ConditionTrue,
+				status.ConditionCertManagerReady:        metav1.ConditionTrue,
+				status.ConditionGatewayAPIReady:         metav1.ConditionTrue,
+				status.ConditionLWSReady:                metav1.ConditionTrue,
+				status.ConditionSailOperatorReady:       metav1.ConditionTrue,
+				status.ConditionKueueReady:              metav1.ConditionTrue,
+				status.ConditionPrometheusOperatorReady: metav1.ConditionTrue,
+				status.ConditionKEDAReady:               metav1.ConditionTrue,
 			},
 			expectedReasons: map[string]string{
-				status.ConditionCertManagerReady:  status.UnmanagedReason,
-				status.ConditionGatewayAPIReady:   status.UnmanagedReason,
-				status.ConditionLWSReady:          status.UnmanagedReason,
-				status.ConditionSailOperatorReady: status.UnmanagedReason,
+				status.ConditionCertManagerReady:        status.UnmanagedReason,
+				status.ConditionGatewayAPIReady:         status.UnmanagedReason,
+				status.ConditionLWSReady:                status.UnmanagedReason,
+				status.ConditionSailOperatorReady:       status.UnmanagedReason,
+				status.ConditionKueueReady:              status.UnmanagedReason,
+				status.ConditionPrometheusOperatorReady: status.UnmanagedReason,
+				status.ConditionKEDAReady:               status.UnmanagedReason,
 			},
 		},
 		{
 			name: "managed GatewayAPI without deployments or CR is True",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Managed},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Managed},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			expectedStatus: map[string]metav1.ConditionStatus{
 				status.ConditionGatewayAPIReady: metav1.ConditionTrue,
@@ -99,10 +114,13 @@ func TestMonitorDependencies(t *testing.T) {
 		{
 			name: "deployment not ready is False",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			objects: func(_ string) []client.Object {
 				return []client.Object{
@@ -119,10 +137,13 @@ func TestMonitorDependencies(t *testing.T) {
 		{
 			name: "deployment ready is True",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			objects: func(_ string) []client.Object {
 				return []client.Object{
@@ -133,6 +154,54 @@ func TestMonitorDependencies(t *testing.T) {
 				status.ConditionCertManagerReady: metav1.ConditionTrue,
 			},
 		},
+		{
+			name: "KEDA deployment not ready in custom namespace is False",
+			dependencies: ccmcommon.Dependencies{
+				KEDA: ccmcommon.KEDADependency{
+					ManagementPolicy: ccmcommon.Managed,
+					Configuration:    ccmcommon.KEDAConfiguration{Namespace: "custom-keda"},
+				},
+			},
+			objects: func(_ string) []client.Object {
+				return []client.Object{
+					newDeployment("custom-metrics-autoscaler-operator", "custom-keda", 0),
+				}
+			},
+			expectedStatus: map[string]metav1.ConditionStatus{
+				status.ConditionKEDAReady: metav1.ConditionFalse,
+			},
+			expectedReasons: map[string]string{
+				status.ConditionKEDAReady: status.ConditionDeploymentsNotAvailableReason,
+			},
+		},
+		{
+			name: "auto dependency with an existing installation is adopted",
+			dependencies: ccmcommon.Dependencies{
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Auto},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
+			},
+			objects: func(_ string) []client.Object {
+				return []client.Object{
+					&apiextensionsv1.CustomResourceDefinition{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "leaderworkersets.leaderworkerset.x-k8s.io",
+							Labels: map[string]string{"app.kubernetes.io/version": "v0.6.1"},
+						},
+					},
+				}
+			},
+			expectedStatus: map[string]metav1.ConditionStatus{
+				status.ConditionLWSReady: metav1.ConditionTrue,
+			},
+			expectedReasons: map[string]string{
+				status.ConditionLWSReady: externalReason,
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -282,7 +351,7 @@ func TestMonitorDependencies_OperatorCR(t *testing.T) {
 				},
 			}
 
-			err = monitorDependencies(ctx, rr, testResourceID, configs)
+			err = monitorDependencies(ctx, rr, rr.Client, testResourceID, configs)
 			g.Expect(err).ShouldNot(HaveOccurred())
 
 			cond := rr.Conditions.GetCondition(conditionType)
@@ -312,20 +381,26 @@ func TestSummarizeDependencyStatus(t *testing.T) {
 		{
 			name: "all ready sets DependenciesReady and Ready True",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			expectedReadyStatus: metav1.ConditionTrue,
 		},
 		{
 			name: "single dependency not ready lists it in DependenciesReady message",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			objects: func(_ string) []client.Object {
 				return []client.Object{
@@ -339,10 +414,13 @@ func TestSummarizeDependencyStatus(t *testing.T) {
 		{
 			name: "multiple dependencies not ready lists all in DependenciesReady message",
 			dependencies: ccmcommon.Dependencies{
-				CertManager:  ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
-				GatewayAPI:   ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
-				LWS:          ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Managed},
-				SailOperator: ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				CertManager:        ccmcommon.CertManagerDependency{ManagementPolicy: ccmcommon.Managed},
+				GatewayAPI:         ccmcommon.GatewayAPIDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				LWS:                ccmcommon.LWSDependency{ManagementPolicy: ccmcommon.Managed},
+				SailOperator:       ccmcommon.SailOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				Kueue:              ccmcommon.KueueDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				PrometheusOperator: ccmcommon.PrometheusOperatorDependency{ManagementPolicy: ccmcommon.Unmanaged},
+				KEDA:               ccmcommon.KEDADependency{ManagementPolicy: ccmcommon.Unmanaged},
 			},
 			objects: func(ns string) []client.Object {
 				return []client.Object{
@@ -444,3 +522,283 @@ func corev1PodTemplate(label string) corev1.PodTemplateSpec {
 		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "c", Image: "busybox"}}},
 	}
 }
+
+func TestReportDependencyVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		cfg             ccmcharts.DependencyMonitorConfig
+		healthy         bool
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name:            "newest version installed",
+			cfg:             ccmcharts.DependencyMonitorConfig{Version: "1.1.0", AvailableVersion: "1.1.0"},
+			healthy:         true,
+			expectedMessage: "Version 1.1.0 installed",
+		},
+		{
+			name:            "older version installed reports the available upgrade",
+			cfg:             ccmcharts.DependencyMonitorConfig{Version: "1.0.0", AvailableVersion: "1.1.0"},
+			healthy:         true,
+			expectedReason:  upgradeAvailableReason,
+			expectedMessage: "Version 1.0.0 installed, version 1.1.0 available",
+		},
+		{
+			name:            "unhealthy dependency is left untouched",
+			cfg:             ccmcharts.DependencyMonitorConfig{Version: "1.0.0", AvailableVersion: "1.1.0"},
+			expectedReason:  status.ConditionDeploymentsNotAvailableReason,
+			expectedMessage: "not available",
+		},
+		{
+			name:            "unknown version is not reported",
+			cfg:             ccmcharts.DependencyMonitorConfig{},
+			healthy:         true,
+			expectedMessage: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			instance := &ccmv1alpha1.AzureKubernetesEngine{}
+			rr := &types.ReconciliationRequest{Instance: instance}
+			rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady, ConditionsTypes...)
+
+			tt.cfg.ConditionType = status.ConditionLWSReady
+			if tt.healthy {
+				rr.Conditions.MarkTrue(status.ConditionLWSReady)
+			} else {
+				rr.Conditions.MarkFalse(status.ConditionLWSReady,
+					conditions.WithReason(status.ConditionDeploymentsNotAvailableReason),
+					conditions.WithMessage("not available"),
+				)
+			}
+
+			reportDependencyVersion(rr, tt.cfg)
+
+			cond := rr.Conditions.GetCondition(status.ConditionLWSReady)
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Reason).To(Equal(tt.expectedReason))
+			g.Expect(cond.Message).To(Equal(tt.expectedMessage))
+		})
+	}
+}
+
+func TestMonitorExternalDependency(t *testing.T) {
+	external := func(name string, readyReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "lws-system"},
+			Status:     appsv1.DeploymentStatus{Replicas: 1, ReadyReplicas: readyReplicas},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		installation    ccmcharts.ExternalInstallation
+		objects         []client.Object
+		expectedStatus  metav1.ConditionStatus
+		expectedReason  string
+		expectedMessage string
+	}{
+		{
+			name: "healthy installation is adopted",
+			installation: ccmcharts.ExternalInstallation{
+				Version:     "v0.6.1",
+				Resources:   []string{"CustomResourceDefinition/leaderworkersets.leaderworkerset.x-k8s.io", "Deployment/lws-system/lws-controller-manager"},
+				Deployments: []k8stypes.NamespacedName{{Namespace: "lws-system", Name: "lws-controller-manager"}},
+			},
+			objects:        []client.Object{external("lws-controller-manager", 1)},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: externalReason,
+			expectedMessage: "Existing installation adopted (version v0.6.1): " +
+				"CustomResourceDefinition/leaderworkersets.leaderworkerset.x-k8s.io, Deployment/lws-system/lws-controller-manager",
+		},
+		{
+			name: "installation without version is adopted",
+			installation: ccmcharts.ExternalInstallation{
+				Resources: []string{"CustomResourceDefinition/leaderworkersets.leaderworkerset.x-k8s.io"},
+			},
+			expectedStatus:  metav1.ConditionTrue,
+			expectedReason:  externalReason,
+			expectedMessage: "Existing installation adopted (version unknown): CustomResourceDefinition/leaderworkersets.leaderworkerset.x-k8s.io",
+		},
+		{
+			name: "incompatible installation is False",
+			installation: ccmcharts.ExternalInstallation{
+				Version:      "v0.4.0",
+				Incompatible: "version v0.4.0 is older than the minimum supported version v0.5.0",
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  incompatibleVersionReason,
+			expectedMessage: "Existing installation cannot be adopted: version v0.4.0 is older than the minimum supported version v0.5.0",
+		},
+		{
+			name: "installation with a deployment not ready is False",
+			installation: ccmcharts.ExternalInstallation{
+				Deployments: []k8stypes.NamespacedName{{Namespace: "lws-system", Name: "lws-controller-manager"}},
+			},
+			objects:         []client.Object{external("lws-controller-manager", 0)},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  dependencyDegradedReason,
+			expectedMessage: "Existing installation deployments not ready: lws-system/lws-controller-manager",
+		},
+		{
+			name: "installation with a deployment gone is False",
+			installation: ccmcharts.ExternalInstallation{
+				Deployments: []k8stypes.NamespacedName{{Namespace: "lws-system", Name: "lws-controller-manager"}},
+			},
+			expectedStatus:  metav1.ConditionFalse,
+			expectedReason:  dependencyDegradedReason,
+			expectedMessage: "Existing installation deployments not ready: lws-system/lws-controller-manager",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cl, err := fakeclient.New(fakeclient.WithObjects(tt.objects...))
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			instance := &ccmv1alpha1.AzureKubernetesEngine{}
+			rr := &types.ReconciliationRequest{Client: cl, Instance: instance}
+			rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady, ConditionsTypes...)
+
+			err = monitorExternalDependency(t.Context(), rr, cl, ccmcharts.DependencyMonitorConfig{
+				ReleaseName:   "lws-operator",
+				ConditionType: status.ConditionLWSReady,
+				Policy:        ccmcommon.Auto,
+				External:      &tt.installation,
+			})
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			cond := rr.Conditions.GetCondition(status.ConditionLWSReady)
+			g.Expect(cond).NotTo(BeNil())
+			g.Expect(cond.Status).To(Equal(tt.expectedStatus))
+			g.Expect(cond.Reason).To(Equal(tt.expectedReason))
+			g.Expect(cond.Message).To(Equal(tt.expectedMessage))
+		})
+	}
+}
+
+func TestReportAutoInstall(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := &ccmv1alpha1.AzureKubernetesEngine{}
+	rr := &types.ReconciliationRequest{Instance: instance}
+	rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady, ConditionsTypes...)
+
+	cfg := ccmcharts.DependencyMonitorConfig{
+		ConditionType:    status.ConditionLWSReady,
+		Policy:           ccmcommon.Auto,
+		Version:          "1.1.0",
+		AvailableVersion: "1.1.0",
+	}
+
+	rr.Conditions.MarkTrue(status.ConditionLWSReady)
+	reportDependencyVersion(rr, cfg)
+	reportAutoInstall(rr, cfg)
+
+	cond := rr.Conditions.GetCondition(status.ConditionLWSReady)
+	g.Expect(cond).NotTo(BeNil())
+	g.Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+	g.Expect(cond.Message).To(Equal("No existing installation found, installed by the operator: Version 1.1.0 installed"))
+}
+
+func TestReportDependenciesStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	instance := &ccmv1alpha1.AzureKubernetesEngine{}
+	rr := &types.ReconciliationRequest{Instance: instance}
+	rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady, ConditionsTypes...)
+
+	configs := []ccmcharts.DependencyMonitorConfig{
+		{
+			Name:             "certManager",
+			ConditionType:    status.ConditionCertManagerReady,
+			Policy:           ccmcommon.Managed,
+			ChartState:       ccmcommon.ChartStateManaged,
+			Namespace:        ccmcommon.DefaultNamespaceCertManagerOperator,
+			Version:          "1.17.0",
+			AvailableVersion: "1.18.0",
+		},
+		{
+			Name:          "lws",
+			ConditionType: status.ConditionLWSReady,
+			Policy:        ccmcommon.Unmanaged,
+			ChartState:    ccmcommon.ChartStateCleaning,
+			Namespace:     ccmcommon.DefaultNamespaceLWSOperator,
+		},
+	}
+
+	rr.Conditions.MarkTrue(status.ConditionCertManagerReady, conditions.WithReason(upgradeAvailableReason))
+	rr.Conditions.MarkFalse(status.ConditionLWSReady,
+		conditions.WithReason(dependencyDegradedReason),
+		conditions.WithMessage("deployment lws-controller-manager not ready"))
+
+	reportDependenciesStatus(rr, configs, "", nil)
+
+	g.Expect(instance.GetDependenciesStatus()).To(Equal(map[string]ccmcommon.DependencyStatus{
+		"certManager": {
+			ManagementPolicy: ccmcommon.Managed,
+			ChartState:       ccmcommon.ChartStateManaged,
+			Namespace:        ccmcommon.DefaultNamespaceCertManagerOperator,
+			Version:          "1.17.0",
+			AvailableVersion: "1.18.0",
+			Ready:            metav1.ConditionTrue,
+			Reason:           upgradeAvailableReason,
+		},
+		"lws": {
+			ManagementPolicy: ccmcommon.Unmanaged,
+			ChartState:       ccmcommon.ChartStateCleaning,
+			Namespace:        ccmcommon.DefaultNamespaceLWSOperator,
+			Ready:            metav1.ConditionFalse,
+			Reason:           dependencyDegradedReason,
+			LastError:        "deployment lws-controller-manager not ready",
+		},
+	}))
+}
+
+func TestMonitorDependenciesCheckError(t *testing.T) {
+	g := NewWithT(t)
+
+	cl, err := fakeclient.New(fakeclient.WithInterceptorFuncs(interceptor.Funcs{
+		List: func(context.Context, client.WithWatch, client.ObjectList, ...client.ListOption) error {
+			return errors.New("boom")
+		},
+	}))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	instance := &ccmv1alpha1.AzureKubernetesEngine{}
+	rr := &types.ReconciliationRequest{Client: cl, Instance: instance}
+	rr.Conditions = conditions.NewManager(instance, status.ConditionTypeReady, ConditionsTypes...)
+
+	configs := []ccmcharts.DependencyMonitorConfig{
+		{Name: "certManager", ConditionType: status.ConditionCertManagerReady, Policy: ccmcommon.Unmanaged},
+		{
+			Name:           "lws",
+			ReleaseName:    "lws-operator",
+			ConditionType:  status.ConditionLWSReady,
+			Policy:         ccmcommon.Managed,
+			HasDeployments: true,
+			Namespace:      ccmcommon.DefaultNamespaceLWSOperator,
+		},
+	}
+
+	err = monitorDependencies(t.Context(), rr, cl, testResourceID, configs)
+	g.Expect(err).To(MatchError(ContainSubstring("deployment check for lws-operator failed")))
+
+	// the status records the error of the dependency that could not be checked
+	g.Expect(instance.GetDependenciesStatus()).To(And(
+		HaveKeyWithValue("certManager", And(
+			HaveField("Ready", metav1.ConditionTrue),
+			HaveField("LastError", BeEmpty()),
+		)),
+		HaveKeyWithValue("lws", And(
+			HaveField("Ready", metav1.ConditionUnknown),
+			HaveField("LastError", And(ContainSubstring("deployment check for lws-operator failed"), ContainSubstring("boom"))),
+		)),
+	))
+}
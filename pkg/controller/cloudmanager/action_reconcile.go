@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	ccmcommon "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/common"
 	ccmcharts "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/common"
@@ -28,6 +31,30 @@ type reconcileAction struct {
 	deployOpts    []deploy.ActionOpts
 	resourceID    string
 	buildChartsFn func(context.Context, *types.ReconciliationRequest) (ccmcharts.BuildResult, error)
+	overrides     []ChartOverride
+	apiReader     client.Reader
+}
+
+// ChartOverride tailors a dependency chart to a provider. Overrides are the
+// only way providers customize the dependencies, so that every provider shares
+// the same reconciliation logic.
+type ChartOverride struct {
+	// ReleaseName selects the chart the override applies to. When empty, the
+	// override applies to every chart.
+	ReleaseName string
+
+	// Values returns the values to deep merge over the chart values. It is
+	// evaluated on each reconciliation, so it may depend on the instance.
+	Values func(context.Context, *types.ReconciliationRequest) (map[string]any, error)
+
+	// PostRenderers returns the post renderers to run on the rendered
+	// resources, after the ones of the chart. It is evaluated on each
+	// reconciliation, so it may depend on the instance.
+	PostRenderers func(context.Context, *types.ReconciliationRequest) ([]engineTypes.PostRenderer, error)
+
+	// PreApply and PostApply hooks run after the ones of the chart.
+	PreApply  []types.HookFn
+	PostApply []types.HookFn
 }
 
 // ReconcileActionOpts configures the combined reconcile action.
@@ -55,7 +82,32 @@ func WithBuildChartsFn(fn func(context.Context, *types.ReconciliationRequest) (c
 	}
 }
 
-func buildCharts(ctx context.Context, rr *types.ReconciliationRequest) (ccmcharts.BuildResult, error) {
+// WithChartOverrides adds provider-specific chart overrides, applied in order.
+func WithChartOverrides(overrides ...ChartOverride) ReconcileActionOpts {
+	return func(a *reconcileAction) {
+		a.overrides = append(a.overrides, overrides...)
+	}
+}
+
+// WithAPIReader sets the uncached reader used to detect and monitor existing
+// installations of the dependencies with the Auto policy, whose resources are
+// not in the cache of the manager. It defaults to the client of the request.
+func WithAPIReader(reader client.Reader) ReconcileActionOpts {
+	return func(a *reconcileAction) {
+		a.apiReader = reader
+	}
+}
+
+// reader returns the reader for resources not managed by the cloud manager.
+func (a *reconcileAction) reader(rr *types.ReconciliationRequest) client.Reader {
+	if a.apiReader != nil {
+		return a.apiReader
+	}
+
+	return rr.Client
+}
+
+func (a *reconcileAction) buildCharts(ctx context.Context, rr *types.ReconciliationRequest) (ccmcharts.BuildResult, error) {
 	if rr.ChartsBasePath == "" {
 		return ccmcharts.BuildResult{}, errors.New("ChartsBasePath must not be empty")
 	}
@@ -65,7 +117,60 @@ func buildCharts(ctx context.Context, rr *types.ReconciliationRequest) (ccmchart
 		return ccmcharts.BuildResult{}, fmt.Errorf("instance %T does not implement KubernetesEngineInstance", rr.Instance)
 	}
 
-	return ccmcharts.BuildHelmCharts(ctx, rr.Client, dp.GetDependencies(), rr.ChartsBasePath)
+	return ccmcharts.BuildHelmCharts(ctx, rr.Client, dp.GetDependencies(), rr.ChartsBasePath,
+		ccmcharts.WithAPIReader(a.reader(rr)),
+	)
+}
+
+// applyChartOverrides applies the overrides to the charts to deploy. Values and
+// post renderers are also applied to the charts to clean up, so that they
+// render the same resources as when they were deployed.
+func applyChartOverrides(
+	ctx context.Context,
+	rr *types.ReconciliationRequest,
+	result ccmcharts.BuildResult,
+	overrides []ChartOverride,
+) (ccmcharts.BuildResult, error) {
+	for _, o := range overrides {
+		if o.Values != nil {
+			values, err := o.Values(ctx, rr)
+			if err != nil {
+				return ccmcharts.BuildResult{}, fmt.Errorf("failed to compute chart values override: %w", err)
+			}
+
+			result.Charts = ccmcharts.WithValues(result.Charts, o.ReleaseName, values)
+			result.CleanupCharts = ccmcharts.WithValues(result.CleanupCharts, o.ReleaseName, values)
+		}
+
+		if o.PostRenderers != nil {
+			renderers, err := o.PostRenderers(ctx, rr)
+			if err != nil {
+				return ccmcharts.BuildResult{}, fmt.Errorf("failed to compute chart post renderers override: %w", err)
+			}
+
+			result.Charts = ccmcharts.WithPostRenderers(result.Charts, o.ReleaseName, renderers...)
+			result.CleanupCharts = ccmcharts.WithPostRenderers(result.CleanupCharts, o.ReleaseName, renderers...)
+		}
+
+		if len(o.PreApply) == 0 && len(o.PostApply) == 0 {
+			continue
+		}
+
+		charts := make([]types.HelmChartInfo, len(result.Charts))
+
+		for i, c := range result.Charts {
+			if o.ReleaseName == "" || c.ReleaseName == o.ReleaseName {
+				c.PreApply = append(slices.Clone(c.PreApply), o.PreApply...)
+				c.PostApply = append(slices.Clone(c.PostApply), o.PostApply...)
+			}
+
+			charts[i] = c
+		}
+
+		result.Charts = charts
+	}
+
+	return result, nil
 }
 
 func filterCRs(resources []unstructured.Unstructured, crs []types.OperatorCR) []unstructured.Unstructured {
@@ -117,6 +222,7 @@ func filterCRs(resources []unstructured.Unstructured, crs []types.OperatorCR) []
 // - Builds the chart list (with two-phase cleanup for Unmanaged dependencies)
 // - Renders Helm charts
 // - Filters operator CRs from Phase 1 cleanup charts
+// - Rewrites images according to the mirror rules and reports the unmirrored ones
 // - Runs PreApply hooks from HelmCharts
 // - Deploys resources via SSA
 // - Runs PostApply hooks from HelmCharts
@@ -131,14 +237,17 @@ func NewReconcileAction(resourceID string, opts ...ReconcileActionOpts) (actions
 	}
 
 	action := reconcileAction{
-		resourceID:    resourceID,
-		buildChartsFn: buildCharts,
+		resourceID: resourceID,
 	}
 
 	for _, opt := range opts {
 		opt(&action)
 	}
 
+	if action.buildChartsFn == nil {
+		action.buildChartsFn = action.buildCharts
+	}
+
 	helmRender := helm.NewAction(action.helmOpts...)
 	deployAction := deploy.NewAction(append(action.deployOpts,
 		deploy.WithApplyOrder(),
@@ -153,6 +262,11 @@ func NewReconcileAction(resourceID string, opts ...ReconcileActionOpts) (actions
 			return err
 		}
 
+		result, err = applyChartOverrides(ctx, rr, result, action.overrides)
+		if err != nil {
+			return err
+		}
+
 		rr.HelmCharts = append(rr.HelmCharts, result.Charts...)
 
 		if err := helmRender(ctx, rr); err != nil {
@@ -161,6 +275,20 @@ func NewReconcileAction(resourceID string, opts ...ReconcileActionOpts) (actions
 
 		rr.Resources = filterCRs(rr.Resources, result.FilterCRs)
 
+		if ke, ok := rr.Instance.(ccmcommon.KubernetesEngineInstance); ok {
+			mirrors, err := imageMirrors(ctx, action.reader(rr), ke)
+			if err != nil {
+				return err
+			}
+
+			unmirrored, err := mirrorImages(rr.Resources, mirrors)
+			if err != nil {
+				return err
+			}
+
+			reportUnmirroredImages(rr, mirrors, unmirrored)
+		}
+
 		// Execute PreApply hooks
 		err = runHooks(ctx, rr, func(c *types.HelmChartInfo) []types.HookFn {
 			return c.PreApply
@@ -186,7 +314,7 @@ func NewReconcileAction(resourceID string, opts ...ReconcileActionOpts) (actions
 			return err
 		}
 
-		if err := monitorDependencies(ctx, rr, action.resourceID, result.MonitorConfigs); err != nil {
+		if err := monitorDependencies(ctx, rr, action.reader(rr), action.resourceID, result.MonitorConfigs); err != nil {
 			return err
 		}
 
@@ -1,11 +1,17 @@
-//nolint:testpackage // white-box tests for unexported filterCRs
+//nolint:testpackage // white-box tests for unexported filterCRs and applyChartOverrides
 package cloudmanager
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	helmRenderer "github.com/k8s-manifest-kit/renderer-helm/pkg"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	ccmcharts "github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/cloudmanager/common"
+
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
 
@@ -196,3 +202,113 @@ func TestFilterCRs(t *testing.T) {
 		g.Expect(result).To(HaveLen(1))
 	})
 }
+
+func TestApplyChartOverrides(t *testing.T) {
+	ctx := t.Context()
+
+	newChart := func(name string) types.HelmChartInfo {
+		c := types.HelmChartInfo{}
+		c.ReleaseName = name
+		c.Values = helmRenderer.Values(map[string]any{"name": name})
+
+		return c
+	}
+
+	noopHook := func(context.Context, *types.ReconciliationRequest) error { return nil }
+
+	build := func() ccmcharts.BuildResult {
+		return ccmcharts.BuildResult{
+			Charts:        []types.HelmChartInfo{newChart("a"), newChart("b")},
+			CleanupCharts: []types.HelmChartInfo{newChart("c")},
+		}
+	}
+
+	t.Run("no overrides returns the charts unchanged", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := applyChartOverrides(ctx, &types.ReconciliationRequest{}, build(), nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(result.Charts).To(HaveLen(2))
+		g.Expect(result.CleanupCharts).To(HaveLen(1))
+	})
+
+	t.Run("values apply to every chart when release name is empty", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := applyChartOverrides(ctx, &types.ReconciliationRequest{}, build(), []ChartOverride{{
+			Values: func(context.Context, *types.ReconciliationRequest) (map[string]any, error) {
+				return map[string]any{"provider": "test"}, nil
+			},
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		for _, c := range append(result.Charts, result.CleanupCharts...) {
+			values, err := c.Values(ctx)
+			g.Expect(err).NotTo(HaveOccurred())
+			g.Expect(values).To(HaveKeyWithValue("provider", "test"))
+			g.Expect(values).To(HaveKeyWithValue("name", c.ReleaseName))
+		}
+	})
+
+	t.Run("post renderers apply to the charts to deploy and to clean up", func(t *testing.T) {
+		g := NewWithT(t)
+
+		renderer := func(_ context.Context, objects []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+			return objects, nil
+		}
+
+		result, err := applyChartOverrides(ctx, &types.ReconciliationRequest{}, build(), []ChartOverride{{
+			PostRenderers: func(context.Context, *types.ReconciliationRequest) ([]engineTypes.PostRenderer, error) {
+				return []engineTypes.PostRenderer{renderer}, nil
+			},
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		for _, c := range append(result.Charts, result.CleanupCharts...) {
+			g.Expect(c.PostRenderers).To(HaveLen(1), "chart %s", c.ReleaseName)
+		}
+	})
+
+	t.Run("hooks apply to the selected chart only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		result, err := applyChartOverrides(ctx, &types.ReconciliationRequest{}, build(), []ChartOverride{{
+			ReleaseName: "b",
+			PreApply:    []types.HookFn{noopHook},
+			PostApply:   []types.HookFn{noopHook, noopHook},
+		}})
+		g.Expect(err).NotTo(HaveOccurred())
+
+		g.Expect(result.Charts[0].PreApply).To(BeEmpty())
+		g.Expect(result.Charts[0].PostApply).To(BeEmpty())
+		g.Expect(result.Charts[1].PreApply).To(HaveLen(1))
+		g.Expect(result.Charts[1].PostApply).To(HaveLen(2))
+		g.Expect(result.CleanupCharts[0].PreApply).To(BeEmpty())
+	})
+
+	t.Run("post renderers errors are returned", func(t *testing.T) {
+		g := NewWithT(t)
+
+		renderersErr := errors.New("boom")
+
+		_, err := applyChartOverrides(ctx, &types.ReconciliationRequest{}, build(), []ChartOverride{{
+			PostRenderers: func(context.Context, *types.ReconciliationRequest) ([]engineTypes.PostRenderer, error) {
+				return nil, renderersErr
+			},
+		}})
+		g.Expect(err).To(MatchError(renderersErr))
+	})
+
+	t.Run("values errors are returned", func(t *testing.T) {
+		g := NewWithT(t)
+
+		valuesErr := errors.New("boom")
+
+		_, err := applyChartOverrides(ctx, &types.ReconciliationRequest{}, build(), []ChartOverride{{
+			Values: func(context.Context, *types.ReconciliationRequest) (map[string]any, error) {
+				return nil, valuesErr
+			},
+		}})
+		g.Expect(err).To(MatchError(valuesErr))
+	})
+}
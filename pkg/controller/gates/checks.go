@@ -0,0 +1,198 @@
+package gates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	configApi "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+)
+
+// AcksAcknowledgedCheck is the name of the preflight check passing once every
+// entry of the odh-upgrade-acks ConfigMap in the operator namespace is
+// acknowledged. It lets an UpgradeGate wait for the admin acknowledgments of
+// the ConfigMap gates.
+const AcksAcknowledgedCheck = "upgrade-acks-acknowledged"
+
+func init() {
+	RegisterCheck(AcksAcknowledgedCheck, checkAcksAcknowledged)
+}
+
+// PreflightCheck is an automated upgrade gate check. It returns whether the
+// check passed and, if not, a message explaining why.
+type PreflightCheck func(ctx context.Context, cli client.Client) (bool, string, error)
+
+var (
+	checksMu sync.RWMutex
+	checks   = map[string]PreflightCheck{}
+)
+
+// RegisterCheck makes a preflight check available to UpgradeGate resources
+// under the given name. It is meant to be called from init functions and
+// panics if a check with the same name is already registered.
+func RegisterCheck(name string, check PreflightCheck) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+
+	if _, ok := checks[name]; ok {
+		panic(fmt.Sprintf("preflight check %q already registered", name))
+	}
+
+	checks[name] = check
+}
+
+// LookupCheck returns the preflight check registered under the given name.
+func LookupCheck(name string) (PreflightCheck, bool) {
+	checksMu.RLock()
+	defer checksMu.RUnlock()
+
+	check, ok := checks[name]
+
+	return check, ok
+}
+
+// RegisteredChecks returns the sorted names of the registered preflight checks.
+func RegisteredChecks() []string {
+	checksMu.RLock()
+	defer checksMu.RUnlock()
+
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func checkAcksAcknowledged(ctx context.Context, cli client.Client) (bool, string, error) {
+	ns, err := cluster.GetOperatorNamespace()
+	if err != nil {
+		return false, "", err
+	}
+
+	unacked, err := NewGateChecker(cli, ns).UnacknowledgedGates(ctx)
+	if err != nil {
+		return false, "", err
+	}
+
+	if len(unacked) == 0 {
+		return true, "", nil
+	}
+
+	keys := make([]string, 0, len(unacked))
+	for _, g := range unacked {
+		keys = append(keys, g.Key)
+	}
+
+	return false, fmt.Sprintf("%s entries not acknowledged: %s", AcksConfigMap, strings.Join(keys, ", ")), nil
+}
+
+func newCELProgram(expression string) (cel.Program, error) {
+	env, err := cel.NewEnv(cel.Variable("object", cel.DynType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	ast, iss := env.Compile(expression)
+	if iss.Err() != nil {
+		return nil, fmt.Errorf("invalid CEL expression: %w", iss.Err())
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("invalid CEL expression: must evaluate to bool, got %s", ast.OutputType())
+	}
+
+	prg, err := env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CEL expression: %w", err)
+	}
+
+	return prg, nil
+}
+
+// ValidatePreflight verifies that the given preflight check can be evaluated,
+// that is the CEL expression compiles or the named check is registered.
+func ValidatePreflight(p *configApi.UpgradeGatePreflight) error {
+	switch {
+	case p == nil:
+		return nil
+	case p.CEL != nil && p.Check != "":
+		return errors.New("exactly one of cel or check must be set")
+	case p.CEL != nil:
+		_, err := newCELProgram(p.CEL.Expression)
+		return err
+	case p.Check != "":
+		if _, ok := LookupCheck(p.Check); !ok {
+			return fmt.Errorf("unknown preflight check %q, registered: %v", p.Check, RegisteredChecks())
+		}
+		return nil
+	default:
+		return errors.New("exactly one of cel or check must be set")
+	}
+}
+
+// runPreflight evaluates the given preflight check, the resource of a CEL
+// check is read with the given reader.
+func runPreflight(ctx context.Context, cli client.Client, reader client.Reader, p *configApi.UpgradeGatePreflight) (bool, string, error) {
+	if err := ValidatePreflight(p); err != nil {
+		return false, "", err
+	}
+
+	if p.Check != "" {
+		check, _ := LookupCheck(p.Check)
+		return check(ctx, cli)
+	}
+
+	return runCELCheck(ctx, reader, p.CEL)
+}
+
+func runCELCheck(ctx context.Context, reader client.Reader, c *configApi.UpgradeGateCELCheck) (bool, string, error) {
+	prg, err := newCELProgram(c.Expression)
+	if err != nil {
+		return false, "", err
+	}
+
+	obj := unstructured.Unstructured{}
+	obj.SetAPIVersion(c.Resource.APIVersion)
+	obj.SetKind(c.Resource.Kind)
+
+	var object any
+
+	err = reader.Get(ctx, client.ObjectKey{Namespace: c.Resource.Namespace, Name: c.Resource.Name}, &obj)
+	switch {
+	case k8serr.IsNotFound(err):
+		object = types.NullValue
+	case err != nil:
+		return false, "", fmt.Errorf("failed to get %s %s: %w", c.Resource.Kind, c.Resource.Name, err)
+	default:
+		object = obj.Object
+	}
+
+	out, _, err := prg.ContextEval(ctx, map[string]any{"object": object})
+	if err != nil {
+		return false, "", fmt.Errorf("failed to evaluate CEL expression: %w", err)
+	}
+
+	passed, ok := out.Value().(bool)
+	if !ok {
+		return false, "", fmt.Errorf("CEL expression evaluated to %T, expected bool", out.Value())
+	}
+
+	if !passed {
+		return false, fmt.Sprintf("expression %q evaluated to false", c.Expression), nil
+	}
+
+	return true, "", nil
+}
@@ -31,12 +31,39 @@ const (
 // ConfigMap; admin acknowledges by setting a gate's value to "true".
 type GateChecker struct {
 	client    client.Client
+	apiReader client.Reader
 	namespace string
 }
 
+// GateCheckerOpt configures a GateChecker.
+type GateCheckerOpt func(*GateChecker)
+
+// WithAPIReader sets the uncached reader used to get the resources evaluated
+// by the CEL preflight checks, which are arbitrary and must not start an
+// informer in the cache of the manager. It defaults to the client.
+func WithAPIReader(reader client.Reader) GateCheckerOpt {
+	return func(gc *GateChecker) {
+		gc.apiReader = reader
+	}
+}
+
 // NewGateChecker creates a new GateChecker for the given namespace.
-func NewGateChecker(cli client.Client, namespace string) *GateChecker {
-	return &GateChecker{client: cli, namespace: namespace}
+func NewGateChecker(cli client.Client, namespace string, opts ...GateCheckerOpt) *GateChecker {
+	gc := &GateChecker{client: cli, namespace: namespace}
+	for _, opt := range opts {
+		opt(gc)
+	}
+
+	return gc
+}
+
+// reader returns the reader for the resources evaluated by the preflight checks.
+func (gc *GateChecker) reader() client.Reader {
+	if gc.apiReader != nil {
+		return gc.apiReader
+	}
+
+	return gc.client
 }
 
 // UnackedGate represents an upgrade gate that has not been acknowledged.
@@ -140,13 +167,60 @@ func (gc *GateChecker) collectUnacked(cm *corev1.ConfigMap, filtered map[string]
 		}
 	}
 
-	sort.Slice(unacked, func(i, j int) bool {
-		return unacked[i].Key < unacked[j].Key
-	})
+	sortGates(unacked)
 
 	return unacked
 }
 
+func sortGates(gates []UnackedGate) {
+	sort.Slice(gates, func(i, j int) bool {
+		return gates[i].Key < gates[j].Key
+	})
+}
+
+// PendingGates is the read-only variant of EnsureGates: it returns the gates
+// matching the version prefix "ack-<version>-" that are not acknowledged in
+// the odh-upgrade-acks ConfigMap, without writing their descriptions.
+func (gc *GateChecker) PendingGates(ctx context.Context, gateEntries map[string]string, version string) ([]UnackedGate, error) {
+	if version == "" {
+		return nil, errors.New("version must not be empty")
+	}
+
+	versionPrefix := "ack-" + version + "-"
+
+	filtered := make(map[string]string)
+	for k, v := range gateEntries {
+		if strings.HasPrefix(k, versionPrefix) {
+			filtered[k] = v
+		}
+	}
+
+	if len(filtered) == 0 {
+		return nil, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := gc.client.Get(ctx, client.ObjectKey{Name: AcksConfigMap, Namespace: gc.namespace}, cm)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get %s ConfigMap: %w", AcksConfigMap, err)
+	}
+
+	return gc.collectUnacked(cm, filtered), nil
+}
+
+// UnacknowledgedGates returns all the entries of the odh-upgrade-acks
+// ConfigMap that are not acknowledged, whatever their version. No gate is
+// returned if the ConfigMap does not exist.
+func (gc *GateChecker) UnacknowledgedGates(ctx context.Context) ([]UnackedGate, error) {
+	cm := &corev1.ConfigMap{}
+	err := gc.client.Get(ctx, client.ObjectKey{Name: AcksConfigMap, Namespace: gc.namespace}, cm)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get %s ConfigMap: %w", AcksConfigMap, err)
+	}
+
+	return gc.collectUnacked(cm, cm.Data), nil
+}
+
 // IsGateConfigMap returns true if the given ConfigMap has the upgrade gate
 // label, indicating it should be extracted during chart rendering.
 func IsGateConfigMap(cm *corev1.ConfigMap) bool {
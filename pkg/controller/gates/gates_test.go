@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -179,6 +180,57 @@ func TestEnsureGates_LeavesStaleAcks(t *testing.T) {
 	assert.Equal(t, "New gate", cm.Data["ack-2.0.0-new-gate"])
 }
 
+func TestPendingGates_DoesNotWrite(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	gc := gates.NewGateChecker(cli, testNamespace)
+
+	unacked, err := gc.PendingGates(context.Background(), map[string]string{
+		"ack-2.0.0-api-change": "API changed; review migration guide",
+		"ack-1.0.0-old":        "Old gate",
+	}, "2.0.0")
+
+	require.NoError(t, err)
+	require.Len(t, unacked, 1)
+	assert.Equal(t, "ack-2.0.0-api-change", unacked[0].Key)
+
+	err = cli.Get(context.Background(), acksObjectKey(), &corev1.ConfigMap{})
+	assert.True(t, k8serr.IsNotFound(err), "acks ConfigMap must not be created")
+}
+
+func TestUnacknowledgedGates(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().WithScheme(scheme).Build()
+	gc := gates.NewGateChecker(cli, testNamespace)
+
+	unacked, err := gc.UnacknowledgedGates(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, unacked)
+
+	require.NoError(t, cli.Create(context.Background(), &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: gates.AcksConfigMap, Namespace: testNamespace},
+		Data: map[string]string{
+			"ack-2.0.0-api-change": "API changed",
+			"ack-2.0.0-storage":    "true",
+			"ack-1.0.0-old":        "Old gate",
+		},
+	}))
+
+	unacked, err = gc.UnacknowledgedGates(context.Background())
+	require.NoError(t, err)
+	require.Len(t, unacked, 2)
+	assert.Equal(t, "ack-1.0.0-old", unacked[0].Key)
+	assert.Equal(t, "ack-2.0.0-api-change", unacked[1].Key)
+}
+
 func TestIsGateConfigMap(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,175 @@
+package gates
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	configApi "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+)
+
+// AppliesTo returns true if the given gate applies to the given operator
+// version.
+func AppliesTo(gate *configApi.UpgradeGate, version string) (bool, error) {
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return false, fmt.Errorf("invalid version %q: %w", version, err)
+	}
+
+	r, err := semver.ParseRange(gate.Spec.Versions)
+	if err != nil {
+		return false, fmt.Errorf("invalid versions range %q: %w", gate.Spec.Versions, err)
+	}
+
+	return r(v), nil
+}
+
+// EvaluateUpgradeGates evaluates the UpgradeGate resources applying to the
+// given version. Gates with a preflight check are cleared once the check
+// passes, the others once an admin acknowledges them. The outcome is recorded
+// in the Cleared condition of each gate, and the blocking gates that are not
+// cleared are returned. No gate is returned if the UpgradeGate CRD is not
+// installed.
+func (gc *GateChecker) EvaluateUpgradeGates(ctx context.Context, version string) ([]UnackedGate, error) {
+	log := logf.FromContext(ctx)
+
+	pending, advisory, err := gc.evaluateUpgradeGates(ctx, version, true)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, g := range advisory {
+		log.Info("upgrade gate not cleared", "gate", g.Key, "message", g.Message)
+	}
+
+	return pending, nil
+}
+
+// PreviewUpgradeGates is the read-only variant of EvaluateUpgradeGates: the
+// gates are evaluated but their status is left untouched. Both the blocking
+// and the non-blocking gates that are not cleared are returned.
+func (gc *GateChecker) PreviewUpgradeGates(ctx context.Context, version string) ([]UnackedGate, []UnackedGate, error) {
+	return gc.evaluateUpgradeGates(ctx, version, false)
+}
+
+func (gc *GateChecker) evaluateUpgradeGates(ctx context.Context, version string, record bool) ([]UnackedGate, []UnackedGate, error) {
+	var list configApi.UpgradeGateList
+	if err := gc.client.List(ctx, &list); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to list upgrade gates: %w", err)
+	}
+
+	var pending []UnackedGate
+	var advisory []UnackedGate
+
+	for i := range list.Items {
+		gate := &list.Items[i]
+
+		cond := gc.evaluateUpgradeGate(ctx, gate, version)
+		if cond == nil {
+			continue
+		}
+
+		cond.ObservedGeneration = gate.Generation
+
+		// The gates are evaluated by several reconcilers, so the status is
+		// patched rather than updated to not fail on conflicts between them.
+		if record {
+			original := gate.DeepCopy()
+			if conditions.SetStatusCondition(gate, *cond) {
+				gate.Status.ObservedGeneration = gate.Generation
+				if err := gc.client.Status().Patch(ctx, gate, client.MergeFrom(original)); err != nil {
+					return nil, nil, fmt.Errorf("failed to patch status of upgrade gate %s: %w", gate.Name, err)
+				}
+			}
+		}
+
+		if cond.Status == metav1.ConditionTrue {
+			continue
+		}
+
+		if !gate.IsBlocking() {
+			advisory = append(advisory, UnackedGate{Key: gate.Name, Message: cond.Message})
+			continue
+		}
+
+		pending = append(pending, UnackedGate{Key: gate.Name, Message: cond.Message})
+	}
+
+	sortGates(pending)
+	sortGates(advisory)
+
+	return pending, advisory, nil
+}
+
+// evaluateUpgradeGate computes the Cleared condition of the given gate, nil is
+// returned if the gate does not apply to the given version.
+func (gc *GateChecker) evaluateUpgradeGate(ctx context.Context, gate *configApi.UpgradeGate, version string) *common.Condition {
+	applies, err := AppliesTo(gate, version)
+	if err != nil {
+		return &common.Condition{
+			Type:    status.ConditionUpgradeGateCleared,
+			Status:  metav1.ConditionFalse,
+			Reason:  status.InvalidUpgradeGateReason,
+			Message: err.Error(),
+		}
+	}
+
+	if !applies {
+		return nil
+	}
+
+	if gate.Spec.Preflight == nil {
+		if ack := gate.Spec.Acknowledgment; ack != nil {
+			return &common.Condition{
+				Type:    status.ConditionUpgradeGateCleared,
+				Status:  metav1.ConditionTrue,
+				Reason:  status.AcknowledgedReason,
+				Message: fmt.Sprintf("Acknowledged by %s", ack.AcknowledgedBy),
+			}
+		}
+
+		return &common.Condition{
+			Type:    status.ConditionUpgradeGateCleared,
+			Status:  metav1.ConditionFalse,
+			Reason:  status.AdminAckRequiredReason,
+			Message: gate.Spec.Message,
+		}
+	}
+
+	passed, msg, err := runPreflight(ctx, gc.client, gc.reader(), gate.Spec.Preflight)
+
+	switch {
+	case err != nil:
+		return &common.Condition{
+			Type:    status.ConditionUpgradeGateCleared,
+			Status:  metav1.ConditionFalse,
+			Reason:  status.InvalidUpgradeGateReason,
+			Message: fmt.Sprintf("%s: %v", gate.Spec.Message, err),
+		}
+	case !passed:
+		return &common.Condition{
+			Type:    status.ConditionUpgradeGateCleared,
+			Status:  metav1.ConditionFalse,
+			Reason:  status.PreflightFailedReason,
+			Message: fmt.Sprintf("%s: %s", gate.Spec.Message, msg),
+		}
+	default:
+		return &common.Condition{
+			Type:    status.ConditionUpgradeGateCleared,
+			Status:  metav1.ConditionTrue,
+			Reason:  status.PreflightPassedReason,
+			Message: "Preflight check passed",
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package gates_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	configApi "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+)
+
+func init() {
+	gates.RegisterCheck("test-always-fails", func(context.Context, client.Client) (bool, string, error) {
+		return false, "storage not migrated", nil
+	})
+}
+
+func newUpgradeGate(name string, versions string, mutate func(*configApi.UpgradeGate)) *configApi.UpgradeGate {
+	g := &configApi.UpgradeGate{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: configApi.UpgradeGateSpec{
+			Versions: versions,
+			Severity: configApi.UpgradeGateSeverityBlocking,
+			Message:  name + " message",
+		},
+	}
+	if mutate != nil {
+		mutate(g)
+	}
+	return g
+}
+
+func celPreflight(expression string) func(*configApi.UpgradeGate) {
+	return func(g *configApi.UpgradeGate) {
+		g.Spec.Preflight = &configApi.UpgradeGatePreflight{
+			CEL: &configApi.UpgradeGateCELCheck{
+				Resource: configApi.UpgradeGateResourceReference{
+					APIVersion: "v1",
+					Kind:       "ConfigMap",
+					Namespace:  testNamespace,
+					Name:       "settings",
+				},
+				Expression: expression,
+			},
+		}
+	}
+}
+
+func TestEvaluateUpgradeGates(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, configApi.AddToScheme(scheme))
+
+	objs := []client.Object{
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: testNamespace},
+			Data:       map[string]string{"migrated": "true"},
+		},
+		newUpgradeGate("cel-passes", ">=2.0.0 <2.1.0", celPreflight(`object.data.migrated == "true"`)),
+		newUpgradeGate("cel-fails", ">=2.0.0", celPreflight(`object.data.migrated == "false"`)),
+		newUpgradeGate("check-fails", ">=2.0.0", func(g *configApi.UpgradeGate) {
+			g.Spec.Preflight = &configApi.UpgradeGatePreflight{Check: "test-always-fails"}
+		}),
+		newUpgradeGate("manual", "2.0.0", nil),
+		newUpgradeGate("manual-acked", "2.0.0", func(g *configApi.UpgradeGate) {
+			g.Spec.Acknowledgment = &configApi.UpgradeGateAcknowledgment{
+				AcknowledgedBy: "admin",
+				AcknowledgedAt: metav1.Now(),
+			}
+		}),
+		newUpgradeGate("warning", "2.0.0", func(g *configApi.UpgradeGate) {
+			g.Spec.Severity = configApi.UpgradeGateSeverityWarning
+		}),
+		newUpgradeGate("other-version", "<2.0.0", nil),
+	}
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&configApi.UpgradeGate{}).
+		Build()
+
+	gc := gates.NewGateChecker(cli, testNamespace)
+
+	pending, err := gc.EvaluateUpgradeGates(context.Background(), "2.0.0")
+	require.NoError(t, err)
+
+	keys := make([]string, 0, len(pending))
+	for _, p := range pending {
+		keys = append(keys, p.Key)
+	}
+	assert.Equal(t, []string{"cel-fails", "check-fails", "manual"}, keys)
+	assert.Equal(t, "check-fails message: storage not migrated", pending[1].Message)
+
+	expected := map[string]struct {
+		status metav1.ConditionStatus
+		reason string
+	}{
+		"cel-passes":   {metav1.ConditionTrue, status.PreflightPassedReason},
+		"cel-fails":    {metav1.ConditionFalse, status.PreflightFailedReason},
+		"check-fails":  {metav1.ConditionFalse, status.PreflightFailedReason},
+		"manual":       {metav1.ConditionFalse, status.AdminAckRequiredReason},
+		"manual-acked": {metav1.ConditionTrue, status.AcknowledgedReason},
+		"warning":      {metav1.ConditionFalse, status.AdminAckRequiredReason},
+	}
+
+	for name, want := range expected {
+		g := configApi.UpgradeGate{}
+		require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Name: name}, &g))
+
+		c := conditions.FindStatusCondition(&g, status.ConditionUpgradeGateCleared)
+		require.NotNil(t, c, name)
+		assert.Equal(t, want.status, c.Status, name)
+		assert.Equal(t, want.reason, c.Reason, name)
+	}
+
+	g := configApi.UpgradeGate{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Name: "other-version"}, &g))
+	assert.Empty(t, g.Status.Conditions)
+}
+
+func TestEvaluateUpgradeGates_APIReader(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	require.NoError(t, configApi.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newUpgradeGate("cel", ">=2.0.0", celPreflight(`object.data.migrated == "true"`))).
+		WithStatusSubresource(&configApi.UpgradeGate{}).
+		Build()
+
+	// the resource evaluated by the CEL check is only visible to the API reader
+	reader := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "settings", Namespace: testNamespace},
+			Data:       map[string]string{"migrated": "true"},
+		}).
+		Build()
+
+	pending, err := gates.NewGateChecker(cli, testNamespace, gates.WithAPIReader(reader)).EvaluateUpgradeGates(context.Background(), "2.0.0")
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	pending, err = gates.NewGateChecker(cli, testNamespace).EvaluateUpgradeGates(context.Background(), "2.0.0")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "cel", pending[0].Key)
+}
+
+func TestPreviewUpgradeGates(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, configApi.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			newUpgradeGate("manual", "2.0.0", nil),
+			newUpgradeGate("warning", "2.0.0", func(g *configApi.UpgradeGate) {
+				g.Spec.Severity = configApi.UpgradeGateSeverityWarning
+			}),
+		).
+		WithStatusSubresource(&configApi.UpgradeGate{}).
+		Build()
+
+	pending, advisory, err := gates.NewGateChecker(cli, testNamespace).PreviewUpgradeGates(context.Background(), "2.0.0")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "manual", pending[0].Key)
+	require.Len(t, advisory, 1)
+	assert.Equal(t, "warning", advisory[0].Key)
+
+	g := configApi.UpgradeGate{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Name: "manual"}, &g))
+	assert.Empty(t, g.Status.Conditions, "preview must not record the outcome")
+}
+
+func TestEvaluateUpgradeGates_InvalidRange(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, configApi.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newUpgradeGate("invalid", "not-a-range", nil)).
+		WithStatusSubresource(&configApi.UpgradeGate{}).
+		Build()
+
+	pending, err := gates.NewGateChecker(cli, testNamespace).EvaluateUpgradeGates(context.Background(), "2.0.0")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Contains(t, pending[0].Message, "invalid versions range")
+}
+
+func TestEvaluateUpgradeGates_ConcurrentStatusWrite(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, configApi.AddToScheme(scheme))
+
+	cli := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(newUpgradeGate("manual", "2.0.0", nil)).
+		WithStatusSubresource(&configApi.UpgradeGate{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			// another reconciler writes the status of the gate between the
+			// list and the status write, making the listed gate stale
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				if err := c.List(ctx, list, opts...); err != nil {
+					return err
+				}
+
+				g := configApi.UpgradeGate{}
+				if err := c.Get(ctx, client.ObjectKey{Name: "manual"}, &g); err != nil {
+					return err
+				}
+				g.Status.ObservedGeneration = g.Generation
+
+				return c.Status().Update(ctx, &g)
+			},
+		}).
+		Build()
+
+	pending, err := gates.NewGateChecker(cli, testNamespace).EvaluateUpgradeGates(context.Background(), "2.0.0")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	g := configApi.UpgradeGate{}
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKey{Name: "manual"}, &g))
+
+	c := conditions.FindStatusCondition(&g, status.ConditionUpgradeGateCleared)
+	require.NotNil(t, c)
+	assert.Equal(t, status.AdminAckRequiredReason, c.Reason)
+}
+
+func TestValidatePreflight(t *testing.T) {
+	t.Parallel()
+
+	cel := func(expr string) *configApi.UpgradeGatePreflight {
+		return &configApi.UpgradeGatePreflight{CEL: &configApi.UpgradeGateCELCheck{Expression: expr}}
+	}
+
+	require.NoError(t, gates.ValidatePreflight(nil))
+	require.NoError(t, gates.ValidatePreflight(cel(`object == null || has(object.spec)`)))
+	require.NoError(t, gates.ValidatePreflight(&configApi.UpgradeGatePreflight{Check: "test-always-fails"}))
+	require.NoError(t, gates.ValidatePreflight(&configApi.UpgradeGatePreflight{Check: gates.AcksAcknowledgedCheck}))
+
+	require.ErrorContains(t, gates.ValidatePreflight(cel(`object.spec.`)), "invalid CEL expression")
+	require.ErrorContains(t, gates.ValidatePreflight(cel(`"a string"`)), "must evaluate to bool")
+	require.ErrorContains(t, gates.ValidatePreflight(&configApi.UpgradeGatePreflight{Check: "unknown"}), "unknown preflight check")
+	require.ErrorContains(t, gates.ValidatePreflight(&configApi.UpgradeGatePreflight{}), "exactly one of")
+}
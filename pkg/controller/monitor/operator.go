@@ -54,7 +54,7 @@ var errOperatorCRNotFound = errors.New("operator CR not found")
 // CheckOperatorHealth checks an external operator's health by reading its CR's
 // status conditions and applying the configured Filter.
 // See [OperatorConfig] for configuration details including missing CRD/CR behavior.
-func CheckOperatorHealth(ctx context.Context, cli client.Client, config OperatorConfig) (CheckResult, error) {
+func CheckOperatorHealth(ctx context.Context, cli client.Reader, config OperatorConfig) (CheckResult, error) {
 	if config.OperatorGVK == (schema.GroupVersionKind{}) {
 		return CheckResult{}, errors.New("CheckOperatorHealth: OperatorGVK must not be empty")
 	}
@@ -96,7 +96,7 @@ func CheckOperatorHealth(ctx context.Context, cli client.Client, config Operator
 	return CheckResult{Pass: true}, nil
 }
 
-func fetchOperatorCR(ctx context.Context, cli client.Client, config OperatorConfig) (*unstructured.Unstructured, error) {
+func fetchOperatorCR(ctx context.Context, cli client.Reader, config OperatorConfig) (*unstructured.Unstructured, error) {
 	cr := &unstructured.Unstructured{}
 	cr.SetGroupVersionKind(config.OperatorGVK)
 
@@ -112,7 +112,7 @@ func fetchOperatorCR(ctx context.Context, cli client.Client, config OperatorConf
 	return getFirstCR(ctx, cli, config)
 }
 
-func getFirstCR(ctx context.Context, cli client.Client, config OperatorConfig) (*unstructured.Unstructured, error) {
+func getFirstCR(ctx context.Context, cli client.Reader, config OperatorConfig) (*unstructured.Unstructured, error) {
 	list := &unstructured.UnstructuredList{}
 	list.SetGroupVersionKind(config.OperatorGVK)
 
@@ -73,15 +73,19 @@ func ExtractUpgradeGates(ctx context.Context, rr *odhtype.ReconciliationRequest)
 // CheckUpgradeGates evaluates admin-acknowledgment gates for the current
 // operator version. It collects gates from all sources (in-tree, labeled
 // cluster ConfigMaps, chart-extracted entries), writes their descriptions
-// into odh-upgrade-acks (preserving "true" values), and blocks
-// provisioning if any gates remain unacknowledged.
-func CheckUpgradeGates(ctx context.Context, cli client.Client, release common.Release, conditions ConditionWriter, chartGates map[string]string) error {
+// into odh-upgrade-acks (preserving "true" values), evaluates the
+// UpgradeGate resources, auto-clearing those whose preflight check passes,
+// and blocks provisioning if any gates remain unacknowledged.
+func CheckUpgradeGates(
+	ctx context.Context, cli client.Client, release common.Release, conditions ConditionWriter,
+	chartGates map[string]string, opts ...gates.GateCheckerOpt,
+) error {
 	ns, err := cluster.GetOperatorNamespace()
 	if err != nil {
 		return fmt.Errorf("cannot check upgrade gates: %w", err)
 	}
 
-	return CheckUpgradeGatesInNamespace(ctx, cli, ns, release, conditions, chartGates)
+	return CheckUpgradeGatesInNamespace(ctx, cli, ns, release, conditions, chartGates, opts...)
 }
 
 // CheckUpgradeGatesInNamespace is the namespace-explicit variant of
@@ -89,11 +93,11 @@ func CheckUpgradeGates(ctx context.Context, cli client.Client, release common.Re
 func CheckUpgradeGatesInNamespace(
 	ctx context.Context, cli client.Client, namespace string,
 	release common.Release, conditions ConditionWriter,
-	chartGates map[string]string,
+	chartGates map[string]string, opts ...gates.GateCheckerOpt,
 ) error {
 	log := logf.FromContext(ctx)
 
-	gc := gates.NewGateChecker(cli, namespace)
+	gc := gates.NewGateChecker(cli, namespace, opts...)
 	version := release.Version.String()
 
 	allGates := make(map[string]string)
@@ -123,6 +127,13 @@ func CheckUpgradeGatesInNamespace(
 		return fmt.Errorf("failed to ensure upgrade gates: %w", err)
 	}
 
+	pending, err := gc.EvaluateUpgradeGates(ctx, version)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate upgrade gates: %w", err)
+	}
+
+	unacked = append(unacked, pending...)
+
 	if len(unacked) == 0 {
 		return nil
 	}
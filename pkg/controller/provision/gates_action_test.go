@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
+	configApi "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/status"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
@@ -33,6 +34,7 @@ func (c *condRecorder) SetCondition(cond common.Condition) {
 func newScheme() *runtime.Scheme {
 	s := runtime.NewScheme()
 	_ = corev1.AddToScheme(s)
+	_ = configApi.AddToScheme(s)
 	return s
 }
 
@@ -211,6 +213,35 @@ func TestCheckUpgradeGates_MergesChartGates(t *testing.T) {
 	assert.Equal(t, "From chart", acksCM.Data["ack-2.0.0-chart-gate"])
 }
 
+func TestCheckUpgradeGates_UpgradeGateBlocksProvisioning(t *testing.T) {
+	t.Parallel()
+
+	gate := &configApi.UpgradeGate{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-migration"},
+		Spec: configApi.UpgradeGateSpec{
+			Versions: ">=2.0.0",
+			Message:  "Back up data before proceeding",
+		},
+	}
+
+	cli := fake.NewClientBuilder().WithScheme(newScheme()).WithObjects(gate).WithStatusSubresource(gate).Build()
+	conds := &condRecorder{}
+
+	err := provision.CheckUpgradeGatesInNamespace(context.Background(), cli, "test-ns", release("2.0.0"), conds, nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "1 unacknowledged upgrade gate(s)")
+	require.Len(t, conds.conditions, 1)
+	assert.Contains(t, conds.conditions[0].Message, "storage-migration")
+
+	require.NoError(t, cli.Get(context.Background(), client.ObjectKeyFromObject(gate), gate))
+	gate.Spec.Acknowledgment = &configApi.UpgradeGateAcknowledgment{AcknowledgedBy: "admin", AcknowledgedAt: metav1.Now()}
+	require.NoError(t, cli.Update(context.Background(), gate))
+
+	err = provision.CheckUpgradeGatesInNamespace(context.Background(), cli, "test-ns", release("2.0.0"), &condRecorder{}, nil)
+	require.NoError(t, err)
+}
+
 func TestExtractUpgradeGates_StashesOnGateEntries(t *testing.T) {
 	t.Parallel()
 
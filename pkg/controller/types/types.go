@@ -20,6 +20,7 @@ import (
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
 	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/conditions"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/resources"
 )
 
@@ -227,6 +228,11 @@ type ReconciliationRequest struct {
 	// gate sources (in-tree, cluster-discovered, chart-extracted) are
 	// merged before the gate check runs.
 	GateEntries map[string]string
+
+	// Patches holds the user supplied patches loaded by the patches action.
+	// Render actions apply them to the rendered resources so that user
+	// customizations are not reverted by the deploy action.
+	Patches []patches.Patch
 }
 
 // AddResources adds one or more resources to the ReconciliationRequest's Resources slice.
@@ -355,6 +361,24 @@ func Hash(rr *ReconciliationRequest) ([]byte, error) {
 		}
 	}
 
+	for i := range rr.Patches {
+		if _, err := hash.Write([]byte(rr.Patches[i].Source)); err != nil {
+			return nil, fmt.Errorf("failed to hash patch: %w", err)
+		}
+		if _, err := hash.Write([]byte(rr.Patches[i].Patch)); err != nil {
+			return nil, fmt.Errorf("failed to hash patch: %w", err)
+		}
+		if rr.Patches[i].Target != nil {
+			b, err := json.Marshal(rr.Patches[i].Target)
+			if err != nil {
+				return nil, fmt.Errorf("failed to hash patch target: %w", err)
+			}
+			if _, err := hash.Write(b); err != nil {
+				return nil, fmt.Errorf("failed to hash patch target: %w", err)
+			}
+		}
+	}
+
 	return hash.Sum(nil), nil
 }
 
@@ -4,132 +4,54 @@ import (
 	"context"
 	"fmt"
 
-	operatorv1 "github.com/openshift/api/operator/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
-	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
 	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
-	serviceApi "github.com/opendatahub-io/opendatahub-operator/v2/api/services/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
 )
 
-// CreateDefaultDSC creates a default instance of DSC.
+// CreateDefaultDSC creates a default instance of DSC rendered from the selected install profile.
 // Note: When the platform is not Managed, and a DSC instance already exists, the function doesn't re-create/update the resource.
 func CreateDefaultDSC(ctx context.Context, cli client.Client) error {
-	// Set the default DSC name depending on the platform
-	releaseDataScienceCluster := &dscv2.DataScienceCluster{
+	profile, err := selectedProfile(ctx, cli)
+	if err != nil {
+		return err
+	}
+
+	err = cluster.CreateWithRetry(ctx, cli, NewDefaultDSC(profile)) // 1 min timeout
+	if err != nil {
+		return fmt.Errorf("failed to create DataScienceCluster custom resource: %w", err)
+	}
+	return nil
+}
+
+// NewDefaultDSC renders the default DSC from the given install profile.
+func NewDefaultDSC(profile *Profile) *dscv2.DataScienceCluster {
+	return &dscv2.DataScienceCluster{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "DataScienceCluster",
 			APIVersion: "datasciencecluster.opendatahub.io/v2",
 		},
 		ObjectMeta: metav1.ObjectMeta{
 			Name: "default-dsc",
-		},
-		Spec: dscv2.DataScienceClusterSpec{
-			Components: dscv2.Components{
-				Dashboard: componentApi.DSCDashboard{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				Workbenches: componentApi.DSCWorkbenches{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				AIPipelines: componentApi.DSCDataSciencePipelines{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				Kserve: componentApi.DSCKserve{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-					KserveCommonSpec: componentApi.KserveCommonSpec{
-						NIM: componentApi.NimSpec{
-							ManagementState: operatorv1.Managed,
-						},
-					},
-				},
-				Ray: componentApi.DSCRay{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				Kueue: componentApi.DSCKueue{
-					KueueManagementSpec: componentApi.KueueManagementSpec{ManagementState: operatorv1.Removed},
-				},
-				TrustyAI: componentApi.DSCTrustyAI{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				ModelRegistry: componentApi.DSCModelRegistry{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				TrainingOperator: componentApi.DSCTrainingOperator{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Removed},
-				},
-				FeastOperator: componentApi.DSCFeastOperator{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				LlamaStackOperator: componentApi.DSCLlamaStackOperator{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Removed},
-				},
-				OGX: componentApi.DSCOGX{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				MLflowOperator: componentApi.DSCMLflowOperator{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				Trainer: componentApi.DSCTrainer{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-				},
-				SparkOperator: componentApi.DSCSparkOperator{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Removed},
-				},
-				AIGateway: componentApi.DSCAIGateway{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Removed},
-					AIGatewayCommonSpec: componentApi.AIGatewayCommonSpec{
-						BatchGateway: componentApi.AIGatewayBatchGatewaySpec{
-							ManagementState: operatorv1.Removed,
-						},
-					},
-				},
-				MCPLifecycleOperator: componentApi.DSCMCPLifecycleOperator{
-					ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Removed},
-				},
+			Annotations: map[string]string{
+				annotations.InstallProfile: profile.Name,
 			},
 		},
+		Spec: *profile.DataScienceCluster.DeepCopy(),
 	}
-	err := cluster.CreateWithRetry(ctx, cli, releaseDataScienceCluster) // 1 min timeout
-	if err != nil {
-		return fmt.Errorf("failed to create DataScienceCluster custom resource: %w", err)
-	}
-	return nil
 }
 
-// CreateDefaultDSCI creates a default instance of DSCI
+// CreateDefaultDSCI creates a default instance of DSCI rendered from the selected install profile.
 // If there exists default-dsci instance already, it will not update DSCISpec on it.
 // Note: DSCI CR modifcations are not supported, as it is the initial prereq setting for the components.
 func CreateDefaultDSCI(ctx context.Context, cli client.Client, _ common.Platform, monNamespace string) error {
 	log := logf.FromContext(ctx)
-	defaultDsciSpec := &dsciv2.DSCInitializationSpec{
-		Monitoring: serviceApi.DSCIMonitoring{
-			ManagementSpec: common.ManagementSpec{ManagementState: operatorv1.Managed},
-			MonitoringCommonSpec: serviceApi.MonitoringCommonSpec{
-				Namespace: monNamespace,
-				Metrics:   &serviceApi.Metrics{},
-			},
-		},
-		TrustedCABundle: &dsciv2.TrustedCABundleSpec{
-			ManagementState: "Managed",
-		},
-	}
-
-	defaultDsci := &dsciv2.DSCInitialization{
-		TypeMeta: metav1.TypeMeta{
-			Kind:       "DSCInitialization",
-			APIVersion: "dscinitialization.opendatahub.io/v2",
-		},
-		ObjectMeta: metav1.ObjectMeta{
-			Name: "default-dsci",
-		},
-		Spec: *defaultDsciSpec,
-	}
 
 	instances := &dsciv2.DSCInitializationList{}
 	if err := cli.List(ctx, instances); err != nil {
@@ -145,11 +67,40 @@ func CreateDefaultDSCI(ctx context.Context, cli client.Client, _ common.Platform
 		log.Info("DSCInitialization resource already exists. It will not be updated with default DSCI.")
 		return nil
 	case len(instances.Items) == 0:
-		log.Info("create default DSCI CR.")
-		err := cluster.CreateWithRetry(ctx, cli, defaultDsci) // 1 min timeout
+		profile, err := selectedProfile(ctx, cli)
+		if err != nil {
+			return err
+		}
+
+		log.Info("create default DSCI CR.", "profile", profile.Name)
+		err = cluster.CreateWithRetry(ctx, cli, NewDefaultDSCI(profile, monNamespace)) // 1 min timeout
 		if err != nil {
 			return err
 		}
 	}
 	return nil
 }
+
+// NewDefaultDSCI renders the default DSCI from the given install profile, the
+// monitoring namespace is set unless the profile sets one.
+func NewDefaultDSCI(profile *Profile, monNamespace string) *dsciv2.DSCInitialization {
+	dsci := &dsciv2.DSCInitialization{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DSCInitialization",
+			APIVersion: "dscinitialization.opendatahub.io/v2",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "default-dsci",
+			Annotations: map[string]string{
+				annotations.InstallProfile: profile.Name,
+			},
+		},
+		Spec: *profile.DSCInitialization.DeepCopy(),
+	}
+
+	if dsci.Spec.Monitoring.Namespace == "" {
+		dsci.Spec.Monitoring.Namespace = monNamespace
+	}
+
+	return dsci
+}
@@ -0,0 +1,120 @@
+package initialinstall
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/flags"
+)
+
+//go:embed resources/profiles
+var profilesFS embed.FS
+
+const (
+	// DefaultProfile is the install profile used when none is selected.
+	DefaultProfile = "default"
+
+	// ProfileConfigMapKey is the key holding the profile YAML in custom
+	// profile ConfigMaps.
+	ProfileConfigMapKey = "profile.yaml"
+)
+
+// Profile describes the default DSCInitialization and DataScienceCluster
+// created on a fresh install.
+type Profile struct {
+	Name               string                       `json:"name"`
+	Description        string                       `json:"description,omitempty"`
+	DSCInitialization  dsciv2.DSCInitializationSpec `json:"dscInitialization"`
+	DataScienceCluster dscv2.DataScienceClusterSpec `json:"dataScienceCluster"`
+}
+
+// BuiltinProfiles returns the sorted names of the profiles shipped with the
+// operator.
+func BuiltinProfiles() ([]string, error) {
+	entries, err := profilesFS.ReadDir("resources/profiles")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read embedded install profiles: %w", err)
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+
+	slices.Sort(names)
+
+	return names, nil
+}
+
+// LoadProfile returns the install profile with the given name. Built-in
+// profiles take precedence, otherwise the profile is read from the
+// ProfileConfigMapKey entry of the ConfigMap with the same name in the
+// given namespace. The default profile is returned if name is empty.
+func LoadProfile(ctx context.Context, cli client.Client, namespace string, name string) (*Profile, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+
+	data, err := profilesFS.ReadFile("resources/profiles/" + name + ".yaml")
+	if err == nil {
+		return parseProfile(name, data)
+	}
+
+	builtin, _ := BuiltinProfiles()
+
+	if namespace == "" {
+		return nil, fmt.Errorf("unknown install profile %q, built-in profiles: %v", name, builtin)
+	}
+
+	cm := corev1.ConfigMap{}
+	err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		return nil, fmt.Errorf("unknown install profile %q, built-in profiles: %v, no ConfigMap %s/%s found", name, builtin, namespace, name)
+	case err != nil:
+		return nil, fmt.Errorf("failed to get install profile ConfigMap %s/%s: %w", namespace, name, err)
+	}
+
+	content, ok := cm.Data[ProfileConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("install profile ConfigMap %s/%s has no %s key", namespace, name, ProfileConfigMapKey)
+	}
+
+	return parseProfile(name, []byte(content))
+}
+
+func parseProfile(name string, data []byte) (*Profile, error) {
+	p := Profile{}
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid install profile %q: %w", name, err)
+	}
+
+	switch {
+	case p.Name == "":
+		p.Name = name
+	case p.Name != name:
+		return nil, fmt.Errorf("invalid install profile %q: name is %q", name, p.Name)
+	}
+
+	return &p, nil
+}
+
+// selectedProfile loads the install profile selected by the operator flag
+// or environment variable.
+func selectedProfile(ctx context.Context, cli client.Client) (*Profile, error) {
+	// without an operator namespace only the built-in profiles are available
+	ns, _ := cluster.GetOperatorNamespace()
+
+	return LoadProfile(ctx, cli, ns, flags.GetInstallProfile())
+}
@@ -0,0 +1,128 @@
+package initialinstall_test
+
+import (
+	"testing"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/initialinstall"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/annotations"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+const testOperatorNamespace = "test-operator-ns"
+
+func TestBuiltinProfiles(t *testing.T) {
+	g := NewWithT(t)
+
+	cli, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	names, err := initialinstall.BuiltinProfiles()
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(names).Should(Equal([]string{"default", "full", "minimal", "serving", "training"}))
+
+	for _, name := range names {
+		p, err := initialinstall.LoadProfile(t.Context(), cli, "", name)
+		g.Expect(err).ShouldNot(HaveOccurred(), name)
+		g.Expect(p.Name).Should(Equal(name))
+		g.Expect(p.DataScienceCluster.Components.Dashboard.ManagementState).Should(Equal(operatorv1.Managed), name)
+	}
+}
+
+func TestLoadProfile(t *testing.T) {
+	custom := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "edge", Namespace: testOperatorNamespace},
+		Data: map[string]string{initialinstall.ProfileConfigMapKey: `
+dataScienceCluster:
+  components:
+    kserve:
+      managementState: Managed
+dscInitialization:
+  monitoring:
+    managementState: Removed
+`},
+	}
+
+	invalid := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "typo", Namespace: testOperatorNamespace},
+		Data: map[string]string{initialinstall.ProfileConfigMapKey: `
+dataScienceCluster:
+  components:
+    kserv:
+      managementState: Managed
+`},
+	}
+
+	t.Run("should default to the default profile", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New()
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		p, err := initialinstall.LoadProfile(t.Context(), cli, testOperatorNamespace, "")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(p.Name).Should(Equal(initialinstall.DefaultProfile))
+		g.Expect(p.DataScienceCluster.Components.Kueue.ManagementState).Should(Equal(operatorv1.Removed))
+		g.Expect(p.DataScienceCluster.Components.Kserve.NIM.ManagementState).Should(Equal(operatorv1.Managed))
+		g.Expect(p.DSCInitialization.Monitoring.Metrics).ShouldNot(BeNil())
+	})
+
+	t.Run("should load custom profiles from a ConfigMap", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(custom))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		p, err := initialinstall.LoadProfile(t.Context(), cli, testOperatorNamespace, "edge")
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(p.Name).Should(Equal("edge"))
+		g.Expect(p.DataScienceCluster.Components.Kserve.ManagementState).Should(Equal(operatorv1.Managed))
+		g.Expect(p.DataScienceCluster.Components.Dashboard.ManagementState).Should(BeEmpty())
+		g.Expect(p.DSCInitialization.Monitoring.ManagementState).Should(Equal(operatorv1.Removed))
+	})
+
+	t.Run("should reject unknown and invalid profiles", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(invalid))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		_, err = initialinstall.LoadProfile(t.Context(), cli, testOperatorNamespace, "unknown")
+		g.Expect(err).Should(MatchError(ContainSubstring(`unknown install profile "unknown"`)))
+
+		_, err = initialinstall.LoadProfile(t.Context(), cli, "", "edge")
+		g.Expect(err).Should(MatchError(ContainSubstring("built-in profiles: [default full minimal serving training]")))
+
+		_, err = initialinstall.LoadProfile(t.Context(), cli, testOperatorNamespace, "typo")
+		g.Expect(err).Should(MatchError(ContainSubstring(`invalid install profile "typo"`)))
+	})
+}
+
+func TestNewDefaultDSCI(t *testing.T) {
+	g := NewWithT(t)
+
+	cli, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	p, err := initialinstall.LoadProfile(t.Context(), cli, "", "minimal")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	dsci := initialinstall.NewDefaultDSCI(p, "monitoring-ns")
+	g.Expect(dsci.Annotations).Should(HaveKeyWithValue(annotations.InstallProfile, "minimal"))
+	g.Expect(dsci.Spec.Monitoring.Namespace).Should(Equal("monitoring-ns"))
+	g.Expect(dsci.Spec.Monitoring.ManagementState).Should(Equal(operatorv1.Removed))
+
+	dsc := initialinstall.NewDefaultDSC(p)
+	g.Expect(dsc.Name).Should(Equal("default-dsc"))
+	g.Expect(dsc.Annotations).Should(HaveKeyWithValue(annotations.InstallProfile, "minimal"))
+	g.Expect(dsc.Spec.Components.Kserve.ManagementState).Should(Equal(operatorv1.Removed))
+
+	// the rendered objects do not share state with the profile
+	dsc.Spec.Components.Dashboard.ManagementState = operatorv1.Removed
+	g.Expect(p.DataScienceCluster.Components.Dashboard.ManagementState).Should(Equal(operatorv1.Managed))
+}
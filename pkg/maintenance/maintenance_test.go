@@ -0,0 +1,160 @@
+package maintenance_test
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/maintenance"
+
+	. "github.com/onsi/gomega"
+)
+
+func date(loc *time.Location, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, loc)
+}
+
+func TestParseSchedule(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"0 2 * * SAT",
+		"*/15 1-5 1,15 jan-jun mon-fri",
+		"0-30/10 * * * 7",
+		"5/20 * * * *",
+		"@daily",
+		"@Weekly",
+	}
+
+	for _, expr := range valid {
+		t.Run(expr, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := maintenance.ParseSchedule(expr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+		})
+	}
+
+	invalid := map[string]string{
+		"0 2 * *":       "expected 5 fields",
+		"60 * * * *":    "out of range",
+		"* 24 * * *":    "out of range",
+		"* * 0 * *":     "out of range",
+		"* * * foo *":   `invalid value "foo"`,
+		"* * * * 1-8":   "out of range",
+		"*/0 * * * *":   "invalid step",
+		"5-1 * * * *":   "invalid range",
+		"@fortnightly":  "expected 5 fields",
+		"* * * * mon/x": "invalid step",
+	}
+
+	for expr, msg := range invalid {
+		t.Run(expr, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := maintenance.ParseSchedule(expr)
+			g.Expect(err).Should(MatchError(ContainSubstring(msg)))
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", date(time.UTC, 3, 10, 12, 30).Add(30 * time.Second), date(time.UTC, 3, 10, 12, 31)},
+		{"0 2 * * SAT", date(time.UTC, 3, 10, 12, 30), date(time.UTC, 3, 14, 2, 0)},
+		{"0 2 * * SAT", date(time.UTC, 3, 14, 2, 0), date(time.UTC, 3, 21, 2, 0)},
+		{"*/20 22 * * *", date(time.UTC, 3, 10, 22, 45), date(time.UTC, 3, 11, 22, 0)},
+		{"0 0 1 * *", date(time.UTC, 12, 15, 0, 0), time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// both day fields restricted, either of them matches
+		{"0 0 15 * MON", date(time.UTC, 3, 10, 0, 0), date(time.UTC, 3, 15, 0, 0)},
+		{"0 0 20 * 0", date(time.UTC, 3, 10, 0, 0), date(time.UTC, 3, 15, 0, 0)},
+		{"0 0 30 2 *", date(time.UTC, 1, 1, 0, 0), time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s, err := maintenance.ParseSchedule(tt.expr)
+			g.Expect(err).ShouldNot(HaveOccurred())
+
+			next := s.Next(tt.from)
+			g.Expect(next.Equal(tt.want)).Should(BeTrue(), "got %s, want %s", next, tt.want)
+
+			if !next.IsZero() {
+				g.Expect(s.Matches(next)).Should(BeTrue())
+			}
+		})
+	}
+}
+
+func TestWindows(t *testing.T) {
+	g := NewWithT(t)
+
+	rome, err := time.LoadLocation("Europe/Rome")
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	w, err := maintenance.NewWindows(&dsciv2.MaintenancePolicy{
+		TimeZone: "Europe/Rome",
+		Windows: []dsciv2.MaintenanceWindow{
+			{Schedule: "0 2 * * SAT", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			{Schedule: "30 22 * * WED", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+		},
+	})
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	// Saturday, March 14th 2026
+	g.Expect(w.IsOpen(date(rome, 3, 14, 1, 59))).Should(BeFalse())
+	g.Expect(w.IsOpen(date(rome, 3, 14, 2, 0))).Should(BeTrue())
+	g.Expect(w.IsOpen(date(rome, 3, 14, 5, 59))).Should(BeTrue())
+	g.Expect(w.IsOpen(date(rome, 3, 14, 6, 0))).Should(BeFalse())
+
+	// the window spans midnight, Wednesday March 11th 2026
+	g.Expect(w.IsOpen(date(rome, 3, 11, 23, 15))).Should(BeTrue())
+	g.Expect(w.IsOpen(date(rome, 3, 12, 0, 0))).Should(BeTrue())
+	g.Expect(w.IsOpen(date(rome, 3, 12, 0, 30))).Should(BeFalse())
+
+	// the schedules are evaluated in the policy time zone
+	g.Expect(w.IsOpen(date(time.UTC, 3, 14, 1, 30))).Should(BeTrue())
+
+	next := w.NextOpen(date(rome, 3, 12, 12, 0))
+	g.Expect(next.Equal(date(rome, 3, 14, 2, 0))).Should(BeTrue(), "got %s", next)
+
+	next = w.NextOpen(date(rome, 3, 9, 12, 0))
+	g.Expect(next.Equal(date(rome, 3, 11, 22, 30))).Should(BeTrue(), "got %s", next)
+}
+
+func TestNewWindows(t *testing.T) {
+	g := NewWithT(t)
+
+	w, err := maintenance.NewWindows(nil)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(w).Should(BeNil())
+	g.Expect(w.IsOpen(time.Now())).Should(BeTrue())
+
+	w, err = maintenance.NewWindows(&dsciv2.MaintenancePolicy{TimeZone: "Europe/Rome"})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(w).Should(BeNil())
+
+	_, err = maintenance.NewWindows(&dsciv2.MaintenancePolicy{
+		TimeZone: "Mars/Olympus",
+		Windows:  []dsciv2.MaintenanceWindow{{Schedule: "@daily", Duration: metav1.Duration{Duration: time.Hour}}},
+	})
+	g.Expect(err).Should(MatchError(ContainSubstring("invalid maintenance time zone")))
+
+	_, err = maintenance.NewWindows(&dsciv2.MaintenancePolicy{
+		Windows: []dsciv2.MaintenanceWindow{
+			{Schedule: "@daily"},
+			{Schedule: "bogus", Duration: metav1.Duration{Duration: time.Hour}},
+		},
+	})
+	g.Expect(err).Should(And(
+		MatchError(ContainSubstring("window 0: duration must be positive")),
+		MatchError(ContainSubstring("window 1: invalid schedule")),
+	))
+}
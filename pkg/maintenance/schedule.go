@@ -0,0 +1,256 @@
+// Package maintenance evaluates the maintenance windows configured on the
+// DSCInitialization, during which the operator is allowed to apply changes
+// disrupting the running workloads.
+package maintenance
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// searchLimit bounds the search for the next activation of a schedule, so an
+// expression that never matches (i.e. "0 0 31 2 *") does not loop forever.
+const searchLimit = 5
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var dayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+}
+
+type field struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: monthNames}
+	// 7 is accepted as an alias of Sunday and folded into 0 once parsed.
+	dowField = field{name: "day of week", min: 0, max: 7, names: dayNames}
+)
+
+// Schedule is a parsed standard cron expression with minute, hour, day of
+// month, month and day of week fields.
+type Schedule struct {
+	minute uint64
+	hour   uint64
+	dom    uint64
+	month  uint64
+	dow    uint64
+
+	// domAny and dowAny record whether the day fields are unrestricted, as a
+	// day matches when either of them matches if both are restricted.
+	domAny bool
+	dowAny bool
+}
+
+// ParseSchedule parses a five fields cron expression. Each field accepts "*",
+// single values, ranges ("1-5"), steps ("*/15", "0-30/10") and comma
+// separated lists of them; months and days of week also accept their three
+// letters English names. The @yearly, @monthly, @weekly, @daily and @hourly
+// macros are supported as well.
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if m, ok := macros[strings.ToLower(expr)]; ok {
+		expr = m
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("invalid schedule %q: expected 5 fields, found %d", expr, len(parts))
+	}
+
+	s := Schedule{}
+
+	var err error
+
+	if s.minute, err = parseField(parts[0], minuteField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+	if s.hour, err = parseField(parts[1], hourField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+	if s.dom, err = parseField(parts[2], domField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+	if s.month, err = parseField(parts[3], monthField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+	if s.dow, err = parseField(parts[4], dowField); err != nil {
+		return nil, fmt.Errorf("invalid schedule %q: %w", expr, err)
+	}
+
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+
+	s.domAny = parts[2] == "*"
+	s.dowAny = parts[4] == "*"
+
+	return &s, nil
+}
+
+func parseField(expr string, f field) (uint64, error) {
+	var bits uint64
+
+	for _, item := range strings.Split(expr, ",") {
+		b, err := parseItem(item, f)
+		if err != nil {
+			return 0, err
+		}
+
+		bits |= b
+	}
+
+	return bits, nil
+}
+
+func parseItem(item string, f field) (uint64, error) {
+	rangeExpr, stepExpr, hasStep := strings.Cut(item, "/")
+
+	step := 1
+	if hasStep {
+		v, err := strconv.Atoi(stepExpr)
+		if err != nil || v <= 0 {
+			return 0, fmt.Errorf("invalid step %q in %s field", stepExpr, f.name)
+		}
+
+		step = v
+	}
+
+	var lo, hi int
+
+	switch {
+	case rangeExpr == "*":
+		lo, hi = f.min, f.max
+	case strings.Contains(rangeExpr, "-"):
+		loExpr, hiExpr, _ := strings.Cut(rangeExpr, "-")
+
+		var err error
+		if lo, err = parseValue(loExpr, f); err != nil {
+			return 0, err
+		}
+		if hi, err = parseValue(hiExpr, f); err != nil {
+			return 0, err
+		}
+		if lo > hi {
+			return 0, fmt.Errorf("invalid range %q in %s field", rangeExpr, f.name)
+		}
+	default:
+		v, err := parseValue(rangeExpr, f)
+		if err != nil {
+			return 0, err
+		}
+
+		lo, hi = v, v
+		if hasStep {
+			hi = f.max
+		}
+	}
+
+	var bits uint64
+	for v := lo; v <= hi; v += step {
+		bits |= 1 << uint(v)
+	}
+
+	return bits, nil
+}
+
+func parseValue(expr string, f field) (int, error) {
+	if v, ok := f.names[strings.ToLower(expr)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(expr)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", expr, f.name)
+	}
+
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d] in %s field", v, f.min, f.max, f.name)
+	}
+
+	return v, nil
+}
+
+// Matches reports whether the schedule fires at the minute of the given time,
+// evaluated in the location of t.
+func (s *Schedule) Matches(t time.Time) bool {
+	return has(s.minute, t.Minute()) &&
+		has(s.hour, t.Hour()) &&
+		has(s.month, int(t.Month())) &&
+		s.dayMatches(t)
+}
+
+// Next returns the first time strictly after t at which the schedule fires,
+// evaluated in the location of t. The zero time is returned when the schedule
+// does not fire within the next years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Year() + searchLimit
+
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, loc).Add(time.Minute)
+
+	for t.Year() <= limit {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case !has(s.hour, t.Hour()):
+			next := time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// The wall clock went back (DST end), move on in absolute time.
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+
+			t = next
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dow
+	case s.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
@@ -0,0 +1,114 @@
+package maintenance
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+)
+
+// Window is a recurring period of time starting at the activations of its
+// schedule and lasting for its duration.
+type Window struct {
+	Schedule *Schedule
+	Duration time.Duration
+}
+
+// Windows is the set of maintenance windows of a policy. A nil or empty
+// Windows is always open.
+type Windows struct {
+	windows  []Window
+	location *time.Location
+}
+
+// NewWindows parses the windows of the given policy. It returns nil, meaning
+// disruptive changes are never deferred, when the policy has no windows.
+func NewWindows(policy *dsciv2.MaintenancePolicy) (*Windows, error) {
+	if policy == nil || len(policy.Windows) == 0 {
+		return nil, nil
+	}
+
+	loc := time.UTC
+	if policy.TimeZone != "" {
+		l, err := time.LoadLocation(policy.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maintenance time zone %q: %w", policy.TimeZone, err)
+		}
+
+		loc = l
+	}
+
+	result := Windows{
+		windows:  make([]Window, 0, len(policy.Windows)),
+		location: loc,
+	}
+
+	var errs []error
+
+	for i, w := range policy.Windows {
+		s, err := ParseSchedule(w.Schedule)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("window %d: %w", i, err))
+			continue
+		}
+
+		if w.Duration.Duration <= 0 {
+			errs = append(errs, fmt.Errorf("window %d: duration must be positive, got %s", i, w.Duration.Duration))
+			continue
+		}
+
+		result.windows = append(result.windows, Window{Schedule: s, Duration: w.Duration.Duration})
+	}
+
+	if len(errs) != 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &result, nil
+}
+
+// IsOpen reports whether t falls within one of the windows.
+func (w *Windows) IsOpen(t time.Time) bool {
+	if w == nil || len(w.windows) == 0 {
+		return true
+	}
+
+	t = t.In(w.location)
+
+	for _, win := range w.windows {
+		// The window is open if it started within the last Duration, that is
+		// if the first activation after t-Duration is not after t.
+		start := win.Schedule.Next(t.Add(-win.Duration))
+		if !start.IsZero() && !start.After(t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NextOpen returns the first time after t at which one of the windows opens,
+// or the zero time if none of them ever opens.
+func (w *Windows) NextOpen(t time.Time) time.Time {
+	if w == nil {
+		return time.Time{}
+	}
+
+	t = t.In(w.location)
+
+	var next time.Time
+
+	for _, win := range w.windows {
+		start := win.Schedule.Next(t)
+		if start.IsZero() {
+			continue
+		}
+
+		if next.IsZero() || start.Before(next) {
+			next = start
+		}
+	}
+
+	return next
+}
@@ -1,9 +1,13 @@
 package kustomize
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/kustomize/api/resmap"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
 )
 
 var _ resmap.Transformer = &filterPlugin{}
@@ -26,3 +30,38 @@ type filterProxy struct {
 func (f *filterProxy) Filter(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
 	return f.f(nodes)
 }
+
+func patchesFilter(s *runtime.Scheme, failures *patches.Failures, values []patches.Patch) FilterFn {
+	return unstructuredFilter(func(obj *unstructured.Unstructured) error {
+		failures.Add(patches.ApplyAll(s, values, obj))
+
+		return nil
+	})
+}
+
+// unstructuredFilter adapts a function mutating a single unstructured object
+// to a FilterFn.
+func unstructuredFilter(fn func(obj *unstructured.Unstructured) error) FilterFn {
+	return func(nodes []*kyaml.RNode) ([]*kyaml.RNode, error) {
+		for i := range nodes {
+			m, err := nodes[i].Map()
+			if err != nil {
+				return nil, err
+			}
+
+			u := unstructured.Unstructured{Object: m}
+			if err := fn(&u); err != nil {
+				return nil, err
+			}
+
+			transformed, err := kyaml.FromMap(u.Object)
+			if err != nil {
+				return nil, err
+			}
+
+			nodes[i].SetYNode(transformed.YNode())
+		}
+
+		return nodes, nil
+	}
+}
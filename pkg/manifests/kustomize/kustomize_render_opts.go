@@ -3,8 +3,12 @@ package kustomize
 import (
 	"maps"
 
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/kustomize/api/resmap"
 	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/transformers"
 )
 
 type FilterFn func(nodes []*kyaml.RNode) ([]*kyaml.RNode, error)
@@ -97,3 +101,28 @@ func WithFilters(values ...FilterFn) RenderOptsFn {
 		}
 	}
 }
+
+// WithPatches applies the given user supplied patches to the rendered resources,
+// after all the other plugins have been executed. Patches that cannot be applied
+// are skipped and recorded in failures.
+func WithPatches(s *runtime.Scheme, failures *patches.Failures, values ...patches.Patch) RenderOptsFn {
+	return func(opts *renderOpts) {
+		if len(values) == 0 {
+			return
+		}
+
+		opts.plugins = append(opts.plugins, &filterPlugin{f: patchesFilter(s, failures, values)})
+	}
+}
+
+// WithTransformers applies the given engine agnostic transformers to the
+// rendered resources, in order.
+func WithTransformers(values ...transformers.Transformer) RenderOptsFn {
+	return func(opts *renderOpts) {
+		if len(values) == 0 {
+			return
+		}
+
+		opts.plugins = append(opts.plugins, &filterPlugin{f: unstructuredFilter(transformers.Chain(values...))})
+	}
+}
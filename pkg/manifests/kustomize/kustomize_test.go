@@ -8,7 +8,9 @@ import (
 	"sigs.k8s.io/kustomize/kyaml/filesys"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/kustomize"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
 
 	. "github.com/onsi/gomega"
 )
@@ -202,3 +204,68 @@ func TestEngineOrder(t *testing.T) {
 		})
 	}
 }
+
+const testEnginePatch = `
+target:
+  kind: Deployment
+  name: test-deployment
+patch: |-
+  - op: add
+    path: /spec/template/spec/tolerations
+    value:
+    - key: dedicated
+      operator: Exists
+`
+
+const testEngineBrokenPatch = `
+target:
+  kind: Deployment
+  name: test-deployment
+patch: |-
+  - op: replace
+    path: /spec/template/spec/nodeSelector/missing
+    value: "true"
+`
+
+func TestEnginePatches(t *testing.T) {
+	g := NewWithT(t)
+	root := xid.New().String()
+	ns := xid.New().String()
+	fs := filesys.MakeFsInMemory()
+
+	s, err := scheme.New()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	p, err := patches.Parse("test", []byte(testEnginePatch))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	broken, err := patches.Parse("broken", []byte(testEngineBrokenPatch))
+	g.Expect(err).NotTo(HaveOccurred())
+
+	failures := patches.Failures{}
+
+	e := kustomize.NewEngine(
+		kustomize.WithEngineFS(fs),
+	)
+
+	_ = fs.MkdirAll(path.Join(root, kustomize.DefaultKustomizationFilePath))
+	_ = fs.WriteFile(path.Join(root, kustomize.DefaultKustomizationFileName), []byte(testEngineKustomizationOrderFifo))
+	_ = fs.WriteFile(path.Join(root, "test-engine-cm.yaml"), []byte(testEngineOrderConfigMap))
+	_ = fs.WriteFile(path.Join(root, "test-engine-secrets.yaml"), []byte(testEngineOrderSecret))
+	_ = fs.WriteFile(path.Join(root, "test-engine-deployment.yaml"), []byte(testEngineOrderDeployment))
+
+	r, err := e.Render(
+		root,
+		kustomize.WithNamespace(ns),
+		kustomize.WithPatches(s, &failures, broken, p),
+	)
+
+	g.Expect(err).NotTo(HaveOccurred())
+	g.Expect(failures.Err()).Should(MatchError(ContainSubstring("failed to apply patch broken to Deployment")))
+	g.Expect(r).Should(And(
+		HaveLen(3),
+		HaveEach(jq.Match(`.metadata.namespace == "%s"`, ns)),
+		jq.Match(`.[1] | .spec.template.spec.tolerations[0].key == "dedicated"`),
+		jq.Match(`.[1] | .spec.template.spec.containers[0].image == "nginx:1.14.2"`),
+	))
+}
@@ -0,0 +1,360 @@
+// Package patches implements user supplied strategic merge and JSON6902 patches
+// that are applied to the resources rendered for a component, regardless of the
+// engine (kustomize, helm or templates) used to render them.
+//
+// Patches are provided through ConfigMaps living in the applications namespace
+// and labelled with labels.ComponentPatches=<component>. Each data entry of the
+// ConfigMap holds a single patch using the same layout as a kustomize patches
+// entry:
+//
+//	target:
+//	  group: apps
+//	  version: v1
+//	  kind: Deployment
+//	  name: odh-dashboard
+//	patch: |-
+//	  - op: add
+//	    path: /spec/template/spec/tolerations
+//	    value:
+//	      - key: dedicated
+//	        operator: Exists
+//
+// The patch type is inferred from its content, as kustomize does: a list of
+// operations is a JSON6902 patch, a map is a strategic merge patch. Strategic
+// merge patches may omit the target, in which case it is derived from the
+// apiVersion, kind, metadata.name and metadata.namespace of the patch itself.
+package patches
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+type Type string
+
+const (
+	TypeStrategicMerge Type = "StrategicMerge"
+	TypeJSON6902       Type = "JSON6902"
+)
+
+// Selector identifies the resources a Patch applies to. Empty fields match any value.
+type Selector struct {
+	Group         string `json:"group,omitempty"`
+	Version       string `json:"version,omitempty"`
+	Kind          string `json:"kind,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// Patch is a single, validated, user supplied patch.
+type Patch struct {
+	// Source identifies where the patch comes from, i.e. <namespace>/<configmap>[<key>].
+	Source string    `json:"-"`
+	Type   Type      `json:"-"`
+	Target *Selector `json:"target,omitempty"`
+	Patch  string    `json:"patch"`
+
+	selector k8slabels.Selector
+	smp      map[string]any
+	json6902 jsonpatch.Patch
+}
+
+// Parse decodes and validates a patch definition.
+func Parse(source string, data []byte) (Patch, error) {
+	p := Patch{}
+
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return Patch{}, fmt.Errorf("%s: invalid patch definition: %w", source, err)
+	}
+
+	p.Source = source
+	p.Patch = strings.TrimSpace(p.Patch)
+
+	if p.Patch == "" {
+		return Patch{}, fmt.Errorf("%s: patch must not be empty", source)
+	}
+
+	if err := p.decode(); err != nil {
+		return Patch{}, fmt.Errorf("%s: %w", source, err)
+	}
+
+	if p.Target == nil || (*p.Target == Selector{}) {
+		return Patch{}, fmt.Errorf("%s: unable to determine the patch target", source)
+	}
+
+	if p.Target.LabelSelector != "" {
+		s, err := k8slabels.Parse(p.Target.LabelSelector)
+		if err != nil {
+			return Patch{}, fmt.Errorf("%s: invalid label selector %q: %w", source, p.Target.LabelSelector, err)
+		}
+
+		p.selector = s
+	}
+
+	return p, nil
+}
+
+// FromConfigMap extracts all the patches defined in the given ConfigMap. Entries
+// that fail validation are skipped and reported through the returned error, so
+// callers can still make use of the valid ones.
+func FromConfigMap(cm *corev1.ConfigMap) ([]Patch, error) {
+	keys := slices.Sorted(func(yield func(string) bool) {
+		for k := range cm.Data {
+			if !yield(k) {
+				return
+			}
+		}
+	})
+
+	result := make([]Patch, 0, len(keys))
+
+	var errs []error
+
+	for _, k := range keys {
+		p, err := Parse(fmt.Sprintf("%s/%s[%s]", cm.Namespace, cm.Name, k), []byte(cm.Data[k]))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		result = append(result, p)
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (p *Patch) decode() error {
+	raw, err := yaml.YAMLToJSON([]byte(p.Patch))
+	if err != nil {
+		return fmt.Errorf("unable to decode patch: %w", err)
+	}
+
+	switch {
+	case strings.HasPrefix(strings.TrimSpace(string(raw)), "["):
+		ops, err := jsonpatch.DecodePatch(raw)
+		if err != nil {
+			return fmt.Errorf("invalid JSON6902 patch: %w", err)
+		}
+
+		for i := range ops {
+			if _, err := ops[i].Path(); err != nil {
+				return fmt.Errorf("invalid JSON6902 patch operation %d: %w", i, err)
+			}
+		}
+
+		p.Type = TypeJSON6902
+		p.json6902 = ops
+	default:
+		smp := map[string]any{}
+		if err := json.Unmarshal(raw, &smp); err != nil {
+			return fmt.Errorf("invalid strategic merge patch: %w", err)
+		}
+
+		p.Type = TypeStrategicMerge
+		p.smp = smp
+
+		if p.Target == nil {
+			p.Target = selectorFromObject(&unstructured.Unstructured{Object: smp})
+		}
+
+		// The target is identified by the selector, the identity of the
+		// patch itself must not leak in the patched resource.
+		delete(p.smp, "apiVersion")
+		delete(p.smp, "kind")
+		unstructured.RemoveNestedField(p.smp, "metadata", "name")
+		unstructured.RemoveNestedField(p.smp, "metadata", "namespace")
+	}
+
+	return nil
+}
+
+func selectorFromObject(obj *unstructured.Unstructured) *Selector {
+	if obj.GetKind() == "" || obj.GetName() == "" {
+		return nil
+	}
+
+	gvk := obj.GroupVersionKind()
+
+	return &Selector{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}
+}
+
+// Matches returns true if the given object is selected by the patch target.
+func (p *Patch) Matches(obj *unstructured.Unstructured) bool {
+	if p.Target == nil {
+		return false
+	}
+
+	gvk := obj.GroupVersionKind()
+
+	switch {
+	case p.Target.Group != "" && p.Target.Group != gvk.Group:
+		return false
+	case p.Target.Version != "" && p.Target.Version != gvk.Version:
+		return false
+	case p.Target.Kind != "" && p.Target.Kind != gvk.Kind:
+		return false
+	case p.Target.Name != "" && p.Target.Name != obj.GetName():
+		return false
+	case p.Target.Namespace != "" && p.Target.Namespace != obj.GetNamespace():
+		return false
+	case p.selector != nil && !p.selector.Matches(k8slabels.Set(obj.GetLabels())):
+		return false
+	}
+
+	return true
+}
+
+// Apply patches the given object in place if it is selected by the patch
+// target. The scheme is used to look up the strategic merge metadata of
+// the object, types not known by the scheme are patched using a JSON merge
+// patch as kubectl does.
+func (p *Patch) Apply(s *runtime.Scheme, obj *unstructured.Unstructured) error {
+	if !p.Matches(obj) {
+		return nil
+	}
+
+	var result map[string]any
+	var err error
+
+	switch p.Type {
+	case TypeJSON6902:
+		result, err = p.applyJSON6902(obj)
+	case TypeStrategicMerge:
+		result, err = p.applyStrategicMerge(s, obj)
+	default:
+		err = fmt.Errorf("unsupported patch type %q", p.Type)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to apply patch %s to %s %s/%s: %w",
+			p.Source, obj.GetKind(), obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	obj.SetUnstructuredContent(result)
+
+	return nil
+}
+
+func (p *Patch) applyJSON6902(obj *unstructured.Unstructured) (map[string]any, error) {
+	doc, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := p.json6902.Apply(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (p *Patch) applyStrategicMerge(s *runtime.Scheme, obj *unstructured.Unstructured) (map[string]any, error) {
+	if s != nil {
+		typed, err := s.New(obj.GroupVersionKind())
+		switch {
+		case err == nil:
+			meta, err := strategicpatch.NewPatchMetaFromStruct(typed)
+			if err != nil {
+				return nil, err
+			}
+
+			return strategicpatch.StrategicMergeMapPatchUsingLookupPatchMeta(obj.Object, p.smp, meta)
+		case !runtime.IsNotRegisteredError(err):
+			return nil, err
+		}
+	}
+
+	doc, err := json.Marshal(obj.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := json.Marshal(p.smp)
+	if err != nil {
+		return nil, err
+	}
+
+	patched, err := jsonpatch.MergePatch(doc, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]any{}
+	if err := json.Unmarshal(patched, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ApplyAll applies all the patches to the given object, in order. A patch that
+// cannot be applied is skipped, the object still gets all the other patches, and
+// the errors of the skipped patches are returned joined together.
+func ApplyAll(s *runtime.Scheme, values []Patch, obj *unstructured.Unstructured) error {
+	var errs []error
+
+	for i := range values {
+		if err := values[i].Apply(s, obj); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Failures collects the errors of the patches skipped while rendering, so
+// that they can be reported without failing the whole render. A nil Failures
+// discards the errors.
+type Failures struct {
+	mu   sync.Mutex
+	errs []error
+}
+
+// Add records the error of a skipped patch.
+func (f *Failures) Add(err error) {
+	if f == nil || err == nil {
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.errs = append(f.errs, err)
+}
+
+// Err returns the recorded errors joined together, or nil.
+func (f *Failures) Err() error {
+	if f == nil {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return errors.Join(f.errs...)
+}
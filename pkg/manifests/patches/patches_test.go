@@ -0,0 +1,229 @@
+package patches_test
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/patches"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/scheme"
+
+	. "github.com/onsi/gomega"
+)
+
+const testJSON6902Patch = `
+target:
+  group: apps
+  version: v1
+  kind: Deployment
+  name: odh-dashboard
+patch: |-
+  - op: add
+    path: /spec/template/spec/tolerations
+    value:
+      - key: dedicated
+        operator: Exists
+`
+
+const testStrategicMergePatch = `
+patch: |-
+  apiVersion: apps/v1
+  kind: Deployment
+  metadata:
+    name: odh-dashboard
+  spec:
+    template:
+      spec:
+        containers:
+        - name: dashboard
+          env:
+          - name: FOO
+            value: bar
+`
+
+func newDeployment(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": "opendatahub",
+			"labels": map[string]any{
+				"app": name,
+			},
+		},
+		"spec": map[string]any{
+			"template": map[string]any{
+				"spec": map[string]any{
+					"containers": []any{
+						map[string]any{"name": "dashboard", "image": "dashboard:latest"},
+						map[string]any{"name": "proxy", "image": "proxy:latest"},
+					},
+				},
+			},
+		},
+	}}
+}
+
+func TestParse(t *testing.T) {
+	t.Run("json6902", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(testJSON6902Patch))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(p.Type).Should(Equal(patches.TypeJSON6902))
+		g.Expect(p.Target).Should(Equal(&patches.Selector{Group: "apps", Version: "v1", Kind: "Deployment", Name: "odh-dashboard"}))
+	})
+
+	t.Run("strategic merge with implicit target", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(testStrategicMergePatch))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(p.Type).Should(Equal(patches.TypeStrategicMerge))
+		g.Expect(p.Target).Should(Equal(&patches.Selector{Group: "apps", Version: "v1", Kind: "Deployment", Name: "odh-dashboard"}))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := map[string]string{
+			"empty patch":        `target: {kind: Deployment}`,
+			"unknown field":      "foo: bar\npatch: '{}'",
+			"missing target":     `patch: '{"spec": {"replicas": 1}}'`,
+			"invalid operation":  "target: {kind: Deployment}\npatch: '[{\"op\": \"add\"}]'",
+			"invalid selector":   "target: {kind: Deployment, labelSelector: '=='}\npatch: '{}'",
+			"not a valid object": "target: {kind: Deployment}\npatch: 'foo'",
+		}
+
+		for name, data := range tests {
+			t.Run(name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				_, err := patches.Parse("test", []byte(data))
+				g.Expect(err).Should(HaveOccurred())
+			})
+		}
+	})
+}
+
+func TestApply(t *testing.T) {
+	s, err := scheme.New()
+	NewWithT(t).Expect(err).ShouldNot(HaveOccurred())
+
+	t.Run("json6902", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(testJSON6902Patch))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := newDeployment("odh-dashboard")
+		g.Expect(p.Apply(s, obj)).Should(Succeed())
+		g.Expect(obj).Should(jq.Match(`.spec.template.spec.tolerations[0].key == "dedicated"`))
+	})
+
+	t.Run("strategic merge keeps list items", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(testStrategicMergePatch))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := newDeployment("odh-dashboard")
+		g.Expect(p.Apply(s, obj)).Should(Succeed())
+		g.Expect(obj).Should(And(
+			jq.Match(`.spec.template.spec.containers | length == 2`),
+			jq.Match(`.spec.template.spec.containers[] | select(.name == "dashboard") | .env[0].value == "bar"`),
+			jq.Match(`.spec.template.spec.containers[] | select(.name == "dashboard") | .image == "dashboard:latest"`),
+			jq.Match(`.metadata.name == "odh-dashboard"`),
+		))
+	})
+
+	t.Run("unknown types fall back to merge patch", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(`
+target:
+  kind: Foo
+patch: '{"spec": {"bar": "baz"}}'
+`))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := &unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "example.com/v1",
+			"kind":       "Foo",
+			"metadata":   map[string]any{"name": "foo"},
+			"spec":       map[string]any{"foo": "foo"},
+		}}
+
+		g.Expect(p.Apply(s, obj)).Should(Succeed())
+		g.Expect(obj).Should(And(
+			jq.Match(`.spec.foo == "foo"`),
+			jq.Match(`.spec.bar == "baz"`),
+		))
+	})
+
+	t.Run("non matching resources are left untouched", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(testJSON6902Patch))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		obj := newDeployment("other")
+		g.Expect(p.Apply(s, obj)).Should(Succeed())
+		g.Expect(obj).Should(jq.Match(`.spec.template.spec | has("tolerations") | not`))
+	})
+
+	t.Run("label selector", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(`
+target:
+  kind: Deployment
+  labelSelector: app in (other)
+patch: '{"spec": {"replicas": 3}}'
+`))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		matching := newDeployment("other")
+		g.Expect(p.Apply(s, matching)).Should(Succeed())
+		g.Expect(matching).Should(jq.Match(`.spec.replicas == 3`))
+
+		notMatching := newDeployment("odh-dashboard")
+		g.Expect(p.Apply(s, notMatching)).Should(Succeed())
+		g.Expect(notMatching).Should(jq.Match(`.spec | has("replicas") | not`))
+	})
+
+	t.Run("failing operations are reported", func(t *testing.T) {
+		g := NewWithT(t)
+
+		p, err := patches.Parse("test", []byte(`
+target:
+  kind: Deployment
+patch: '[{"op": "replace", "path": "/spec/missing/field", "value": 1}]'
+`))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		err = p.Apply(s, newDeployment("odh-dashboard"))
+		g.Expect(err).Should(MatchError(ContainSubstring("failed to apply patch test")))
+	})
+}
+
+func TestFromConfigMap(t *testing.T) {
+	g := NewWithT(t)
+
+	cm := corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "dashboard-patches", Namespace: "opendatahub"},
+		Data: map[string]string{
+			"b-smp":     testStrategicMergePatch,
+			"a-json":    testJSON6902Patch,
+			"c-invalid": `patch: ''`,
+		},
+	}
+
+	values, err := patches.FromConfigMap(&cm)
+	g.Expect(err).Should(MatchError(ContainSubstring("opendatahub/dashboard-patches[c-invalid]")))
+	g.Expect(values).Should(HaveLen(2))
+	g.Expect(values[0].Source).Should(Equal("opendatahub/dashboard-patches[a-json]"))
+	g.Expect(values[1].Source).Should(Equal("opendatahub/dashboard-patches[b-smp]"))
+}
@@ -0,0 +1,20 @@
+package patches
+
+import (
+	"context"
+
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// Transformer returns a k8s-manifest-kit transformer applying the given patches
+// to each rendered object, so it can be plugged into helm.WithTransformers.
+// Patches that cannot be applied are skipped and recorded in failures.
+func Transformer(s *runtime.Scheme, failures *Failures, values ...Patch) engineTypes.Transformer {
+	return func(_ context.Context, obj unstructured.Unstructured) (unstructured.Unstructured, error) {
+		failures.Add(ApplyAll(s, values, &obj))
+
+		return obj, nil
+	}
+}
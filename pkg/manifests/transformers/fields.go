@@ -0,0 +1,66 @@
+package transformers
+
+import (
+	"errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Wildcard matches every element of a list or every value of a map when used
+// as a path element.
+const Wildcard = "*"
+
+// RemoveField removes the field identified by path from the resources matching
+// the given GVK, mirroring plugins.RemoverPlugin for kustomize. Path elements
+// set to Wildcard match every element of a list, i.e.:
+//
+//	RemoveField(gvk.Deployment, "spec", "template", "spec", "containers", "*", "resources")
+func RemoveField(gvk schema.GroupVersionKind, path ...string) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		if len(path) == 0 {
+			return errors.New("no field set to remove, path to the field cannot be empty")
+		}
+
+		if obj.GroupVersionKind() != gvk {
+			return nil
+		}
+
+		removeField(obj.Object, path)
+
+		return nil
+	}
+}
+
+func removeField(node any, path []string) {
+	if len(path) == 1 {
+		if m, ok := node.(map[string]any); ok {
+			delete(m, path[0])
+		}
+
+		return
+	}
+
+	switch n := node.(type) {
+	case map[string]any:
+		if path[0] == Wildcard {
+			for _, v := range n {
+				removeField(v, path[1:])
+			}
+
+			return
+		}
+
+		if v, ok := n[path[0]]; ok {
+			removeField(v, path[1:])
+		}
+	case []any:
+		if path[0] != Wildcard {
+			return
+		}
+
+		for i := range n {
+			removeField(n[i], path[1:])
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package transformers
+
+import (
+	"os"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RelatedImages overrides the image of containers and init containers whose
+// repository matches one of the keys of the given map, with the value of the
+// RELATED_IMAGE_* environment variable the key maps to, i.e.:
+//
+//	RelatedImages(map[string]string{
+//	    "quay.io/opendatahub/odh-dashboard": "RELATED_IMAGE_ODH_DASHBOARD_IMAGE",
+//	})
+//
+// Images are left untouched when the environment variable is not set, which
+// mirrors how deploy.ApplyParams handles params.env files for kustomize.
+func RelatedImages(images map[string]string) Transformer {
+	return RelatedImagesFrom(images, os.Getenv)
+}
+
+// RelatedImagesFrom is like RelatedImages but resolves the images using the
+// given lookup function instead of the process environment.
+func RelatedImagesFrom(images map[string]string, lookup func(string) string) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		return visitContainers(obj, func(c map[string]any) error {
+			image, ok := c["image"].(string)
+			if !ok || image == "" {
+				return nil
+			}
+
+			env, ok := images[imageRepository(image)]
+			if !ok {
+				return nil
+			}
+
+			if value := lookup(env); value != "" {
+				c["image"] = value
+			}
+
+			return nil
+		})
+	}
+}
+
+// imageRepository strips the tag and digest from the given image reference.
+func imageRepository(image string) string {
+	if i := strings.Index(image, "@"); i != -1 {
+		image = image[:i]
+	}
+
+	// a colon after the last slash denotes a tag, before it a registry port
+	if i := strings.LastIndex(image, ":"); i != -1 && i > strings.LastIndex(image, "/") {
+		image = image[:i]
+	}
+
+	return image
+}
+
+// ImageMirror rewrites the images starting with Source to start with Mirror
+// instead. Source matches an image equal to it, or followed by a path, a tag
+// or a digest, so that quay.io/org does not match quay.io/organization.
+type ImageMirror struct {
+	Source string
+	Mirror string
+
+	// DigestOnly restricts the rule to images referenced by digest, as the
+	// ImageDigestMirrorSet rules of OpenShift.
+	DigestOnly bool
+}
+
+// ImageMirrors rewrites the image of containers and init containers matching
+// one of the given mirrors. When several mirrors match an image, the one with
+// the longest source is used, and the first one among those of equal length.
+func ImageMirrors(mirrors []ImageMirror) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		return visitContainers(obj, func(c map[string]any) error {
+			image, ok := c["image"].(string)
+			if !ok || image == "" {
+				return nil
+			}
+
+			if mirrored, ok := MirrorImage(mirrors, image); ok {
+				c["image"] = mirrored
+			}
+
+			return nil
+		})
+	}
+}
+
+// MirrorImage returns the given image rewritten by the matching mirror, and
+// whether any mirror matched it.
+func MirrorImage(mirrors []ImageMirror, image string) (string, bool) {
+	match := -1
+
+	for i, m := range mirrors {
+		if m.DigestOnly && !strings.Contains(image, "@") {
+			continue
+		}
+		if !matchesImageSource(image, m.Source) {
+			continue
+		}
+		if match == -1 || len(m.Source) > len(mirrors[match].Source) {
+			match = i
+		}
+	}
+
+	if match == -1 {
+		return image, false
+	}
+
+	return mirrors[match].Mirror + strings.TrimPrefix(image, mirrors[match].Source), true
+}
+
+func matchesImageSource(image string, source string) bool {
+	if source == "" || !strings.HasPrefix(image, source) {
+		return false
+	}
+
+	rest := image[len(source):]
+
+	return rest == "" || strings.ContainsAny(rest[:1], "/:@")
+}
+
+// Images returns the images of the containers and init containers of the
+// given resource, in order of appearance.
+func Images(obj *unstructured.Unstructured) ([]string, error) {
+	var images []string
+
+	err := visitContainers(obj.DeepCopy(), func(c map[string]any) error {
+		if image, ok := c["image"].(string); ok && image != "" {
+			images = append(images, image)
+		}
+
+		return nil
+	})
+
+	return images, err
+}
@@ -0,0 +1,64 @@
+package transformers
+
+import (
+	"maps"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Labels adds the given labels to all resources, mirroring
+// plugins.CreateSetLabelsPlugin for kustomize: on Deployments the labels are
+// also added to the pod template and to the selector.
+func Labels(values map[string]string) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		if len(values) == 0 {
+			return nil
+		}
+
+		obj.SetLabels(merge(obj.GetLabels(), values))
+
+		if obj.GetKind() != "Deployment" {
+			return nil
+		}
+
+		for _, path := range [][]string{
+			{"spec", "template", "metadata", "labels"},
+			{"spec", "selector", "matchLabels"},
+		} {
+			current, _, err := unstructured.NestedStringMap(obj.Object, path...)
+			if err != nil {
+				return err
+			}
+
+			if err := unstructured.SetNestedStringMap(obj.Object, merge(current, values), path...); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// Annotations adds the given annotations to all resources, mirroring
+// plugins.CreateAddAnnotationsPlugin for kustomize.
+func Annotations(values map[string]string) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		if len(values) == 0 {
+			return nil
+		}
+
+		obj.SetAnnotations(merge(obj.GetAnnotations(), values))
+
+		return nil
+	}
+}
+
+func merge(current map[string]string, values map[string]string) map[string]string {
+	if current == nil {
+		current = make(map[string]string, len(values))
+	}
+
+	maps.Copy(current, values)
+
+	return current
+}
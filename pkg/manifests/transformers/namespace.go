@@ -0,0 +1,82 @@
+package transformers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/resid"
+)
+
+// Namespace moves resources to the given namespace, mirroring
+// plugins.CreateNamespaceApplierPlugin for kustomize:
+//   - metadata.namespace is set on all namespaced resources, kinds unknown to
+//     the kustomize openapi schema are considered namespaced;
+//   - the namespace of ServiceAccount subjects of RoleBinding and
+//     ClusterRoleBinding is set;
+//   - the namespace of the services referenced by webhook configurations and
+//     by CRD conversion webhooks is updated if present.
+func Namespace(ns string) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		gvk := obj.GroupVersionKind()
+
+		if !resid.NewGvk(gvk.Group, gvk.Version, gvk.Kind).IsClusterScoped() {
+			obj.SetNamespace(ns)
+		}
+
+		switch {
+		case gvk.Group == "rbac.authorization.k8s.io" && (gvk.Kind == "RoleBinding" || gvk.Kind == "ClusterRoleBinding"):
+			return setSubjectsNamespace(obj, ns)
+		case gvk.Group == "admissionregistration.k8s.io" && (gvk.Kind == "ValidatingWebhookConfiguration" || gvk.Kind == "MutatingWebhookConfiguration"):
+			return setWebhooksNamespace(obj, ns)
+		case gvk.Group == "apiextensions.k8s.io" && gvk.Kind == "CustomResourceDefinition":
+			return setIfPresent(obj.Object, ns, "spec", "conversion", "webhook", "clientConfig", "service", "namespace")
+		}
+
+		return nil
+	}
+}
+
+func setSubjectsNamespace(obj *unstructured.Unstructured, ns string) error {
+	subjects, found, err := unstructured.NestedSlice(obj.Object, "subjects")
+	if err != nil || !found {
+		return err
+	}
+
+	for i := range subjects {
+		s, ok := subjects[i].(map[string]any)
+		if !ok || s["kind"] != "ServiceAccount" {
+			continue
+		}
+
+		s["namespace"] = ns
+	}
+
+	return unstructured.SetNestedSlice(obj.Object, subjects, "subjects")
+}
+
+func setWebhooksNamespace(obj *unstructured.Unstructured, ns string) error {
+	webhooks, found, err := unstructured.NestedSlice(obj.Object, "webhooks")
+	if err != nil || !found {
+		return err
+	}
+
+	for i := range webhooks {
+		w, ok := webhooks[i].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if err := setIfPresent(w, ns, "clientConfig", "service", "namespace"); err != nil {
+			return err
+		}
+	}
+
+	return unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+}
+
+func setIfPresent(obj map[string]any, value string, path ...string) error {
+	_, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+	if err != nil || !found {
+		return err
+	}
+
+	return unstructured.SetNestedField(obj, value, path...)
+}
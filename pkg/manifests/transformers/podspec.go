@@ -0,0 +1,64 @@
+package transformers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// podSpecPath returns the path of the pod spec embedded in the given
+// resource, if the resource is a known workload kind.
+func podSpecPath(obj *unstructured.Unstructured) ([]string, bool) {
+	gvk := obj.GroupVersionKind()
+
+	switch {
+	case gvk.Group == "" && gvk.Kind == "Pod":
+		return []string{"spec"}, true
+	case gvk.Group == "" && gvk.Kind == "ReplicationController":
+		return []string{"spec", "template", "spec"}, true
+	case gvk.Group == "apps" && (gvk.Kind == "Deployment" || gvk.Kind == "StatefulSet" || gvk.Kind == "DaemonSet" || gvk.Kind == "ReplicaSet"):
+		return []string{"spec", "template", "spec"}, true
+	case gvk.Group == "batch" && gvk.Kind == "Job":
+		return []string{"spec", "template", "spec"}, true
+	case gvk.Group == "batch" && gvk.Kind == "CronJob":
+		return []string{"spec", "jobTemplate", "spec", "template", "spec"}, true
+	default:
+		return nil, false
+	}
+}
+
+// visitContainers invokes fn for each container and init container of the pod
+// spec embedded in the given resource. Changes made by fn are written back.
+func visitContainers(obj *unstructured.Unstructured, fn func(container map[string]any) error) error {
+	specPath, ok := podSpecPath(obj)
+	if !ok {
+		return nil
+	}
+
+	for _, field := range []string{"initContainers", "containers"} {
+		path := append(append([]string{}, specPath...), field)
+
+		containers, found, err := unstructured.NestedSlice(obj.Object, path...)
+		if err != nil {
+			return err
+		}
+		if !found {
+			continue
+		}
+
+		for i := range containers {
+			c, ok := containers[i].(map[string]any)
+			if !ok {
+				continue
+			}
+
+			if err := fn(c); err != nil {
+				return err
+			}
+		}
+
+		if err := unstructured.SetNestedSlice(obj.Object, containers, path...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package transformers
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// PodSecurity hardens the security context of workloads so they comply with
+// the restricted pod security standard. Only values that are not explicitly
+// set in the manifests are defaulted:
+//   - pod: runAsNonRoot=true and seccompProfile.type=RuntimeDefault;
+//   - containers and init containers: allowPrivilegeEscalation=false and
+//     capabilities.drop=[ALL].
+func PodSecurity() Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		specPath, ok := podSpecPath(obj)
+		if !ok {
+			return nil
+		}
+
+		podSC := append(append([]string{}, specPath...), "securityContext")
+
+		if err := setDefault(obj.Object, true, append(podSC, "runAsNonRoot")...); err != nil {
+			return err
+		}
+		if err := setDefault(obj.Object, "RuntimeDefault", append(podSC, "seccompProfile", "type")...); err != nil {
+			return err
+		}
+
+		return visitContainers(obj, func(c map[string]any) error {
+			if err := setDefault(c, false, "securityContext", "allowPrivilegeEscalation"); err != nil {
+				return err
+			}
+
+			return setDefault(c, []any{"ALL"}, "securityContext", "capabilities", "drop")
+		})
+	}
+}
+
+func setDefault(obj map[string]any, value any, path ...string) error {
+	_, found, err := unstructured.NestedFieldNoCopy(obj, path...)
+	if err != nil || found {
+		return err
+	}
+
+	return unstructured.SetNestedField(obj, value, path...)
+}
@@ -0,0 +1,56 @@
+// Package transformers provides resource transformers that behave the same
+// regardless of the engine used to render the manifests. Each transformer
+// operates on a single unstructured object and can be plugged into the
+// kustomize engine through kustomize.WithTransformers or into the helm render
+// action through Transformer.Engine, so components migrating from kustomize
+// to helm keep an identical set of rendered resources.
+package transformers
+
+import (
+	"context"
+
+	engineTypes "github.com/k8s-manifest-kit/engine/pkg/types"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Transformer mutates a single rendered resource in place.
+type Transformer func(obj *unstructured.Unstructured) error
+
+// Engine adapts the transformer to the k8s-manifest-kit engine, so it can be
+// passed to helm.WithTransformers.
+func (t Transformer) Engine() engineTypes.Transformer {
+	return func(_ context.Context, obj unstructured.Unstructured) (unstructured.Unstructured, error) {
+		if err := t(&obj); err != nil {
+			return unstructured.Unstructured{}, err
+		}
+
+		return obj, nil
+	}
+}
+
+// Chain returns a transformer executing the given transformers in order,
+// stopping at the first error.
+func Chain(values ...Transformer) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		for i := range values {
+			if err := values[i](obj); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+// ForGroupKind restricts the given transformer to resources of the given group
+// and kind, regardless of their version.
+func ForGroupKind(gk schema.GroupKind, t Transformer) Transformer {
+	return func(obj *unstructured.Unstructured) error {
+		if obj.GroupVersionKind().GroupKind() != gk {
+			return nil
+		}
+
+		return t(obj)
+	}
+}
@@ -0,0 +1,319 @@
+package transformers_test
+
+import (
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/rs/xid"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/kustomize"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/manifests/transformers"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/plugins"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/matchers/jq"
+
+	. "github.com/onsi/gomega"
+)
+
+const testKustomization = `
+apiVersion: kustomize.config.k8s.io/v1beta1
+resources:
+- resources.yaml
+`
+
+const testResources = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test-deployment
+  labels:
+    app: test
+spec:
+  replicas: 3
+  selector:
+    matchLabels:
+      app: test
+  template:
+    metadata:
+      labels:
+        app: test
+    spec:
+      initContainers:
+      - name: init
+        image: quay.io/opendatahub/init:v1
+      containers:
+      - name: main
+        image: quay.io/opendatahub/main@sha256:0000000000000000000000000000000000000000000000000000000000000000
+        resources:
+          limits:
+            cpu: 100m
+        securityContext:
+          allowPrivilegeEscalation: true
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: test-cluster-role
+rules: []
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: test-role-binding
+  namespace: other
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: test-role
+subjects:
+- kind: ServiceAccount
+  name: test-sa
+  namespace: other
+- kind: User
+  name: test-user
+---
+apiVersion: admissionregistration.k8s.io/v1
+kind: ValidatingWebhookConfiguration
+metadata:
+  name: test-webhook
+webhooks:
+- name: test.opendatahub.io
+  clientConfig:
+    service:
+      name: test-webhook-service
+      namespace: other
+---
+apiVersion: example.com/v1
+kind: Foo
+metadata:
+  name: test-foo
+`
+
+func render(t *testing.T, opts ...kustomize.RenderOptsFn) []unstructured.Unstructured {
+	t.Helper()
+
+	g := NewWithT(t)
+	root := xid.New().String()
+	fs := filesys.MakeFsInMemory()
+
+	_ = fs.MkdirAll(path.Join(root, kustomize.DefaultKustomizationFilePath))
+	_ = fs.WriteFile(path.Join(root, kustomize.DefaultKustomizationFileName), []byte(testKustomization))
+	_ = fs.WriteFile(path.Join(root, "resources.yaml"), []byte(testResources))
+
+	r, err := kustomize.NewEngine(kustomize.WithEngineFS(fs)).Render(root, opts...)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	return r
+}
+
+func find(resources []unstructured.Unstructured, kind string) *unstructured.Unstructured {
+	for i := range resources {
+		if resources[i].GetKind() == kind {
+			return &resources[i]
+		}
+	}
+
+	return nil
+}
+
+// normalize drops the bookkeeping annotations kustomize adds when resources
+// are moved to a different namespace.
+func normalize(resources []unstructured.Unstructured) []unstructured.Unstructured {
+	for i := range resources {
+		a := resources[i].GetAnnotations()
+		for k := range a {
+			if strings.HasPrefix(k, "internal.config.kubernetes.io/") {
+				delete(a, k)
+			}
+		}
+
+		resources[i].SetAnnotations(a)
+	}
+
+	return resources
+}
+
+func apply(t *testing.T, tr transformers.Transformer) []unstructured.Unstructured {
+	t.Helper()
+
+	g := NewWithT(t)
+	resources := render(t)
+
+	for i := range resources {
+		g.Expect(tr(&resources[i])).Should(Succeed())
+	}
+
+	return resources
+}
+
+func TestRemoveField(t *testing.T) {
+	g := NewWithT(t)
+
+	resources := apply(t, transformers.Chain(
+		transformers.RemoveField(gvk.Deployment, "spec", "template", "spec", "containers", transformers.Wildcard, "resources"),
+		transformers.RemoveField(gvk.Deployment, "spec", "replicas"),
+	))
+
+	g.Expect(find(resources, "Deployment")).Should(And(
+		jq.Match(`.spec | has("replicas") | not`),
+		jq.Match(`.spec.template.spec.containers[0] | has("resources") | not`),
+		jq.Match(`.spec.template.spec.containers[0].name == "main"`),
+	))
+
+	err := transformers.RemoveField(gvk.Deployment)(find(resources, "Deployment"))
+	g.Expect(err).Should(HaveOccurred())
+}
+
+func TestRelatedImages(t *testing.T) {
+	g := NewWithT(t)
+
+	env := map[string]string{
+		"RELATED_IMAGE_MAIN": "registry.example.com/main:v2",
+	}
+
+	resources := apply(t, transformers.RelatedImagesFrom(
+		map[string]string{
+			"quay.io/opendatahub/main": "RELATED_IMAGE_MAIN",
+			"quay.io/opendatahub/init": "RELATED_IMAGE_INIT",
+		},
+		func(k string) string { return env[k] },
+	))
+
+	g.Expect(find(resources, "Deployment")).Should(And(
+		jq.Match(`.spec.template.spec.containers[0].image == "registry.example.com/main:v2"`),
+		jq.Match(`.spec.template.spec.initContainers[0].image == "quay.io/opendatahub/init:v1"`),
+	))
+}
+
+func TestImageMirrors(t *testing.T) {
+	g := NewWithT(t)
+
+	resources := apply(t, transformers.ImageMirrors([]transformers.ImageMirror{
+		{Source: "quay.io", Mirror: "registry.example.com/quay"},
+		{Source: "quay.io/opendatahub/main", Mirror: "registry.example.com/main", DigestOnly: true},
+	}))
+
+	g.Expect(find(resources, "Deployment")).Should(And(
+		jq.Match(`.spec.template.spec.containers[0].image | startswith("registry.example.com/main@sha256:")`),
+		jq.Match(`.spec.template.spec.initContainers[0].image == "registry.example.com/quay/opendatahub/init:v1"`),
+	))
+
+	images, err := transformers.Images(find(resources, "Deployment"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(images).Should(HaveLen(2))
+
+	images, err = transformers.Images(find(resources, "ClusterRole"))
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(images).Should(BeEmpty())
+}
+
+func TestMirrorImage(t *testing.T) {
+	mirrors := []transformers.ImageMirror{
+		{Source: "quay.io/org", Mirror: "mirror.example.com/org"},
+		{Source: "quay.io/org/app", Mirror: "mirror.example.com/app"},
+		{Source: "docker.io/library/busybox", Mirror: "mirror.example.com/busybox", DigestOnly: true},
+	}
+
+	tests := []struct {
+		image    string
+		expected string
+		mirrored bool
+	}{
+		{image: "quay.io/org/tool:v1", expected: "mirror.example.com/org/tool:v1", mirrored: true},
+		{image: "quay.io/org/app:v1", expected: "mirror.example.com/app:v1", mirrored: true},
+		{image: "quay.io/org/app/sub@sha256:abc", expected: "mirror.example.com/app/sub@sha256:abc", mirrored: true},
+		{image: "quay.io/organization/tool:v1", expected: "quay.io/organization/tool:v1", mirrored: false},
+		{image: "docker.io/library/busybox:1.36", expected: "docker.io/library/busybox:1.36", mirrored: false},
+		{image: "docker.io/library/busybox@sha256:abc", expected: "mirror.example.com/busybox@sha256:abc", mirrored: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			g := NewWithT(t)
+
+			image, mirrored := transformers.MirrorImage(mirrors, tt.image)
+			g.Expect(image).Should(Equal(tt.expected))
+			g.Expect(mirrored).Should(Equal(tt.mirrored))
+		})
+	}
+}
+
+func TestPodSecurity(t *testing.T) {
+	g := NewWithT(t)
+
+	resources := apply(t, transformers.PodSecurity())
+
+	g.Expect(find(resources, "Deployment")).Should(And(
+		jq.Match(`.spec.template.spec.securityContext.runAsNonRoot == true`),
+		jq.Match(`.spec.template.spec.securityContext.seccompProfile.type == "RuntimeDefault"`),
+		jq.Match(`.spec.template.spec.initContainers[0].securityContext.allowPrivilegeEscalation == false`),
+		jq.Match(`.spec.template.spec.initContainers[0].securityContext.capabilities.drop == ["ALL"]`),
+		// explicitly set values are preserved
+		jq.Match(`.spec.template.spec.containers[0].securityContext.allowPrivilegeEscalation == true`),
+		jq.Match(`.spec.template.spec.containers[0].securityContext.capabilities.drop == ["ALL"]`),
+	))
+
+	g.Expect(find(resources, "ClusterRole")).Should(
+		jq.Match(`has("spec") | not`),
+	)
+}
+
+// TestKustomizeParity ensures the transformers produce the same resources as
+// the kustomize plugins they replace.
+func TestKustomizeParity(t *testing.T) {
+	g := NewWithT(t)
+	ns := xid.New().String()
+
+	labels := map[string]string{"platform.opendatahub.io/part-of": "test"}
+	annotations := map[string]string{"platform.opendatahub.io/release": "1.2.3"}
+
+	expected := normalize(render(t,
+		kustomize.WithNamespace(ns),
+		kustomize.WithLabels(labels),
+		kustomize.WithAnnotations(annotations),
+		kustomize.WithPlugin(&plugins.RemoverPlugin{
+			Gvk:  gvk.Deployment,
+			Path: []string{"spec", "template", "spec", "containers", "*", "resources"},
+		}),
+	))
+
+	chain := transformers.Chain(
+		transformers.Namespace(ns),
+		transformers.Labels(labels),
+		transformers.Annotations(annotations),
+		transformers.RemoveField(gvk.Deployment, "spec", "template", "spec", "containers", transformers.Wildcard, "resources"),
+	)
+
+	t.Run("kustomize", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(normalize(render(t, kustomize.WithTransformers(chain)))).Should(Equal(expected))
+	})
+
+	t.Run("engine", func(t *testing.T) {
+		g := NewWithT(t)
+
+		resources := render(t)
+		for i := range resources {
+			r, err := chain.Engine()(t.Context(), resources[i])
+			g.Expect(err).NotTo(HaveOccurred())
+
+			resources[i] = r
+		}
+
+		g.Expect(resources).Should(Equal(expected))
+	})
+
+	g.Expect(find(expected, "ClusterRole")).Should(jq.Match(`.metadata | has("namespace") | not`))
+	g.Expect(find(expected, "Foo")).Should(jq.Match(`.metadata.namespace == "%s"`, ns))
+	g.Expect(find(expected, "RoleBinding")).Should(And(
+		jq.Match(`.subjects[0].namespace == "%s"`, ns),
+		jq.Match(`.subjects[1] | has("namespace") | not`),
+	))
+	g.Expect(find(expected, "ValidatingWebhookConfiguration")).Should(
+		jq.Match(`.webhooks[0].clientConfig.service.namespace == "%s"`, ns),
+	)
+}
@@ -22,7 +22,22 @@ type ManagedResource struct {
 	Namespace string `json:"namespace"`
 }
 
-func fetchManagedResources(ctx context.Context, c client.Client, component, appsNS string) ([]ManagedResource, error) {
+// fetchManagedResources returns the resources deployed for the component. The inventory
+// published by the operator is used when available, otherwise the common namespaced kinds
+// are scanned by label.
+func fetchManagedResources(ctx context.Context, c client.Client, component, appsNS, operatorNS string) ([]ManagedResource, error) {
+	inventory, found, err := clusterhealth.GetInventory(ctx, c, operatorNS, component)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		out := make([]ManagedResource, 0, len(inventory))
+		for _, r := range inventory {
+			out = append(out, ManagedResource{r.Kind, r.Name, r.Namespace})
+		}
+		return out, nil
+	}
+
 	opts := []client.ListOption{
 		client.InNamespace(appsNS),
 		client.MatchingLabels{"app.opendatahub.io/" + component: "true"},
@@ -70,6 +85,8 @@ func registerComponentStatus(s *server.MCPServer, kubeClient client.Client) {
 			mcp.Description("Component name, e.g. kserve, dashboard, workbenches")),
 		mcp.WithString("applications_namespace",
 			mcp.Description("Apps namespace. Auto-discovered from DSCI if not provided. Returns an error if DSCI discovery fails due to RBAC or missing CRD. Falls back to E2E_TEST_APPLICATIONS_NAMESPACE env var or 'opendatahub'.")),
+		mcp.WithString("operator_namespace",
+			mcp.Description("Operator namespace, holding the resource inventories. Auto-discovered from env or defaults to opendatahub-operator-system.")),
 	)
 
 	s.AddTool(tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -82,8 +99,13 @@ func registerComponentStatus(s *server.MCPServer, kubeClient client.Client) {
 			}
 		}
 
+		operatorNS := stringParam(req, "operator_namespace", "")
+		if operatorNS == "" {
+			operatorNS = discoverOperatorNamespace()
+		}
+
 		component := stringParam(req, "component", "")
-		result, err := clusterhealth.GetComponentStatus(ctx, kubeClient, component, appsNS)
+		result, err := clusterhealth.GetComponentStatus(ctx, kubeClient, component, appsNS, operatorNS)
 		if err != nil {
 			switch {
 			case k8serr.IsForbidden(err):
@@ -100,7 +122,7 @@ func registerComponentStatus(s *server.MCPServer, kubeClient client.Client) {
 			}
 		}
 
-		managed, err := fetchManagedResources(ctx, kubeClient, component, appsNS)
+		managed, err := fetchManagedResources(ctx, kubeClient, component, appsNS, operatorNS)
 		if err != nil {
 			log.Printf("component_status: managed resources for %q: %v", component, err)
 			return mcp.NewToolResultError(fmt.Sprintf("failed to fetch managed resources for component %q", component)), nil
@@ -3,6 +3,7 @@ package mcptools
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/server"
@@ -20,14 +21,14 @@ func TestComponentStatus(t *testing.T) {
 	cl := newFakeClient()
 
 	t.Run("unknown component", func(t *testing.T) {
-		_, err := clusterhealth.GetComponentStatus(context.Background(), cl, "bogus", DefaultAppsNS)
+		_, err := clusterhealth.GetComponentStatus(context.Background(), cl, "bogus", DefaultAppsNS, DefaultOperatorNS)
 		if err == nil {
 			t.Error("expected error for unknown component")
 		}
 	})
 
 	t.Run("no CR exists", func(t *testing.T) {
-		r, err := clusterhealth.GetComponentStatus(context.Background(), cl, "kserve", DefaultAppsNS)
+		r, err := clusterhealth.GetComponentStatus(context.Background(), cl, "kserve", DefaultAppsNS, DefaultOperatorNS)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -52,7 +53,7 @@ func TestComponentStatus_ErrorClients(t *testing.T) {
 	for _, tt := range tests {
 		for _, comp := range components {
 			t.Run(tt.name+"/"+comp, func(t *testing.T) {
-				_, err := clusterhealth.GetComponentStatus(context.Background(), tt.client, comp, DefaultAppsNS)
+				_, err := clusterhealth.GetComponentStatus(context.Background(), tt.client, comp, DefaultAppsNS, DefaultOperatorNS)
 				if err == nil {
 					t.Fatalf("expected error for component %q, got nil", comp)
 				}
@@ -87,7 +88,7 @@ func TestComponentStatus_DeploymentsAndPods(t *testing.T) {
 		},
 	)
 
-	r, err := clusterhealth.GetComponentStatus(context.Background(), cl, "kserve", DefaultAppsNS)
+	r, err := clusterhealth.GetComponentStatus(context.Background(), cl, "kserve", DefaultAppsNS, DefaultOperatorNS)
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -99,6 +100,44 @@ func TestComponentStatus_DeploymentsAndPods(t *testing.T) {
 	}
 }
 
+func TestComponentStatus_Inventory(t *testing.T) {
+	replicas := int32(1)
+	cl := newFakeClient(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "kserve-gvk-inventory", Namespace: DefaultOperatorNS,
+				Labels: map[string]string{clusterhealth.InventoryLabel: "kserve"},
+			},
+			Data: map[string]string{clusterhealth.InventoryDataKey: `{"resources":[` +
+				`{"group":"apps","version":"v1","kind":"Deployment","namespace":"` + DefaultAppsNS + `","name":"kserve-ctrl","resourceVersion":"1"},` +
+				`{"group":"apps","version":"v1","kind":"Deployment","namespace":"` + DefaultAppsNS + `","name":"kserve-gone"}]}`},
+		},
+		// not labelled, found through the inventory only
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "kserve-ctrl", Namespace: DefaultAppsNS},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "kserve"}},
+			},
+			Status: appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+	)
+
+	r, err := clusterhealth.GetComponentStatus(context.Background(), cl, "kserve", DefaultAppsNS, DefaultOperatorNS)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(r.Resources) != 2 {
+		t.Errorf("Resources = %+v, want 2", r.Resources)
+	}
+	if len(r.Deployments) != 1 || r.Deployments[0].Name != "kserve-ctrl" {
+		t.Errorf("Deployments = %+v, want kserve-ctrl", r.Deployments)
+	}
+	if len(r.Errors) != 1 || !strings.Contains(r.Errors[0], "kserve-gone") {
+		t.Errorf("Errors = %v, want missing kserve-gone", r.Errors)
+	}
+}
+
 func TestFetchManagedResources(t *testing.T) {
 	callTool := func(t *testing.T, cl client.Client, component string) string {
 		t.Helper()
@@ -121,7 +160,7 @@ func TestFetchManagedResources(t *testing.T) {
 	}
 
 	t.Run("no resources in empty cluster", func(t *testing.T) {
-		resources, err := fetchManagedResources(context.Background(), newFakeClient(), "dashboard", DefaultAppsNS)
+		resources, err := fetchManagedResources(context.Background(), newFakeClient(), "dashboard", DefaultAppsNS, DefaultOperatorNS)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -164,4 +203,36 @@ func TestFetchManagedResources(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("inventory takes precedence over labels", func(t *testing.T) {
+		cl := newFakeClient(
+			&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "kserve-gvk-inventory", Namespace: DefaultOperatorNS,
+					Labels: map[string]string{clusterhealth.InventoryLabel: "kserve"},
+				},
+				Data: map[string]string{clusterhealth.InventoryDataKey: `{"resources":[` +
+					`{"group":"apps","version":"v1","kind":"Deployment","namespace":"` + DefaultAppsNS + `","name":"kserve-ctrl"},` +
+					`{"group":"rbac.authorization.k8s.io","version":"v1","kind":"ClusterRole","name":"kserve-role"}]}`},
+			},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "webhook-svc", Namespace: DefaultAppsNS, Labels: map[string]string{"app.opendatahub.io/kserve": "true"}}},
+		)
+
+		resources, err := fetchManagedResources(context.Background(), cl, "kserve", DefaultAppsNS, DefaultOperatorNS)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		want := []ManagedResource{
+			{Kind: "Deployment", Name: "kserve-ctrl", Namespace: DefaultAppsNS},
+			{Kind: "ClusterRole", Name: "kserve-role"},
+		}
+		if len(resources) != len(want) {
+			t.Fatalf("got %+v, want %+v", resources, want)
+		}
+		for i := range want {
+			if resources[i] != want[i] {
+				t.Errorf("resources[%d] = %+v, want %+v", i, resources[i], want[i])
+			}
+		}
+	})
 }
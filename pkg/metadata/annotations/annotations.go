@@ -62,3 +62,29 @@ const ConnectionTypeProtocol = "opendatahub.io/connection-type-protocol"
 // ConnectionPath annotation for specifying the path under bucket(s3) to use for the connection.
 // TODO: extend to oci.
 const ConnectionPath = "opendatahub.io/connection-path"
+
+// InstallProfile records the install profile the default DSCInitialization and
+// DataScienceCluster were created from.
+const InstallProfile = "platform.opendatahub.io/install-profile"
+
+// MaintenanceOverride, when set to "true" on the DSCInitialization or on a
+// reconciled instance, makes the operator apply disruptive changes right away
+// instead of waiting for the next maintenance window.
+const MaintenanceOverride = "platform.opendatahub.io/maintenance-override"
+
+// DisruptiveConfigHash records the hash of the disruptive fields of a resource
+// as last applied by the operator, so pending disruptive changes can be detected
+// without comparing against server defaulted values.
+const DisruptiveConfigHash = "platform.opendatahub.io/disruptive-config-hash"
+
+// DependencyChart and DependencyChartVersion record, on the resources of a
+// cloud manager dependency, the chart they were rendered from and its version.
+const (
+	DependencyChart        = "infrastructure.opendatahub.io/chart"
+	DependencyChartVersion = "infrastructure.opendatahub.io/chart-version"
+)
+
+// DependencyValuesHash records, on the pod template of the deployments of a
+// cloud manager dependency, the hash of the chart values overridden by the
+// user, so that the pods are rolled out when they change.
+const DependencyValuesHash = "infrastructure.opendatahub.io/values-hash"
@@ -11,6 +11,9 @@ const (
 	ClusterMonitoring       = "openshift.io/cluster-monitoring"
 	PlatformPartOf          = ODHPlatformPrefix + "/part-of"
 	PlatformDependency      = ODHPlatformPrefix + "/dependency"
+	ComponentPatches        = ODHPlatformPrefix + "/patches"
+	GVKInventory            = ODHPlatformPrefix + "/gvk-inventory"
+	MigrationLedger         = ODHPlatformPrefix + "/migration-ledger"
 	InfrastructurePartOf    = ODHInfrastructurePrefix + "/part-of"
 	Platform                = "platform"
 	True                    = "true"
@@ -40,10 +43,12 @@ var GatewayAPI = struct {
 
 // ODH holds Open Data Hub specific labels grouped by types.
 var ODH = struct {
-	OwnedNamespace string
-	Component      func(string) string
+	OwnedNamespace   string
+	DashboardProject string
+	Component        func(string) string
 }{
-	OwnedNamespace: "opendatahub.io/generated-namespace",
+	OwnedNamespace:   "opendatahub.io/generated-namespace",
+	DashboardProject: "opendatahub.io/dashboard",
 	Component: func(name string) string {
 		return ODHAppPrefix + "/" + name
 	},
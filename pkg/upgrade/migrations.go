@@ -0,0 +1,484 @@
+package upgrade
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/blang/semver/v4"
+	"github.com/hashicorp/go-multierror"
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+)
+
+const (
+	// MigrationLedgerName is the name of the ConfigMap, in the application
+	// namespace, recording the outcome of the migrations run by the operator.
+	// Each migration is stored under its name as a JSON encoded LedgerEntry.
+	MigrationLedgerName = "opendatahub-migration-ledger"
+)
+
+// MigrationOutcome is the outcome of a migration run.
+type MigrationOutcome string
+
+const (
+	MigrationSucceeded MigrationOutcome = "Succeeded"
+	MigrationFailed    MigrationOutcome = "Failed"
+)
+
+// MigrationState describes what a run of the registry would do with a
+// migration.
+type MigrationState string
+
+const (
+	// MigrationPending means the migration would run.
+	MigrationPending MigrationState = "Pending"
+	// MigrationCompleted means the migration already succeeded and is skipped.
+	MigrationCompleted MigrationState = "Completed"
+	// MigrationNotApplicable means the migration version constraints do not
+	// match the upgrade.
+	MigrationNotApplicable MigrationState = "NotApplicable"
+	// MigrationPreconditionNotMet means the migration precondition is false,
+	// it is evaluated again on the next run.
+	MigrationPreconditionNotMet MigrationState = "PreconditionNotMet"
+)
+
+// MigrationEnv carries the inputs available to a migration.
+type MigrationEnv struct {
+	Client               client.Client
+	ApplicationNamespace string
+	ManifestsBasePath    string
+	// FromVersion is the operator version deployed before the upgrade, it is
+	// the zero version on fresh installations.
+	FromVersion semver.Version
+	// ToVersion is the running operator version.
+	ToVersion semver.Version
+}
+
+// IsDowngrade reports whether the deployed version is newer than the running
+// one, i.e. the operator runs in release-downgrade mode.
+func (e MigrationEnv) IsDowngrade() bool {
+	return e.FromVersion.GT(e.ToVersion)
+}
+
+// Migration is a one-time data migration run at operator startup.
+//
+// A migration runs when FromVersion and ToVersion match the From and To
+// ranges and Precondition returns true. Once Run and Verify succeed the
+// outcome is recorded in the ledger and the migration is not run again,
+// unless it is Repeatable; failed migrations are retried on the next startup.
+//
+// Reverse migrations are only run in release-downgrade mode, when the
+// deployed version, matched by From, is newer than the running one, matched
+// by To. They undo the changes of the migration named by Reverts, which is
+// dropped from the ledger so that it runs again on the next upgrade.
+type Migration struct {
+	// Name uniquely identifies the migration in the ledger.
+	Name        string
+	Description string
+	// Reverts is the name of the migration undone by a reverse migration.
+	// Optional, reverse migrations only.
+	Reverts string
+	// From is a semver range the deployed version must match, empty matches
+	// any version.
+	From string
+	// To is a semver range the running version must match, empty matches any
+	// version.
+	To string
+	// Repeatable migrations are run on every startup, their outcome is
+	// recorded in the ledger but does not prevent them from running again.
+	// Meant for the checks which must keep converging the cluster state.
+	Repeatable bool
+	// Precondition reports whether the migration can run, e.g. the CRDs it
+	// touches are installed. Optional.
+	Precondition func(ctx context.Context, env MigrationEnv) (bool, error)
+	// Run performs the migration, it must be idempotent as a failed migration
+	// is run again.
+	Run func(ctx context.Context, env MigrationEnv) error
+	// Verify checks the migration outcome after Run. Optional.
+	Verify func(ctx context.Context, env MigrationEnv) error
+}
+
+// LedgerEntry records the last outcome of a migration.
+type LedgerEntry struct {
+	Timestamp       metav1.Time      `json:"timestamp"`
+	OperatorVersion string           `json:"operatorVersion"`
+	Outcome         MigrationOutcome `json:"outcome"`
+	Message         string           `json:"message,omitempty"`
+}
+
+// PlannedMigration is the dry-run result for a migration.
+type PlannedMigration struct {
+	Name        string
+	Description string
+	State       MigrationState
+}
+
+// Registry is an ordered set of migrations.
+type Registry struct {
+	mu         sync.RWMutex
+	migrations []Migration
+	reverse    []Migration
+}
+
+// NewRegistry returns an empty migration registry.
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// DefaultRegistry holds the migrations run by CleanupExistingResource.
+var DefaultRegistry = NewRegistry()
+
+// Register appends a migration to the registry, migrations run in
+// registration order. It is meant to be called from init functions and
+// panics if the migration is invalid or its name is already registered.
+func (r *Registry) Register(m Migration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if m.Reverts != "" {
+		panic(fmt.Sprintf("migration %q reverts %q but is not a reverse migration", m.Name, m.Reverts))
+	}
+
+	r.register(&r.migrations, m)
+}
+
+// RegisterReverse appends a reverse migration to the registry, reverse
+// migrations run in registration order in release-downgrade mode. It panics
+// like Register.
+func (r *Registry) RegisterReverse(m Migration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.register(&r.reverse, m)
+}
+
+func (r *Registry) register(migrations *[]Migration, m Migration) {
+	if err := validateMigration(m); err != nil {
+		panic(err.Error())
+	}
+
+	for _, existing := range slices.Concat(r.migrations, r.reverse) {
+		if existing.Name == m.Name {
+			panic(fmt.Sprintf("migration %q already registered", m.Name))
+		}
+	}
+
+	*migrations = append(*migrations, m)
+}
+
+// Migrations returns the registered migrations in registration order.
+func (r *Registry) Migrations() []Migration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Migration(nil), r.migrations...)
+}
+
+// ReverseMigrations returns the registered reverse migrations in registration
+// order.
+func (r *Registry) ReverseMigrations() []Migration {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Migration(nil), r.reverse...)
+}
+
+// migrationsFor returns the migrations to consider for the given environment:
+// the reverse ones in release-downgrade mode, the regular ones otherwise.
+func (r *Registry) migrationsFor(env MigrationEnv) []Migration {
+	if env.IsDowngrade() {
+		return r.ReverseMigrations()
+	}
+
+	return r.Migrations()
+}
+
+// revertedBy returns the names of the reverse migrations undoing m.
+func (r *Registry) revertedBy(m Migration) []string {
+	names := make([]string, 0)
+	for _, rm := range r.ReverseMigrations() {
+		if rm.Reverts == m.Name {
+			names = append(names, rm.Name)
+		}
+	}
+
+	return names
+}
+
+// Plan reports, without running anything, what Run would do with each
+// registered migration, or each reverse migration in release-downgrade mode.
+func (r *Registry) Plan(ctx context.Context, env MigrationEnv) ([]PlannedMigration, error) {
+	ledger, err := ReadLedger(ctx, env.Client, env.ApplicationNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	var multiErr *multierror.Error
+
+	migrations := r.migrationsFor(env)
+	plan := make([]PlannedMigration, 0, len(migrations))
+
+	for _, m := range migrations {
+		state, err := migrationState(ctx, m, env, ledger)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		plan = append(plan, PlannedMigration{Name: m.Name, Description: m.Description, State: state})
+	}
+
+	return plan, multiErr.ErrorOrNil()
+}
+
+// Run runs the pending migrations in registration order and records their
+// outcome in the ledger. In release-downgrade mode the reverse migrations are
+// run instead. A failing migration does not prevent the next ones from
+// running, all errors are returned.
+func (r *Registry) Run(ctx context.Context, env MigrationEnv) error {
+	log := logf.FromContext(ctx)
+
+	ledger, err := ReadLedger(ctx, env.Client, env.ApplicationNamespace)
+	if err != nil {
+		return err
+	}
+
+	var multiErr *multierror.Error
+
+	for _, m := range r.migrationsFor(env) {
+		state, err := migrationState(ctx, m, env, ledger)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		if state != MigrationPending {
+			log.V(1).Info("skipping migration", "migration", m.Name, "state", state)
+			continue
+		}
+
+		log.Info("running migration", "migration", m.Name, "from", env.FromVersion.String(), "to", env.ToVersion.String())
+
+		runErr := runMigration(ctx, m, env)
+
+		entry := LedgerEntry{
+			Timestamp:       metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+			OperatorVersion: env.ToVersion.String(),
+			Outcome:         MigrationSucceeded,
+		}
+
+		// a successful migration makes the one it is the reverse of, or the
+		// reverse migrations undoing it, eligible to run again.
+		var forget []string
+		switch {
+		case runErr != nil:
+			entry.Outcome = MigrationFailed
+			entry.Message = runErr.Error()
+			multiErr = multierror.Append(multiErr, runErr)
+		case m.Reverts != "":
+			forget = []string{m.Reverts}
+		default:
+			forget = r.revertedBy(m)
+		}
+
+		if err := recordMigration(ctx, env.Client, env.ApplicationNamespace, m.Name, entry, forget...); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
+// PendingMigrations is a dry-run of the default registry against the cluster,
+// it returns the migrations the next operator startup would run.
+func PendingMigrations(ctx context.Context, cli client.Client, basePath string) ([]PlannedMigration, error) {
+	env, err := NewMigrationEnv(ctx, cli, basePath)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	plan, err := DefaultRegistry.Plan(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make([]PlannedMigration, 0, len(plan))
+	for _, p := range plan {
+		if p.State == MigrationPending {
+			pending = append(pending, p)
+		}
+	}
+
+	return pending, nil
+}
+
+// NewMigrationEnv builds the migration environment from the cluster state. A
+// NotFound error is returned if the application namespace is not known yet.
+func NewMigrationEnv(ctx context.Context, cli client.Client, basePath string) (MigrationEnv, error) {
+	applicationNS, err := cluster.ApplicationNamespace(ctx, cli)
+	if err != nil {
+		return MigrationEnv{}, err
+	}
+
+	deployed, err := cluster.GetDeployedRelease(ctx, cli)
+	if err != nil {
+		return MigrationEnv{}, fmt.Errorf("failed to get deployed release: %w", err)
+	}
+
+	return MigrationEnv{
+		Client:               cli,
+		ApplicationNamespace: applicationNS,
+		ManifestsBasePath:    basePath,
+		FromVersion:          deployed.Version.Version,
+		ToVersion:            cluster.GetRelease().Version.Version,
+	}, nil
+}
+
+// ReadLedger returns the ledger entries keyed by migration name. An empty
+// ledger is returned if the ledger ConfigMap does not exist.
+func ReadLedger(ctx context.Context, cli client.Client, namespace string) (map[string]LedgerEntry, error) {
+	cm := corev1.ConfigMap{}
+
+	err := cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: MigrationLedgerName}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		return map[string]LedgerEntry{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get migration ledger: %w", err)
+	}
+
+	ledger := make(map[string]LedgerEntry, len(cm.Data))
+	for name, data := range cm.Data {
+		entry := LedgerEntry{}
+		if err := json.Unmarshal([]byte(data), &entry); err != nil {
+			return nil, fmt.Errorf("failed to decode migration ledger entry %s: %w", name, err)
+		}
+		ledger[name] = entry
+	}
+
+	return ledger, nil
+}
+
+func validateMigration(m Migration) error {
+	if m.Name == "" {
+		return errors.New("migration name must be set")
+	}
+	if m.Run == nil {
+		return fmt.Errorf("migration %q has no run func", m.Name)
+	}
+	if _, err := parseVersionRange(m.From); err != nil {
+		return fmt.Errorf("migration %q has an invalid from range: %w", m.Name, err)
+	}
+	if _, err := parseVersionRange(m.To); err != nil {
+		return fmt.Errorf("migration %q has an invalid to range: %w", m.Name, err)
+	}
+
+	return nil
+}
+
+func parseVersionRange(r string) (semver.Range, error) {
+	if r == "" {
+		return func(semver.Version) bool { return true }, nil
+	}
+
+	return semver.ParseRange(r)
+}
+
+func migrationState(ctx context.Context, m Migration, env MigrationEnv, ledger map[string]LedgerEntry) (MigrationState, error) {
+	if entry, ok := ledger[m.Name]; ok && entry.Outcome == MigrationSucceeded && !m.Repeatable {
+		return MigrationCompleted, nil
+	}
+
+	// ranges are validated on registration
+	from, _ := parseVersionRange(m.From)
+	to, _ := parseVersionRange(m.To)
+
+	if !from(env.FromVersion) || !to(env.ToVersion) {
+		return MigrationNotApplicable, nil
+	}
+
+	if m.Precondition != nil {
+		ok, err := m.Precondition(ctx, env)
+		if err != nil {
+			return "", err
+		}
+		if !ok {
+			return MigrationPreconditionNotMet, nil
+		}
+	}
+
+	return MigrationPending, nil
+}
+
+func runMigration(ctx context.Context, m Migration, env MigrationEnv) error {
+	if err := m.Run(ctx, env); err != nil {
+		return fmt.Errorf("migration %s failed: %w", m.Name, err)
+	}
+
+	if m.Verify != nil {
+		if err := m.Verify(ctx, env); err != nil {
+			return fmt.Errorf("migration %s verification failed: %w", m.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// recordMigration stores the entry of the named migration in the ledger and
+// drops the entries of the migrations to forget.
+func recordMigration(ctx context.Context, cli client.Client, namespace string, name string, entry LedgerEntry, forget ...string) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode migration ledger entry %s: %w", name, err)
+	}
+
+	cm := corev1.ConfigMap{}
+
+	err = cli.Get(ctx, client.ObjectKey{Namespace: namespace, Name: MigrationLedgerName}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      MigrationLedgerName,
+				Namespace: namespace,
+				Labels:    map[string]string{labels.MigrationLedger: labels.True},
+			},
+			Data: map[string]string{name: string(data)},
+		}
+		if err := cli.Create(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to create migration ledger: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get migration ledger: %w", err)
+	}
+
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[name] = string(data)
+
+	for _, f := range forget {
+		delete(cm.Data, f)
+	}
+
+	if err := cli.Update(ctx, &cm); err != nil {
+		return fmt.Errorf("failed to update migration ledger: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,291 @@
+package upgrade_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func newMigrationEnv(g *WithT, from string, to string) upgrade.MigrationEnv {
+	cli, err := fakeclient.New(fakeclient.WithObjects(newTestDSCI(testAppNamespace)))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	return upgrade.MigrationEnv{
+		Client:               cli,
+		ApplicationNamespace: testAppNamespace,
+		FromVersion:          semver.MustParse(from),
+		ToVersion:            semver.MustParse(to),
+	}
+}
+
+func TestMigrationRegistry_Run(t *testing.T) {
+	ctx := t.Context()
+
+	t.Run("should record succeeded migrations and not run them again", func(t *testing.T) {
+		g := NewWithT(t)
+		env := newMigrationEnv(g, "2.25.0", "3.0.0")
+
+		runs := 0
+		verified := 0
+
+		r := upgrade.NewRegistry()
+		r.Register(upgrade.Migration{
+			Name: "counter",
+			Run: func(context.Context, upgrade.MigrationEnv) error {
+				runs++
+				return nil
+			},
+			Verify: func(context.Context, upgrade.MigrationEnv) error {
+				verified++
+				return nil
+			},
+		})
+
+		for range 2 {
+			g.Expect(r.Run(ctx, env)).Should(Succeed())
+		}
+
+		g.Expect(runs).Should(Equal(1))
+		g.Expect(verified).Should(Equal(1))
+
+		ledger, err := upgrade.ReadLedger(ctx, env.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger).Should(HaveKey("counter"))
+		g.Expect(ledger["counter"].Outcome).Should(Equal(upgrade.MigrationSucceeded))
+		g.Expect(ledger["counter"].OperatorVersion).Should(Equal("3.0.0"))
+		g.Expect(ledger["counter"].Timestamp.Time.IsZero()).Should(BeFalse())
+	})
+
+	t.Run("should run repeatable migrations on every run", func(t *testing.T) {
+		g := NewWithT(t)
+		env := newMigrationEnv(g, "2.25.0", "3.0.0")
+
+		runs := 0
+
+		r := upgrade.NewRegistry()
+		r.Register(upgrade.Migration{
+			Name:       "converge",
+			Repeatable: true,
+			Run: func(context.Context, upgrade.MigrationEnv) error {
+				runs++
+				return nil
+			},
+		})
+
+		for range 2 {
+			g.Expect(r.Run(ctx, env)).Should(Succeed())
+		}
+
+		g.Expect(runs).Should(Equal(2))
+
+		ledger, err := upgrade.ReadLedger(ctx, env.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger["converge"].Outcome).Should(Equal(upgrade.MigrationSucceeded))
+	})
+
+	t.Run("should record failed migrations and retry them", func(t *testing.T) {
+		g := NewWithT(t)
+		env := newMigrationEnv(g, "2.25.0", "3.0.0")
+
+		fail := true
+		runs := 0
+
+		r := upgrade.NewRegistry()
+		r.Register(upgrade.Migration{
+			Name: "flaky",
+			Run: func(context.Context, upgrade.MigrationEnv) error {
+				runs++
+				return nil
+			},
+			Verify: func(context.Context, upgrade.MigrationEnv) error {
+				if fail {
+					return errors.New("not migrated")
+				}
+				return nil
+			},
+		})
+		r.Register(upgrade.Migration{
+			Name: "next",
+			Run:  func(context.Context, upgrade.MigrationEnv) error { return nil },
+		})
+
+		err := r.Run(ctx, env)
+		g.Expect(err).Should(MatchError(ContainSubstring("migration flaky verification failed: not migrated")))
+
+		ledger, err := upgrade.ReadLedger(ctx, env.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger["flaky"].Outcome).Should(Equal(upgrade.MigrationFailed))
+		g.Expect(ledger["flaky"].Message).Should(ContainSubstring("not migrated"))
+		g.Expect(ledger["next"].Outcome).Should(Equal(upgrade.MigrationSucceeded))
+
+		fail = false
+		g.Expect(r.Run(ctx, env)).Should(Succeed())
+		g.Expect(runs).Should(Equal(2))
+
+		ledger, err = upgrade.ReadLedger(ctx, env.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger["flaky"].Outcome).Should(Equal(upgrade.MigrationSucceeded))
+		g.Expect(ledger["flaky"].Message).Should(BeEmpty())
+	})
+
+	t.Run("should not record migrations whose precondition is not met", func(t *testing.T) {
+		g := NewWithT(t)
+		env := newMigrationEnv(g, "2.25.0", "3.0.0")
+
+		ready := false
+
+		r := upgrade.NewRegistry()
+		r.Register(upgrade.Migration{
+			Name: "needs-crd",
+			Precondition: func(context.Context, upgrade.MigrationEnv) (bool, error) {
+				return ready, nil
+			},
+			Run: func(context.Context, upgrade.MigrationEnv) error { return nil },
+		})
+
+		g.Expect(r.Run(ctx, env)).Should(Succeed())
+
+		ledger, err := upgrade.ReadLedger(ctx, env.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger).Should(BeEmpty())
+
+		ready = true
+		g.Expect(r.Run(ctx, env)).Should(Succeed())
+
+		ledger, err = upgrade.ReadLedger(ctx, env.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger).Should(HaveKey("needs-crd"))
+	})
+
+	t.Run("should run reverse migrations on downgrade and rerun the reverted ones on upgrade", func(t *testing.T) {
+		g := NewWithT(t)
+		upgradeEnv := newMigrationEnv(g, "3.0.0", "3.1.0")
+
+		downgradeEnv := upgradeEnv
+		downgradeEnv.FromVersion, downgradeEnv.ToVersion = upgradeEnv.ToVersion, upgradeEnv.FromVersion
+		g.Expect(downgradeEnv.IsDowngrade()).Should(BeTrue())
+
+		runs := map[string]int{}
+		count := func(name string) func(context.Context, upgrade.MigrationEnv) error {
+			return func(context.Context, upgrade.MigrationEnv) error {
+				runs[name]++
+				return nil
+			}
+		}
+
+		r := upgrade.NewRegistry()
+		r.Register(upgrade.Migration{Name: "split-config", To: ">=3.1.0", Run: count("split-config")})
+		r.RegisterReverse(upgrade.Migration{Name: "merge-config", Reverts: "split-config", From: ">=3.1.0", To: "<3.1.0", Run: count("merge-config")})
+
+		g.Expect(r.Run(ctx, upgradeEnv)).Should(Succeed())
+		g.Expect(runs).Should(Equal(map[string]int{"split-config": 1}))
+
+		g.Expect(r.Run(ctx, downgradeEnv)).Should(Succeed())
+		g.Expect(r.Run(ctx, downgradeEnv)).Should(Succeed())
+		g.Expect(runs).Should(Equal(map[string]int{"split-config": 1, "merge-config": 1}))
+
+		ledger, err := upgrade.ReadLedger(ctx, upgradeEnv.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger).Should(HaveKey("merge-config"))
+		g.Expect(ledger).ShouldNot(HaveKey("split-config"))
+
+		g.Expect(r.Run(ctx, upgradeEnv)).Should(Succeed())
+		g.Expect(runs).Should(Equal(map[string]int{"split-config": 2, "merge-config": 1}))
+
+		ledger, err = upgrade.ReadLedger(ctx, upgradeEnv.Client, testAppNamespace)
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(ledger).Should(HaveKey("split-config"))
+		g.Expect(ledger).ShouldNot(HaveKey("merge-config"))
+	})
+}
+
+func TestMigrationRegistry_Plan(t *testing.T) {
+	ctx := t.Context()
+	g := NewWithT(t)
+
+	env := newMigrationEnv(g, "2.25.0", "3.0.0")
+
+	ran := false
+	run := func(context.Context, upgrade.MigrationEnv) error {
+		ran = true
+		return nil
+	}
+
+	r := upgrade.NewRegistry()
+	r.Register(upgrade.Migration{Name: "from-2x", From: "<3.0.0", To: ">=3.0.0", Run: run})
+	r.Register(upgrade.Migration{Name: "from-3x", From: ">=3.0.0", Run: run})
+	r.Register(upgrade.Migration{Name: "to-4x", To: ">=4.0.0", Run: run})
+	r.Register(upgrade.Migration{
+		Name:         "blocked",
+		Precondition: func(context.Context, upgrade.MigrationEnv) (bool, error) { return false, nil },
+		Run:          run,
+	})
+
+	plan, err := r.Plan(ctx, env)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(ran).Should(BeFalse())
+	g.Expect(plan).Should(Equal([]upgrade.PlannedMigration{
+		{Name: "from-2x", State: upgrade.MigrationPending},
+		{Name: "from-3x", State: upgrade.MigrationNotApplicable},
+		{Name: "to-4x", State: upgrade.MigrationNotApplicable},
+		{Name: "blocked", State: upgrade.MigrationPreconditionNotMet},
+	}))
+
+	g.Expect(r.Run(ctx, env)).Should(Succeed())
+
+	plan, err = r.Plan(ctx, env)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(plan[0]).Should(Equal(upgrade.PlannedMigration{Name: "from-2x", State: upgrade.MigrationCompleted}))
+}
+
+func TestMigrationRegistry_Register(t *testing.T) {
+	g := NewWithT(t)
+
+	noop := func(context.Context, upgrade.MigrationEnv) error { return nil }
+
+	r := upgrade.NewRegistry()
+	r.Register(upgrade.Migration{Name: "first", Run: noop})
+
+	g.Expect(func() { r.Register(upgrade.Migration{Name: "first", Run: noop}) }).Should(PanicWith(ContainSubstring("already registered")))
+	g.Expect(func() { r.Register(upgrade.Migration{Name: "no-run"}) }).Should(PanicWith(ContainSubstring("no run func")))
+	g.Expect(func() { r.Register(upgrade.Migration{Name: "bad-range", From: "3.x.y", Run: noop}) }).Should(PanicWith(ContainSubstring("invalid from range")))
+	g.Expect(func() { r.RegisterReverse(upgrade.Migration{Name: "first", Run: noop}) }).Should(PanicWith(ContainSubstring("already registered")))
+	g.Expect(func() { r.Register(upgrade.Migration{Name: "forward", Reverts: "first", Run: noop}) }).Should(PanicWith(ContainSubstring("not a reverse migration")))
+	g.Expect(r.Migrations()).Should(HaveLen(1))
+	g.Expect(r.ReverseMigrations()).Should(BeEmpty())
+}
+
+func TestPendingMigrations(t *testing.T) {
+	ctx := t.Context()
+	g := NewWithT(t)
+
+	// the default migrations require CRDs not installed in the fake cluster
+	// or the operator namespace, not known in tests
+	cli, err := fakeclient.New(fakeclient.WithObjects(newTestDSCI(testAppNamespace)))
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	pending, err := upgrade.PendingMigrations(ctx, cli, "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(pending).Should(BeEmpty())
+
+	names := make([]string, 0)
+	for _, m := range upgrade.DefaultRegistry.Migrations() {
+		names = append(names, m.Name)
+	}
+	g.Expect(names).Should(ConsistOf("infra-hardware-profiles", "gatewayconfig-ingress-mode"))
+
+	// no DSCI, the application namespace is not known yet
+	noDSCI, err := fakeclient.New()
+	g.Expect(err).ShouldNot(HaveOccurred())
+
+	pending, err = upgrade.PendingMigrations(ctx, noDSCI, "")
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(pending).Should(BeEmpty())
+}
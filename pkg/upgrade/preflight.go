@@ -0,0 +1,397 @@
+package upgrade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
+	odhtype "github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/types"
+)
+
+// PreflightStatus is the outcome of a single preflight finding.
+type PreflightStatus string
+
+const (
+	PreflightPass  PreflightStatus = "Pass"
+	PreflightWarn  PreflightStatus = "Warn"
+	PreflightBlock PreflightStatus = "Block"
+)
+
+const (
+	PreflightCategoryGates       = "UpgradeGate"
+	PreflightCategoryMigrations  = "Migration"
+	PreflightCategoryDeprecated  = "DeprecatedResource"
+	PreflightCategoryConversions = "Conversion"
+)
+
+// platformGroupSuffix selects the CRDs owned by the platform whose conversion
+// webhooks are checked.
+const platformGroupSuffix = "opendatahub.io"
+
+// PreflightFinding is a single line of the preflight report.
+type PreflightFinding struct {
+	Category string          `json:"category"`
+	Name     string          `json:"name"`
+	Status   PreflightStatus `json:"status"`
+	Message  string          `json:"message,omitempty"`
+}
+
+// PreflightReport is the outcome of an upgrade preflight run.
+type PreflightReport struct {
+	FromVersion string             `json:"fromVersion,omitempty"`
+	ToVersion   string             `json:"toVersion"`
+	Findings    []PreflightFinding `json:"findings"`
+}
+
+// Blocked returns true if any finding blocks the upgrade.
+func (r *PreflightReport) Blocked() bool {
+	return slices.ContainsFunc(r.Findings, func(f PreflightFinding) bool {
+		return f.Status == PreflightBlock
+	})
+}
+
+// WriteJSON writes the report as indented JSON.
+func (r *PreflightReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(r)
+}
+
+// WriteTable writes the report as a human readable table.
+func (r *PreflightReport) WriteTable(w io.Writer) error {
+	from := r.FromVersion
+	if from == "" {
+		from = "<none>"
+	}
+
+	if _, err := fmt.Fprintf(w, "Upgrade preflight %s -> %s\n\n", from, r.ToVersion); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "STATUS\tCATEGORY\tNAME\tMESSAGE")
+	for _, f := range r.Findings {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", f.Status, f.Category, f.Name, f.Message)
+	}
+
+	return tw.Flush()
+}
+
+// PreflightOptions configures an upgrade preflight run.
+type PreflightOptions struct {
+	// OperatorNamespace is where the upgrade gate ConfigMaps live.
+	OperatorNamespace string
+	// TargetVersion is the release the cluster would be upgraded to.
+	TargetVersion semver.Version
+	// ChartGates are the gate entries extracted from the rendered module
+	// charts of the target release, see ChartGatesFromManifests.
+	ChartGates map[string]string
+	// ManifestsBasePath is handed to the migrations' preconditions.
+	ManifestsBasePath string
+}
+
+// Preflight evaluates, without changing anything in the cluster, whether an
+// upgrade to the target version would be blocked: unacknowledged upgrade gates,
+// pending migrations, deprecated resources the target release deletes and
+// conversion webhooks that cannot serve stored objects.
+func Preflight(ctx context.Context, cli client.Client, opts PreflightOptions) (*PreflightReport, error) {
+	report := &PreflightReport{
+		ToVersion: opts.TargetVersion.String(),
+	}
+
+	deployed, err := cluster.GetDeployedRelease(ctx, cli)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deployed release: %w", err)
+	}
+
+	if !deployed.Version.Version.Equals(semver.Version{}) {
+		report.FromVersion = deployed.Version.String()
+	}
+
+	checks := []struct {
+		category string
+		check    func(context.Context, client.Client, PreflightOptions) ([]PreflightFinding, error)
+	}{
+		{PreflightCategoryGates, preflightGates},
+		{PreflightCategoryMigrations, preflightMigrations},
+		{PreflightCategoryDeprecated, preflightDeprecatedResources},
+		{PreflightCategoryConversions, preflightConversions},
+	}
+
+	for _, c := range checks {
+		findings, err := c.check(ctx, cli, opts)
+		if err != nil {
+			return nil, fmt.Errorf("%s preflight failed: %w", c.category, err)
+		}
+
+		if len(findings) == 0 {
+			findings = []PreflightFinding{{Category: c.category, Name: "-", Status: PreflightPass}}
+		}
+
+		report.Findings = append(report.Findings, findings...)
+	}
+
+	return report, nil
+}
+
+// ChartGatesFromManifests extracts the upgrade gate entries from rendered
+// chart manifests the same way the modules controller does.
+func ChartGatesFromManifests(ctx context.Context, manifests ...[]byte) (map[string]string, error) {
+	rr := odhtype.ReconciliationRequest{}
+
+	for _, m := range manifests {
+		u, err := decodeManifests(m)
+		if err != nil {
+			return nil, err
+		}
+		rr.Resources = append(rr.Resources, u...)
+	}
+
+	if err := provision.ExtractUpgradeGates(ctx, &rr); err != nil {
+		return nil, err
+	}
+
+	return rr.GateEntries, nil
+}
+
+func decodeManifests(content []byte) ([]unstructured.Unstructured, error) {
+	var result []unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(content), 4096)
+	for {
+		u := unstructured.Unstructured{}
+		if err := decoder.Decode(&u.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode manifests: %w", err)
+		}
+
+		if len(u.Object) != 0 {
+			result = append(result, u)
+		}
+	}
+
+	return result, nil
+}
+
+func preflightGates(ctx context.Context, cli client.Client, opts PreflightOptions) ([]PreflightFinding, error) {
+	version := opts.TargetVersion.String()
+	gc := gates.NewGateChecker(cli, opts.OperatorNamespace)
+
+	entries, err := gates.LoadInTreeGates(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load in-tree gates: %w", err)
+	}
+
+	clusterGates, err := gc.DiscoverGates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(entries, clusterGates)
+	maps.Copy(entries, opts.ChartGates)
+
+	unacked, err := gc.PendingGates(ctx, entries, version)
+	if err != nil {
+		return nil, err
+	}
+
+	blocking, advisory, err := gc.PreviewUpgradeGates(ctx, version)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]PreflightFinding, 0, len(unacked)+len(blocking)+len(advisory))
+	for _, g := range append(unacked, blocking...) {
+		findings = append(findings, PreflightFinding{Category: PreflightCategoryGates, Name: g.Key, Status: PreflightBlock, Message: g.Message})
+	}
+	for _, g := range advisory {
+		findings = append(findings, PreflightFinding{Category: PreflightCategoryGates, Name: g.Key, Status: PreflightWarn, Message: g.Message})
+	}
+
+	return findings, nil
+}
+
+func preflightMigrations(ctx context.Context, cli client.Client, opts PreflightOptions) ([]PreflightFinding, error) {
+	env, err := NewMigrationEnv(ctx, cli, opts.ManifestsBasePath)
+	if err != nil {
+		if k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	env.ToVersion = opts.TargetVersion
+
+	plan, err := DefaultRegistry.Plan(ctx, env)
+	if err != nil {
+		return nil, err
+	}
+
+	findings := make([]PreflightFinding, 0, len(plan))
+	for _, p := range plan {
+		switch p.State {
+		case MigrationPending:
+			findings = append(findings, PreflightFinding{Category: PreflightCategoryMigrations, Name: p.Name, Status: PreflightWarn,
+				Message: "runs during the upgrade: " + p.Description})
+		case MigrationPreconditionNotMet:
+			findings = append(findings, PreflightFinding{Category: PreflightCategoryMigrations, Name: p.Name, Status: PreflightWarn,
+				Message: "precondition not met, the migration is deferred: " + p.Description})
+		}
+	}
+
+	return findings, nil
+}
+
+// deprecatedKinds are the component kinds removed by the current release,
+// any instance left in the cluster is deleted on upgrade.
+var deprecatedKinds = []schema.GroupVersionKind{
+	gvk.CodeFlare,
+	gvk.ModelMeshServing,
+}
+
+// deprecatedDSCComponents are the DataScienceCluster v1 components that are
+// not carried over to v2.
+var deprecatedDSCComponents = []string{
+	"codeflare",
+	"modelmeshserving",
+}
+
+func preflightDeprecatedResources(ctx context.Context, cli client.Client, _ PreflightOptions) ([]PreflightFinding, error) {
+	var findings []PreflightFinding
+
+	for _, kind := range deprecatedKinds {
+		items := unstructured.UnstructuredList{}
+		items.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+
+		if err := cli.List(ctx, &items); err != nil {
+			if meta.IsNoMatchError(err) || k8serr.IsNotFound(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to list %s: %w", kind.Kind, err)
+		}
+
+		for _, item := range items.Items {
+			findings = append(findings, PreflightFinding{Category: PreflightCategoryDeprecated, Name: kind.Kind + "/" + item.GetName(), Status: PreflightWarn,
+				Message: kind.Kind + " is no longer supported and will be deleted"})
+		}
+	}
+
+	dscs := unstructured.UnstructuredList{}
+	dscs.SetGroupVersionKind(gvk.DataScienceClusterV1.GroupVersion().WithKind(gvk.DataScienceClusterV1.Kind + "List"))
+
+	if err := cli.List(ctx, &dscs); err != nil && !meta.IsNoMatchError(err) {
+		return nil, fmt.Errorf("failed to list DataScienceCluster: %w", err)
+	}
+
+	for _, dsc := range dscs.Items {
+		for _, name := range deprecatedDSCComponents {
+			state, _, _ := unstructured.NestedString(dsc.Object, "spec", "components", name, "managementState")
+			if state != "Managed" {
+				continue
+			}
+			findings = append(findings, PreflightFinding{Category: PreflightCategoryDeprecated, Name: "DataScienceCluster/" + dsc.GetName(), Status: PreflightWarn,
+				Message: fmt.Sprintf("component %s is Managed but is not part of the DataScienceCluster v2 API, its resources will be deleted", name)})
+		}
+	}
+
+	oauthClient, err := getLegacyOAuthClient(ctx, cli)
+	if err != nil {
+		return nil, err
+	}
+
+	if oauthClient != nil {
+		findings = append(findings, PreflightFinding{Category: PreflightCategoryDeprecated, Name: "OAuthClient/" + oauthClient.Name, Status: PreflightWarn,
+			Message: "legacy OAuthClient created by a previous release will be deleted"})
+	}
+
+	return findings, nil
+}
+
+func preflightConversions(ctx context.Context, cli client.Client, _ PreflightOptions) ([]PreflightFinding, error) {
+	crds := apiextensionsv1.CustomResourceDefinitionList{}
+	if err := cli.List(ctx, &crds); err != nil {
+		return nil, fmt.Errorf("failed to list CustomResourceDefinitions: %w", err)
+	}
+
+	var findings []PreflightFinding
+
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		if !strings.HasSuffix(crd.Spec.Group, platformGroupSuffix) {
+			continue
+		}
+
+		finding := func(status PreflightStatus, format string, args ...any) {
+			findings = append(findings, PreflightFinding{Category: PreflightCategoryConversions, Name: crd.Name, Status: status,
+				Message: fmt.Sprintf(format, args...)})
+		}
+
+		served := map[string]bool{}
+		storage := ""
+		for _, v := range crd.Spec.Versions {
+			served[v.Name] = v.Served
+			if v.Storage {
+				storage = v.Name
+			}
+		}
+
+		for _, v := range crd.Status.StoredVersions {
+			switch {
+			case !served[v]:
+				finding(PreflightBlock, "stored version %s is not served, migrate the stored objects to %s", v, storage)
+			case v != storage:
+				finding(PreflightWarn, "objects stored as %s rely on the conversion webhook until they are migrated to %s", v, storage)
+			}
+		}
+
+		conv := crd.Spec.Conversion
+		if conv == nil || conv.Strategy != apiextensionsv1.WebhookConverter || conv.Webhook == nil || conv.Webhook.ClientConfig == nil {
+			continue
+		}
+
+		cc := conv.Webhook.ClientConfig
+		if len(cc.CABundle) == 0 {
+			finding(PreflightBlock, "conversion webhook has no CA bundle")
+		}
+
+		if cc.Service == nil {
+			continue
+		}
+
+		svc := corev1.Service{}
+		err := cli.Get(ctx, client.ObjectKey{Namespace: cc.Service.Namespace, Name: cc.Service.Name}, &svc)
+		switch {
+		case k8serr.IsNotFound(err):
+			finding(PreflightBlock, "conversion webhook service %s/%s not found", cc.Service.Namespace, cc.Service.Name)
+		case err != nil:
+			return nil, fmt.Errorf("failed to get conversion webhook service %s/%s: %w", cc.Service.Namespace, cc.Service.Name, err)
+		}
+	}
+
+	return findings, nil
+}
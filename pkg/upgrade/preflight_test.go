@@ -0,0 +1,192 @@
+package upgrade_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	oauthv1 "github.com/openshift/api/oauth/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/opendatahub-io/opendatahub-operator/v2/internal/controller/services/gateway"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/gates"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+func newPreflightOptions(chartGates map[string]string) upgrade.PreflightOptions {
+	return upgrade.PreflightOptions{
+		OperatorNamespace: testOperatorNamespace,
+		TargetVersion:     semver.MustParse("3.1.0"),
+		ChartGates:        chartGates,
+	}
+}
+
+func TestPreflight(t *testing.T) {
+	t.Run("should pass on a clean cluster", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cli, err := fakeclient.New()
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		report, err := upgrade.Preflight(t.Context(), cli, newPreflightOptions(nil))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(report.Blocked()).Should(BeFalse())
+		g.Expect(report.ToVersion).Should(Equal("3.1.0"))
+		g.Expect(report.Findings).Should(HaveEach(HaveField("Status", upgrade.PreflightPass)))
+		g.Expect(report.Findings).Should(HaveLen(4))
+	})
+
+	t.Run("should block on unacknowledged chart gates only", func(t *testing.T) {
+		g := NewWithT(t)
+
+		acks := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: gates.AcksConfigMap, Namespace: testOperatorNamespace},
+			Data:       map[string]string{"ack-3.1.0-acked": "true"},
+		}
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(acks))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		report, err := upgrade.Preflight(t.Context(), cli, newPreflightOptions(map[string]string{
+			"ack-3.1.0-acked":    "already acknowledged",
+			"ack-3.1.0-breaking": "the API changed",
+			"ack-3.0.0-old":      "for another release",
+		}))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(report.Blocked()).Should(BeTrue())
+		g.Expect(report.Findings).Should(ContainElement(upgrade.PreflightFinding{
+			Category: upgrade.PreflightCategoryGates,
+			Name:     "ack-3.1.0-breaking",
+			Status:   upgrade.PreflightBlock,
+			Message:  "the API changed",
+		}))
+		g.Expect(report.Findings).ShouldNot(ContainElement(HaveField("Name", "ack-3.1.0-acked")))
+
+		// preflight is read-only
+		g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(acks), acks)).Should(Succeed())
+		g.Expect(acks.Data).Should(HaveLen(1))
+	})
+
+	t.Run("should report deprecated resources", func(t *testing.T) {
+		g := NewWithT(t)
+
+		codeflare := &unstructured.Unstructured{}
+		codeflare.SetGroupVersionKind(gvk.CodeFlare)
+		codeflare.SetName("default-codeflare")
+
+		oauthClient := &oauthv1.OAuthClient{
+			ObjectMeta:   metav1.ObjectMeta{Name: gateway.LegacyAuthClientID},
+			RedirectURIs: []string{"https://odh.apps.example.com" + gateway.OAuthCallbackPath},
+		}
+
+		cli, err := fakeclient.New(
+			fakeclient.WithObjects(codeflare, oauthClient),
+			fakeclient.WithGVKs(fakeclient.GVKMapping{GVK: gvk.CodeFlare, Scope: meta.RESTScopeRoot}),
+		)
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		report, err := upgrade.Preflight(t.Context(), cli, newPreflightOptions(nil))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(report.Blocked()).Should(BeFalse())
+		g.Expect(report.Findings).Should(ContainElements(
+			And(HaveField("Name", "CodeFlare/default-codeflare"), HaveField("Status", upgrade.PreflightWarn)),
+			And(HaveField("Name", "OAuthClient/"+gateway.LegacyAuthClientID), HaveField("Status", upgrade.PreflightWarn)),
+		))
+	})
+
+	t.Run("should block on conversion issues", func(t *testing.T) {
+		g := NewWithT(t)
+
+		crd := &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "datascienceclusters.datasciencecluster.opendatahub.io"},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: "datasciencecluster.opendatahub.io",
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{
+					{Name: "v1", Served: false},
+					{Name: "v2", Served: true, Storage: true},
+				},
+				Conversion: &apiextensionsv1.CustomResourceConversion{
+					Strategy: apiextensionsv1.WebhookConverter,
+					Webhook: &apiextensionsv1.WebhookConversion{
+						ClientConfig: &apiextensionsv1.WebhookClientConfig{
+							Service: &apiextensionsv1.ServiceReference{Namespace: testOperatorNamespace, Name: "webhook-service"},
+						},
+					},
+				},
+			},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				StoredVersions: []string{"v1", "v2"},
+			},
+		}
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(crd))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		report, err := upgrade.Preflight(t.Context(), cli, newPreflightOptions(nil))
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(report.Blocked()).Should(BeTrue())
+		g.Expect(report.Findings).Should(ContainElements(
+			HaveField("Message", ContainSubstring("stored version v1 is not served")),
+			HaveField("Message", ContainSubstring("no CA bundle")),
+			HaveField("Message", ContainSubstring("service "+testOperatorNamespace+"/webhook-service not found")),
+		))
+	})
+}
+
+func TestPreflightReport_Write(t *testing.T) {
+	g := NewWithT(t)
+
+	report := upgrade.PreflightReport{
+		ToVersion: "3.1.0",
+		Findings: []upgrade.PreflightFinding{
+			{Category: upgrade.PreflightCategoryGates, Name: "ack-3.1.0-breaking", Status: upgrade.PreflightBlock, Message: "the API changed"},
+		},
+	}
+
+	table := bytes.Buffer{}
+	g.Expect(report.WriteTable(&table)).Should(Succeed())
+	g.Expect(table.String()).Should(ContainSubstring("<none> -> 3.1.0"))
+	g.Expect(table.String()).Should(MatchRegexp(`Block\s+UpgradeGate\s+ack-3.1.0-breaking\s+the API changed`))
+
+	out := bytes.Buffer{}
+	g.Expect(report.WriteJSON(&out)).Should(Succeed())
+
+	decoded := upgrade.PreflightReport{}
+	g.Expect(json.Unmarshal(out.Bytes(), &decoded)).Should(Succeed())
+	g.Expect(decoded).Should(Equal(report))
+}
+
+func TestChartGatesFromManifests(t *testing.T) {
+	g := NewWithT(t)
+
+	manifests := []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: chart-gates
+  labels:
+    platform.opendatahub.io/upgrade-gate: "true"
+data:
+  ack-3.1.0-breaking: the API changed
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+data:
+  ack-3.1.0-ignored: not a gate
+`)
+
+	entries, err := upgrade.ChartGatesFromManifests(t.Context(), manifests)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(entries).Should(Equal(map[string]string{"ack-3.1.0-breaking": "the API changed"}))
+}
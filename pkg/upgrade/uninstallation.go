@@ -2,20 +2,27 @@ package upgrade
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/opendatahub-io/opendatahub-operator/v2/api/common"
-	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
-	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
 )
 
@@ -23,102 +30,750 @@ const (
 	// DeleteConfigMapLabel is the label for configMap used to trigger operator uninstall
 	// TODO: Label should be updated if addon name changes.
 	DeleteConfigMapLabel = "api.openshift.com/addon-managed-odh-delete"
+
+	// PreserveUserDataAnnotation, set to "true" on the delete ConfigMap, keeps
+	// PVCs and the operator namespaces holding them.
+	PreserveUserDataAnnotation = "opendatahub.io/uninstall-preserve-user-data"
+	// FinalizerTimeoutAnnotation, set on the delete ConfigMap, overrides how
+	// long each stage waits for finalizers, e.g. "10m".
+	FinalizerTimeoutAnnotation = "opendatahub.io/uninstall-finalizer-timeout"
+
+	// UninstallFinalizer holds the DataScienceCluster while the components
+	// are deleted.
+	UninstallFinalizer = "platform.opendatahub.io/uninstall"
+
+	// UninstallStatusConfigMapName is the name of the ConfigMap, in the
+	// operator namespace, reporting the uninstall progress.
+	UninstallStatusConfigMapName = "opendatahub-uninstall-status"
+	// UninstallStatusDataKey is the UninstallStatusConfigMapName key holding
+	// the JSON encoded UninstallStatus.
+	UninstallStatusDataKey = "status.json"
+
+	// DefaultFinalizerTimeout is how long a stage waits for the deleted
+	// objects to go away before reporting them and moving on.
+	DefaultFinalizerTimeout = 10 * time.Minute
+
+	uninstallPollInterval = 5 * time.Second
+)
+
+// UninstallStage is a step of the uninstall state machine.
+type UninstallStage string
+
+const (
+	// UninstallStagePreserveUserData detaches the PVCs from their owners so
+	// they survive the deletion of the resources that created them.
+	UninstallStagePreserveUserData UninstallStage = "PreserveUserData"
+	// UninstallStageHold deletes the DataScienceCluster while holding it with
+	// the uninstall finalizer: the DataScienceCluster controller stops
+	// provisioning and the component CRs it owns are kept until they are
+	// deleted in order.
+	UninstallStageHold UninstallStage = "Hold"
+	// UninstallStageComponents deletes the component and module CRs one
+	// runlevel at a time, highest runlevel first.
+	UninstallStageComponents UninstallStage = "Components"
+	// UninstallStageDSC releases the DataScienceCluster.
+	UninstallStageDSC UninstallStage = "DataScienceCluster"
+	// UninstallStageDSCI deletes the DSCInitialization.
+	UninstallStageDSCI UninstallStage = "DSCInitialization"
+	// UninstallStageNamespaces deletes the namespaces generated by the operator.
+	UninstallStageNamespaces UninstallStage = "Namespaces"
+	// UninstallStageOperator deletes the operator Subscription and CSV.
+	UninstallStageOperator UninstallStage = "Operator"
+	// UninstallStageCompleted is the final stage.
+	UninstallStageCompleted UninstallStage = "Completed"
 )
 
-// OperatorUninstall deletes all the externally generated resources.
-// This includes DSCI, namespace created by operator (but not workbench or MR's), subscription and CSV.
-func OperatorUninstall(ctx context.Context, cli client.Client, platform common.Platform) error {
+// UninstallObject references an object reported in the uninstall status.
+type UninstallObject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// Reason explains why the object blocks the uninstall, or why it is kept.
+	Reason string `json:"reason,omitempty"`
+}
+
+// UninstallStatus is the progress of the uninstall, as stored in the status
+// ConfigMap.
+type UninstallStatus struct {
+	Stage            UninstallStage `json:"stage"`
+	PreserveUserData bool           `json:"preserveUserData,omitempty"`
+	// Batch is the index of the reverse runlevel batch being deleted in the
+	// Components stage.
+	Batch          int         `json:"batch,omitempty"`
+	StartedAt      metav1.Time `json:"startedAt"`
+	StageStartedAt metav1.Time `json:"stageStartedAt"`
+	Message        string      `json:"message,omitempty"`
+	// Blocking lists the objects the current stage is waiting for.
+	Blocking []UninstallObject `json:"blocking,omitempty"`
+	// TimedOut lists the objects still present when a previous stage timed out.
+	TimedOut []UninstallObject `json:"timedOut,omitempty"`
+	// Preserved lists the objects kept on the cluster.
+	Preserved []UninstallObject `json:"preserved,omitempty"`
+}
+
+// NodeGVKFunc returns the kind of the CR backing a node of the unified
+// provisioning DAG, false if the node has no CR.
+type NodeGVKFunc func(node provision.UnifiedNode) (schema.GroupVersionKind, bool)
+
+// Uninstaller removes the platform from the cluster through a sequence of
+// stages. Each call to Reconcile resumes from the stage recorded in the status
+// ConfigMap and returns a requeue while waiting on finalizers, so it never
+// blocks the calling controller.
+type Uninstaller struct {
+	client            client.Client
+	operatorNamespace string
+	platform          common.Platform
+	preserveUserData  bool
+	finalizerTimeout  time.Duration
+	nodeGVK           NodeGVKFunc
+	now               func() time.Time
+}
+
+// UninstallerOpt configures an Uninstaller.
+type UninstallerOpt func(*Uninstaller)
+
+// WithPreserveUserData keeps PVCs and the operator namespaces holding them.
+func WithPreserveUserData(preserve bool) UninstallerOpt {
+	return func(u *Uninstaller) {
+		u.preserveUserData = preserve
+	}
+}
+
+// WithFinalizerTimeout sets how long each stage waits for the deleted objects
+// to go away.
+func WithFinalizerTimeout(timeout time.Duration) UninstallerOpt {
+	return func(u *Uninstaller) {
+		if timeout > 0 {
+			u.finalizerTimeout = timeout
+		}
+	}
+}
+
+// WithNodeGVK sets how the component and module CRs deleted in the
+// Components stage are found.
+func WithNodeGVK(fn NodeGVKFunc) UninstallerOpt {
+	return func(u *Uninstaller) {
+		u.nodeGVK = fn
+	}
+}
+
+// WithClock overrides the time source, for testing.
+func WithClock(now func() time.Time) UninstallerOpt {
+	return func(u *Uninstaller) {
+		u.now = now
+	}
+}
+
+// NewUninstaller returns an Uninstaller for the operator running in the given
+// namespace.
+func NewUninstaller(cli client.Client, operatorNamespace string, platform common.Platform, opts ...UninstallerOpt) *Uninstaller {
+	u := &Uninstaller{
+		client:            cli,
+		operatorNamespace: operatorNamespace,
+		platform:          platform,
+		finalizerTimeout:  DefaultFinalizerTimeout,
+		nodeGVK:           func(provision.UnifiedNode) (schema.GroupVersionKind, bool) { return schema.GroupVersionKind{}, false },
+		now:               time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(u)
+	}
+
+	return u
+}
+
+// Reconcile advances the uninstall as far as possible, persisting the
+// progress in the status ConfigMap. A non-zero RequeueAfter is returned while
+// a stage waits for objects being deleted.
+func (u *Uninstaller) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	if err := removeDSC(ctx, cli); err != nil {
-		return err
+	st, err := GetUninstallStatus(ctx, u.client, u.operatorNamespace)
+	if err != nil {
+		return ctrl.Result{}, err
 	}
 
-	if err := removeDSCI(ctx, cli); err != nil {
-		return err
+	// a completed status is left behind by a previous uninstall, the operator
+	// has been installed again since
+	if st == nil || st.Stage == UninstallStageCompleted {
+		now := metav1.NewTime(u.now())
+		st = &UninstallStatus{
+			Stage:            UninstallStageHold,
+			PreserveUserData: u.preserveUserData,
+			StartedAt:        now,
+			StageStartedAt:   now,
+		}
+		if u.preserveUserData {
+			st.Stage = UninstallStagePreserveUserData
+		}
+	}
+
+	for st.Stage != UninstallStageCompleted {
+		var done bool
+
+		switch st.Stage {
+		case UninstallStagePreserveUserData:
+			done, err = u.preservePVCs(ctx, st)
+		case UninstallStageHold:
+			done, err = u.holdDSC(ctx, st)
+		case UninstallStageComponents:
+			done, err = u.deleteComponents(ctx, st)
+		case UninstallStageDSC:
+			done, err = u.releaseDSC(ctx, st)
+		case UninstallStageDSCI:
+			done, err = u.deleteDSCI(ctx, st)
+		case UninstallStageNamespaces:
+			done, err = u.deleteNamespaces(ctx, st)
+		case UninstallStageOperator:
+			// the stage is retried until the operator is removed, the status
+			// may not be written as the operator is shut down right after
+			err = u.removeOperator(ctx)
+			done = err == nil
+			if done {
+				st.next(UninstallStageCompleted, u.now())
+			}
+		default:
+			return ctrl.Result{}, fmt.Errorf("unknown uninstall stage %q", st.Stage)
+		}
+
+		if err != nil {
+			st.Message = err.Error()
+			if werr := u.writeStatus(ctx, st); werr != nil {
+				log.Error(werr, "failed to write uninstall status")
+			}
+			return ctrl.Result{}, err
+		}
+
+		if !done {
+			if err := u.writeStatus(ctx, st); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{RequeueAfter: uninstallPollInterval}, nil
+		}
+
+		log.Info("uninstall stage completed", "stage", st.Stage)
+	}
+
+	return ctrl.Result{}, u.writeStatus(ctx, st)
+}
+
+// next moves the status to the given stage.
+func (st *UninstallStatus) next(stage UninstallStage, now time.Time) *UninstallStatus {
+	st.Stage = stage
+	st.Batch = 0
+	st.StageStartedAt = metav1.NewTime(now)
+	st.Message = ""
+	st.Blocking = nil
+	return st
+}
+
+// waitOrTimeout records the objects a stage waits for. Once the finalizer
+// timeout elapsed they are moved to the timed out list and true is returned,
+// so the uninstall carries on without them.
+func (u *Uninstaller) waitOrTimeout(ctx context.Context, st *UninstallStatus, remaining []UninstallObject) bool {
+	if len(remaining) == 0 {
+		return true
+	}
+
+	if u.now().Sub(st.StageStartedAt.Time) < u.finalizerTimeout {
+		st.Blocking = remaining
+		st.Message = fmt.Sprintf("waiting for %d object(s) to be deleted", len(remaining))
+		return false
+	}
+
+	logf.FromContext(ctx).Info("timed out waiting for objects to be deleted", "stage", st.Stage, "objects", remaining)
+	st.TimedOut = append(st.TimedOut, remaining...)
+
+	return true
+}
+
+// preservePVCs detaches from their owners the PVCs of the namespaces that may
+// hold user data: the operator namespaces, the applications namespace and the
+// data science projects.
+func (u *Uninstaller) preservePVCs(ctx context.Context, st *UninstallStatus) (bool, error) {
+	namespaces, err := u.userDataNamespaces(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, ns := range namespaces {
+		pvcs := corev1.PersistentVolumeClaimList{}
+		if err := u.client.List(ctx, &pvcs, client.InNamespace(ns)); err != nil {
+			return false, fmt.Errorf("error listing PVCs in namespace %s: %w", ns, err)
+		}
+
+		for i := range pvcs.Items {
+			pvc := &pvcs.Items[i]
+
+			if len(pvc.OwnerReferences) > 0 {
+				patch := client.MergeFrom(pvc.DeepCopy())
+				pvc.OwnerReferences = nil
+				if err := u.client.Patch(ctx, pvc, patch); err != nil {
+					return false, fmt.Errorf("error detaching PVC %s/%s from its owners: %w", pvc.Namespace, pvc.Name, err)
+				}
+			}
+
+			st.Preserved = appendObject(st.Preserved, UninstallObject{
+				Kind:      gvk.PersistentVolumeClaim.Kind,
+				Namespace: pvc.Namespace,
+				Name:      pvc.Name,
+				Reason:    "user data preserved",
+			})
+		}
+	}
+
+	st.next(UninstallStageHold, u.now())
+
+	return true, nil
+}
+
+func (u *Uninstaller) holdDSC(ctx context.Context, st *UninstallStatus) (bool, error) {
+	list, err := u.list(ctx, gvk.DataScienceCluster)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range list {
+		obj := &list[i]
+
+		if obj.GetDeletionTimestamp().IsZero() && controllerutil.AddFinalizer(obj, UninstallFinalizer) {
+			if err := u.client.Update(ctx, obj); err != nil {
+				return false, fmt.Errorf("error adding uninstall finalizer to %s: %w", obj.GetName(), err)
+			}
+		}
+
+		err := u.client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("error deleting %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+	}
+
+	st.next(UninstallStageComponents, u.now())
+
+	return true, nil
+}
+
+func (u *Uninstaller) releaseDSC(ctx context.Context, st *UninstallStatus) (bool, error) {
+	list, err := u.list(ctx, gvk.DataScienceCluster)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range list {
+		obj := &list[i]
+
+		if controllerutil.RemoveFinalizer(obj, UninstallFinalizer) {
+			if err := u.client.Update(ctx, obj); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("error removing uninstall finalizer from %s: %w", obj.GetName(), err)
+			}
+		}
+	}
+
+	remaining, err := u.deleteAll(ctx, gvk.DataScienceCluster)
+	if err != nil {
+		return false, err
+	}
+
+	if !u.waitOrTimeout(ctx, st, remaining) {
+		return false, nil
+	}
+
+	st.next(UninstallStageDSCI, u.now())
+
+	return true, nil
+}
+
+func (u *Uninstaller) deleteDSCI(ctx context.Context, st *UninstallStatus) (bool, error) {
+	remaining, err := u.deleteAll(ctx, gvk.DSCInitialization)
+	if err != nil {
+		return false, err
+	}
+
+	if !u.waitOrTimeout(ctx, st, remaining) {
+		return false, nil
+	}
+
+	st.next(UninstallStageNamespaces, u.now())
+
+	return true, nil
+}
+
+func (u *Uninstaller) deleteComponents(ctx context.Context, st *UninstallStatus) (bool, error) {
+	batches, err := provision.ReverseBatchesAll()
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve uninstall order: %w", err)
+	}
+
+	for st.Batch < len(batches) {
+		var kinds []schema.GroupVersionKind
+		for _, node := range batches[st.Batch] {
+			if k, ok := u.nodeGVK(node); ok {
+				kinds = append(kinds, k)
+			}
+		}
+
+		var remaining []UninstallObject
+		for _, k := range kinds {
+			objs, err := u.deleteAll(ctx, k)
+			if err != nil {
+				return false, err
+			}
+			remaining = append(remaining, objs...)
+		}
+
+		if !u.waitOrTimeout(ctx, st, remaining) {
+			return false, nil
+		}
+
+		st.Batch++
+		st.StageStartedAt = metav1.NewTime(u.now())
+		st.Blocking = nil
+	}
+
+	st.next(UninstallStageDSC, u.now())
+
+	return true, nil
+}
+
+// deleteAll deletes the objects of the given kind and returns the ones still
+// present, that is being finalized.
+func (u *Uninstaller) deleteAll(ctx context.Context, kind schema.GroupVersionKind) ([]UninstallObject, error) {
+	list, err := u.list(ctx, kind)
+	if err != nil {
+		return nil, err
 	}
 
-	// Delete generated namespaces by the operator
+	remaining := make([]UninstallObject, 0, len(list))
+
+	for i := range list {
+		obj := &list[i]
+
+		if obj.GetDeletionTimestamp().IsZero() {
+			err := u.client.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationForeground))
+			if k8serr.IsNotFound(err) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("error deleting %s %s: %w", kind.Kind, obj.GetName(), err)
+			}
+
+			logf.FromContext(ctx).Info("deleted as part of uninstall", "kind", kind.Kind, "name", obj.GetName())
+
+			if err := u.client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				if k8serr.IsNotFound(err) {
+					continue
+				}
+				return nil, fmt.Errorf("error getting %s %s: %w", kind.Kind, obj.GetName(), err)
+			}
+		}
+
+		remaining = append(remaining, UninstallObject{
+			Kind:      kind.Kind,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Reason:    finalizersReason(obj.GetFinalizers()),
+		})
+	}
+
+	return remaining, nil
+}
+
+// list returns the objects of the given kind, none if the kind is not
+// installed.
+func (u *Uninstaller) list(ctx context.Context, kind schema.GroupVersionKind) ([]unstructured.Unstructured, error) {
+	list := unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(kind.GroupVersion().WithKind(kind.Kind + "List"))
+
+	if err := u.client.List(ctx, &list); err != nil {
+		if meta.IsNoMatchError(err) || k8serr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error listing %s: %w", kind.Kind, err)
+	}
+
+	return list.Items, nil
+}
+
+func (u *Uninstaller) deleteNamespaces(ctx context.Context, st *UninstallStatus) (bool, error) {
+	namespaces, err := u.ownedNamespaces(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var remaining []UninstallObject
+
+	for i := range namespaces {
+		ns := &namespaces[i]
+
+		if st.PreserveUserData {
+			hasPVCs, err := u.hasPVCs(ctx, ns.Name)
+			if err != nil {
+				return false, err
+			}
+			if hasPVCs {
+				st.Preserved = appendObject(st.Preserved, UninstallObject{
+					Kind:   gvk.Namespace.Kind,
+					Name:   ns.Name,
+					Reason: "namespace holds preserved PVCs",
+				})
+				continue
+			}
+		}
+
+		if ns.Status.Phase != corev1.NamespaceTerminating {
+			if err := u.client.Delete(ctx, ns); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("error deleting namespace %v: %w", ns.Name, err)
+			}
+			logf.FromContext(ctx).Info("Namespace deleted as a part of uninstallation", "namespace", ns.Name)
+
+			if err := u.client.Get(ctx, client.ObjectKeyFromObject(ns), ns); err != nil {
+				if k8serr.IsNotFound(err) {
+					continue
+				}
+				return false, fmt.Errorf("error getting namespace %v: %w", ns.Name, err)
+			}
+		}
+
+		blockers, err := u.namespaceBlockers(ctx, ns)
+		if err != nil {
+			return false, err
+		}
+		remaining = append(remaining, blockers...)
+	}
+
+	if !u.waitOrTimeout(ctx, st, remaining) {
+		return false, nil
+	}
+
+	st.next(UninstallStageOperator, u.now())
+
+	return true, nil
+}
+
+// namespaceBlockers reports a namespace being deleted, with the reasons
+// recorded in its status conditions and the PVCs it holds.
+func (u *Uninstaller) namespaceBlockers(ctx context.Context, ns *corev1.Namespace) ([]UninstallObject, error) {
+	var reasons []string
+	for _, c := range ns.Status.Conditions {
+		if c.Status == corev1.ConditionTrue && c.Message != "" {
+			reasons = append(reasons, c.Message)
+		}
+	}
+
+	if len(reasons) == 0 {
+		reasons = append(reasons, "namespace is terminating")
+	}
+
+	blockers := []UninstallObject{{
+		Kind:   gvk.Namespace.Kind,
+		Name:   ns.Name,
+		Reason: strings.Join(reasons, "; "),
+	}}
+
+	pvcs := corev1.PersistentVolumeClaimList{}
+	if err := u.client.List(ctx, &pvcs, client.InNamespace(ns.Name)); err != nil {
+		return nil, fmt.Errorf("error listing PVCs in namespace %s: %w", ns.Name, err)
+	}
+
+	for _, pvc := range pvcs.Items {
+		blockers = append(blockers, UninstallObject{
+			Kind:      gvk.PersistentVolumeClaim.Kind,
+			Namespace: pvc.Namespace,
+			Name:      pvc.Name,
+			Reason:    finalizersReason(pvc.Finalizers),
+		})
+	}
+
+	return blockers, nil
+}
+
+func (u *Uninstaller) ownedNamespaces(ctx context.Context) ([]corev1.Namespace, error) {
 	generatedNamespaces := &corev1.NamespaceList{}
 	nsOptions := []client.ListOption{
 		client.MatchingLabels{labels.ODH.OwnedNamespace: "true"},
 	}
-	if err := cli.List(ctx, generatedNamespaces, nsOptions...); err != nil {
-		return fmt.Errorf("error getting generated namespaces : %w", err)
+	if err := u.client.List(ctx, generatedNamespaces, nsOptions...); err != nil {
+		return nil, fmt.Errorf("error getting generated namespaces : %w", err)
 	}
 
-	// Return if any one of the namespaces is Terminating due to resources that are in process of deletion. (e.g. CRDs)
-	for _, namespace := range generatedNamespaces.Items {
-		if namespace.Status.Phase == corev1.NamespaceTerminating {
-			return fmt.Errorf("waiting for namespace %v to be deleted", namespace.Name)
-		}
+	return generatedNamespaces.Items, nil
+}
+
+// userDataNamespaces returns the namespaces the PVCs are preserved in: the
+// namespaces generated by the operator, the applications namespace, the data
+// science projects created by the dashboard and the namespaces holding
+// workbenches or pipeline servers.
+func (u *Uninstaller) userDataNamespaces(ctx context.Context) ([]string, error) {
+	var namespaces []string
+
+	owned, err := u.ownedNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, ns := range owned {
+		namespaces = append(namespaces, ns.Name)
 	}
 
-	for _, namespace := range generatedNamespaces.Items {
-		if namespace.Status.Phase == corev1.NamespaceActive {
-			if err := cli.Delete(ctx, &namespace); err != nil {
-				return fmt.Errorf("error deleting namespace %v: %w", namespace.Name, err)
-			}
-			log.Info("Namespace deleted as a part of uninstallation", "namespace", namespace.Name)
+	appNamespace, err := cluster.ApplicationNamespace(ctx, u.client)
+	switch {
+	case k8serr.IsNotFound(err):
+	case err != nil:
+		return nil, fmt.Errorf("error getting applications namespace: %w", err)
+	default:
+		namespaces = append(namespaces, appNamespace)
+	}
+
+	projects := &corev1.NamespaceList{}
+	if err := u.client.List(ctx, projects, client.MatchingLabels{labels.ODH.DashboardProject: "true"}); err != nil {
+		return nil, fmt.Errorf("error getting data science projects: %w", err)
+	}
+	for _, ns := range projects.Items {
+		namespaces = append(namespaces, ns.Name)
+	}
+
+	for _, kind := range []schema.GroupVersionKind{gvk.Notebook, gvk.DataSciencePipelinesApplication} {
+		objs, err := u.list(ctx, kind)
+		if err != nil {
+			return nil, err
 		}
+		for _, obj := range objs {
+			namespaces = append(namespaces, obj.GetNamespace())
+		}
+	}
+
+	slices.Sort(namespaces)
+
+	return slices.Compact(namespaces), nil
+}
+
+func (u *Uninstaller) hasPVCs(ctx context.Context, namespace string) (bool, error) {
+	pvcs := corev1.PersistentVolumeClaimList{}
+	if err := u.client.List(ctx, &pvcs, client.InNamespace(namespace), client.Limit(1)); err != nil {
+		return false, fmt.Errorf("error listing PVCs in namespace %s: %w", namespace, err)
 	}
 
-	// give enough time for namespace deletion before proceed
-	time.Sleep(10 * time.Second)
+	return len(pvcs.Items) > 0, nil
+}
+
+func (u *Uninstaller) removeOperator(ctx context.Context) error {
+	log := logf.FromContext(ctx)
 
 	// We can only assume the subscription is using standard names
 	// if user install by creating different named subs, then we will not know the name
 	// we cannot remove CSV before remove subscription because that need SA account
-	operatorNs, err := cluster.GetOperatorNamespace()
-	if err != nil {
-		return err
-	}
-
 	log.Info("Removing operator subscription which in turn will remove installplan")
 	subsName := "opendatahub-operator"
-	if platform == cluster.SelfManagedRhoai {
+	if u.platform == cluster.SelfManagedRhoai {
 		subsName = "rhods-operator"
 	}
-	if platform != cluster.ManagedRhoai {
-		if err := cluster.DeleteExistingSubscription(ctx, cli, operatorNs, subsName); err != nil {
+	if u.platform != cluster.ManagedRhoai {
+		if err := cluster.DeleteExistingSubscription(ctx, u.client, u.operatorNamespace, subsName); err != nil {
 			return err
 		}
 	}
 
 	log.Info("Removing the operator CSV in turn remove operator deployment")
-	err = removeCSV(ctx, cli)
+	if err := removeCSV(ctx, u.client, u.operatorNamespace); err != nil {
+		return err
+	}
 
 	log.Info("All resources deleted as part of uninstall.")
-	return err
+
+	return nil
 }
 
-func removeDSCI(ctx context.Context, cli client.Client) error {
-	instance := &dsciv2.DSCInitialization{}
+func (u *Uninstaller) writeStatus(ctx context.Context, st *UninstallStatus) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return fmt.Errorf("failed to encode uninstall status: %w", err)
+	}
+
+	cm := corev1.ConfigMap{}
+
+	err = u.client.Get(ctx, client.ObjectKey{Namespace: u.operatorNamespace, Name: UninstallStatusConfigMapName}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		cm = corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      UninstallStatusConfigMapName,
+				Namespace: u.operatorNamespace,
+			},
+			Data: map[string]string{UninstallStatusDataKey: string(data)},
+		}
+		if err := u.client.Create(ctx, &cm); err != nil {
+			return fmt.Errorf("failed to create uninstall status: %w", err)
+		}
+		return nil
+	case err != nil:
+		return fmt.Errorf("failed to get uninstall status: %w", err)
+	}
+
+	if cm.Data[UninstallStatusDataKey] == string(data) {
+		return nil
+	}
 
-	if err := cli.DeleteAllOf(ctx, instance, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
-		return fmt.Errorf("failure deleting DSCI: %w", err)
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[UninstallStatusDataKey] = string(data)
+
+	if err := u.client.Update(ctx, &cm); err != nil {
+		return fmt.Errorf("failed to update uninstall status: %w", err)
 	}
 
 	return nil
 }
 
-func removeDSC(ctx context.Context, cli client.Client) error {
-	instance := &dscv2.DataScienceCluster{}
+// GetUninstallStatus returns the uninstall progress recorded in the given
+// operator namespace, nil if the uninstall has not started.
+func GetUninstallStatus(ctx context.Context, cli client.Client, operatorNamespace string) (*UninstallStatus, error) {
+	cm := corev1.ConfigMap{}
 
-	if err := cli.DeleteAllOf(ctx, instance, client.PropagationPolicy(metav1.DeletePropagationForeground)); err != nil {
-		return fmt.Errorf("failure deleting DSC: %w", err)
+	err := cli.Get(ctx, client.ObjectKey{Namespace: operatorNamespace, Name: UninstallStatusConfigMapName}, &cm)
+	switch {
+	case k8serr.IsNotFound(err):
+		return nil, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to get uninstall status: %w", err)
 	}
 
-	return nil
+	data, ok := cm.Data[UninstallStatusDataKey]
+	if !ok {
+		return nil, nil
+	}
+
+	st := UninstallStatus{}
+	if err := json.Unmarshal([]byte(data), &st); err != nil {
+		return nil, fmt.Errorf("failed to decode uninstall status: %w", err)
+	}
+
+	return &st, nil
 }
 
-// HasDeleteConfigMap returns true if delete configMap is added to the operator namespace by managed-tenants repo.
-// It returns false in all other cases.
-func HasDeleteConfigMap(ctx context.Context, c client.Client) bool {
+func finalizersReason(finalizers []string) string {
+	if len(finalizers) == 0 {
+		return "deletion in progress"
+	}
+
+	return "waiting for finalizers: " + strings.Join(finalizers, ", ")
+}
+
+func appendObject(objs []UninstallObject, obj UninstallObject) []UninstallObject {
+	if slices.Contains(objs, obj) {
+		return objs
+	}
+
+	return append(objs, obj)
+}
+
+// GetDeleteConfigMap returns the delete configMap added to the operator namespace by managed-tenants repo,
+// nil if there is none.
+func GetDeleteConfigMap(ctx context.Context, c client.Client) (*corev1.ConfigMap, error) {
 	// Get watchNamespace
 	operatorNamespace, err := cluster.GetOperatorNamespace()
 	if err != nil {
-		return false
+		return nil, err
 	}
 
 	// If delete configMap is added, uninstall the operator and the resources
@@ -129,20 +784,47 @@ func HasDeleteConfigMap(ctx context.Context, c client.Client) bool {
 	}
 
 	if err := c.List(ctx, deleteConfigMapList, cmOptions...); err != nil {
-		return false
+		return nil, err
+	}
+
+	if len(deleteConfigMapList.Items) == 0 {
+		return nil, nil
 	}
 
-	return len(deleteConfigMapList.Items) != 0
+	return &deleteConfigMapList.Items[0], nil
 }
 
-func removeCSV(ctx context.Context, c client.Client) error {
-	log := logf.FromContext(ctx)
-	// Get watchNamespace
-	operatorNamespace, err := cluster.GetOperatorNamespace()
-	if err != nil {
-		return err
+// HasDeleteConfigMap returns true if delete configMap is added to the operator namespace by managed-tenants repo.
+// It returns false in all other cases.
+func HasDeleteConfigMap(ctx context.Context, c client.Client) bool {
+	cm, err := GetDeleteConfigMap(ctx, c)
+
+	return err == nil && cm != nil
+}
+
+// UninstallOptionsFromConfigMap reads the uninstall options set on the
+// delete configMap.
+func UninstallOptionsFromConfigMap(cm *corev1.ConfigMap) ([]UninstallerOpt, error) {
+	var opts []UninstallerOpt
+
+	if cm.Annotations[PreserveUserDataAnnotation] == "true" {
+		opts = append(opts, WithPreserveUserData(true))
+	}
+
+	if v, ok := cm.Annotations[FinalizerTimeoutAnnotation]; ok {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation %q: %w", FinalizerTimeoutAnnotation, v, err)
+		}
+		opts = append(opts, WithFinalizerTimeout(timeout))
 	}
 
+	return opts, nil
+}
+
+func removeCSV(ctx context.Context, c client.Client, operatorNamespace string) error {
+	log := logf.FromContext(ctx)
+
 	operatorCsv, err := cluster.GetClusterServiceVersion(ctx, c, operatorNamespace)
 	if k8serr.IsNotFound(err) {
 		ctrl.Log.Info("No clusterserviceversion for the operator found.")
@@ -0,0 +1,267 @@
+package upgrade_test
+
+import (
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
+	dscv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v2"
+	dsciv2 "github.com/opendatahub-io/opendatahub-operator/v2/api/dscinitialization/v2"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/dag"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/controller/provision"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/metadata/labels"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/upgrade"
+	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/utils/test/fakeclient"
+
+	. "github.com/onsi/gomega"
+)
+
+const (
+	testOperatorNamespace = "test-operator-ns"
+	testFinalizer         = "test.opendatahub.io/finalizer"
+)
+
+func registerUninstallNodes(t *testing.T) upgrade.UninstallerOpt {
+	t.Helper()
+
+	provision.DefaultRegistry().Reset()
+	provision.Add(componentApi.RayComponentName, provision.KindComponent, dag.RL(10))
+	provision.Add(componentApi.DashboardComponentName, provision.KindComponent, dag.RL(20))
+	t.Cleanup(provision.DefaultRegistry().Reset)
+
+	kinds := map[string]schema.GroupVersionKind{
+		componentApi.RayComponentName:       gvk.Ray,
+		componentApi.DashboardComponentName: gvk.Dashboard,
+	}
+
+	return upgrade.WithNodeGVK(func(node provision.UnifiedNode) (schema.GroupVersionKind, bool) {
+		k, ok := kinds[node.GetName()]
+		return k, ok
+	})
+}
+
+func newUninstallObjects() []client.Object {
+	return []client.Object{
+		&dscv2.DataScienceCluster{ObjectMeta: metav1.ObjectMeta{Name: "default-dsc"}},
+		&dsciv2.DSCInitialization{
+			ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"},
+			Spec:       dsciv2.DSCInitializationSpec{ApplicationsNamespace: testAppNamespace},
+		},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   testAppNamespace,
+			Labels: map[string]string{labels.ODH.OwnedNamespace: "true"},
+		}},
+		&componentApi.Dashboard{ObjectMeta: metav1.ObjectMeta{
+			Name:       componentApi.DashboardInstanceName,
+			Finalizers: []string{testFinalizer},
+		}},
+		&componentApi.Ray{ObjectMeta: metav1.ObjectMeta{Name: componentApi.RayInstanceName}},
+	}
+}
+
+func getUninstallStatus(t *testing.T, g *WithT, cli client.Client) *upgrade.UninstallStatus {
+	t.Helper()
+
+	st, err := upgrade.GetUninstallStatus(t.Context(), cli, testOperatorNamespace)
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(st).ShouldNot(BeNil())
+
+	return st
+}
+
+func expectGone(t *testing.T, g *WithT, cli client.Client, obj client.Object) {
+	t.Helper()
+
+	err := cli.Get(t.Context(), client.ObjectKeyFromObject(obj), obj)
+	g.Expect(k8serr.IsNotFound(err)).Should(BeTrue(), "%T %s should be deleted", obj, obj.GetName())
+}
+
+func TestUninstaller(t *testing.T) {
+	t.Run("should delete components in reverse runlevel order and wait on finalizers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		nodeGVK := registerUninstallNodes(t)
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(newUninstallObjects()...))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		u := upgrade.NewUninstaller(cli, testOperatorNamespace, cluster.OpenDataHub, nodeGVK)
+
+		res, err := u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).Should(BeNumerically(">", 0))
+
+		st := getUninstallStatus(t, g, cli)
+		g.Expect(st.Stage).Should(Equal(upgrade.UninstallStageComponents))
+		g.Expect(st.Blocking).Should(ConsistOf(upgrade.UninstallObject{
+			Kind:   gvk.Dashboard.Kind,
+			Name:   componentApi.DashboardInstanceName,
+			Reason: "waiting for finalizers: " + testFinalizer,
+		}))
+
+		// the DSC is held and the lower runlevel is not deleted yet
+		dsc := &dscv2.DataScienceCluster{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: "default-dsc"}, dsc)).Should(Succeed())
+		g.Expect(dsc.DeletionTimestamp).ShouldNot(BeNil())
+		g.Expect(dsc.Finalizers).Should(ContainElement(upgrade.UninstallFinalizer))
+
+		ray := &componentApi.Ray{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: componentApi.RayInstanceName}, ray)).Should(Succeed())
+		g.Expect(ray.DeletionTimestamp).Should(BeNil())
+
+		dashboard := &componentApi.Dashboard{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: componentApi.DashboardInstanceName}, dashboard)).Should(Succeed())
+		dashboard.Finalizers = nil
+		g.Expect(cli.Update(t.Context(), dashboard)).Should(Succeed())
+
+		res, err = u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).Should(BeZero())
+
+		st = getUninstallStatus(t, g, cli)
+		g.Expect(st.Stage).Should(Equal(upgrade.UninstallStageCompleted))
+		g.Expect(st.Blocking).Should(BeEmpty())
+		g.Expect(st.TimedOut).Should(BeEmpty())
+
+		expectGone(t, g, cli, ray)
+		expectGone(t, g, cli, dsc)
+		expectGone(t, g, cli, &dsciv2.DSCInitialization{ObjectMeta: metav1.ObjectMeta{Name: "default-dsci"}})
+		expectGone(t, g, cli, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testAppNamespace}})
+	})
+
+	t.Run("should report objects stuck past the finalizer timeout and carry on", func(t *testing.T) {
+		g := NewWithT(t)
+
+		nodeGVK := registerUninstallNodes(t)
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(newUninstallObjects()...))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		now := time.Now()
+		clock := upgrade.WithClock(func() time.Time { return now })
+
+		u := upgrade.NewUninstaller(cli, testOperatorNamespace, cluster.OpenDataHub, nodeGVK, clock,
+			upgrade.WithFinalizerTimeout(time.Minute))
+
+		_, err = u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+		g.Expect(getUninstallStatus(t, g, cli).Stage).Should(Equal(upgrade.UninstallStageComponents))
+
+		now = now.Add(2 * time.Minute)
+
+		_, err = u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		st := getUninstallStatus(t, g, cli)
+		g.Expect(st.Stage).Should(Equal(upgrade.UninstallStageCompleted))
+		g.Expect(st.TimedOut).Should(ConsistOf(HaveField("Name", componentApi.DashboardInstanceName)))
+	})
+
+	t.Run("should keep PVCs and the namespaces holding them when preserving user data", func(t *testing.T) {
+		g := NewWithT(t)
+
+		nodeGVK := registerUninstallNodes(t)
+
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:      "model-registry-db",
+			Namespace: testAppNamespace,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: gvk.Ray.GroupVersion().String(),
+				Kind:       gvk.Ray.Kind,
+				Name:       componentApi.RayInstanceName,
+				UID:        "ray-uid",
+			}},
+		}}
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(append(newUninstallObjects(), pvc)...))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		u := upgrade.NewUninstaller(cli, testOperatorNamespace, cluster.OpenDataHub, nodeGVK,
+			upgrade.WithPreserveUserData(true))
+
+		_, err = u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(pvc), pvc)).Should(Succeed())
+		g.Expect(pvc.OwnerReferences).Should(BeEmpty())
+
+		dashboard := &componentApi.Dashboard{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: componentApi.DashboardInstanceName}, dashboard)).Should(Succeed())
+		dashboard.Finalizers = nil
+		g.Expect(cli.Update(t.Context(), dashboard)).Should(Succeed())
+
+		_, err = u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		st := getUninstallStatus(t, g, cli)
+		g.Expect(st.Stage).Should(Equal(upgrade.UninstallStageCompleted))
+		g.Expect(st.PreserveUserData).Should(BeTrue())
+		g.Expect(st.Preserved).Should(ConsistOf(
+			HaveField("Kind", gvk.PersistentVolumeClaim.Kind),
+			HaveField("Kind", gvk.Namespace.Kind),
+		))
+
+		ns := &corev1.Namespace{}
+		g.Expect(cli.Get(t.Context(), client.ObjectKey{Name: testAppNamespace}, ns)).Should(Succeed())
+		g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(pvc), pvc)).Should(Succeed())
+	})
+
+	t.Run("should detach PVCs in data science projects when preserving user data", func(t *testing.T) {
+		g := NewWithT(t)
+
+		nodeGVK := registerUninstallNodes(t)
+
+		project := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name:   "my-project",
+			Labels: map[string]string{labels.ODH.DashboardProject: "true"},
+		}}
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+			Name:      "workbench-storage",
+			Namespace: project.Name,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: gvk.Notebook.GroupVersion().String(),
+				Kind:       gvk.Notebook.Kind,
+				Name:       "workbench",
+				UID:        "workbench-uid",
+			}},
+		}}
+
+		cli, err := fakeclient.New(fakeclient.WithObjects(append(newUninstallObjects(), project, pvc)...))
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		u := upgrade.NewUninstaller(cli, testOperatorNamespace, cluster.OpenDataHub, nodeGVK,
+			upgrade.WithPreserveUserData(true))
+
+		_, err = u.Reconcile(t.Context())
+		g.Expect(err).ShouldNot(HaveOccurred())
+
+		g.Expect(cli.Get(t.Context(), client.ObjectKeyFromObject(pvc), pvc)).Should(Succeed())
+		g.Expect(pvc.OwnerReferences).Should(BeEmpty())
+	})
+}
+
+func TestUninstallOptionsFromConfigMap(t *testing.T) {
+	g := NewWithT(t)
+
+	opts, err := upgrade.UninstallOptionsFromConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{
+			upgrade.PreserveUserDataAnnotation: "true",
+			upgrade.FinalizerTimeoutAnnotation: "15m",
+		},
+	}})
+	g.Expect(err).ShouldNot(HaveOccurred())
+	g.Expect(opts).Should(HaveLen(2))
+
+	_, err = upgrade.UninstallOptionsFromConfigMap(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Annotations: map[string]string{upgrade.FinalizerTimeoutAnnotation: "soon"},
+	}})
+	g.Expect(err).Should(MatchError(ContainSubstring("invalid")))
+}
@@ -66,6 +66,64 @@ const (
 	kserveDeploymentModeServerless    = "Serverless"
 )
 
+// No reverse migration is registered: none of the migrations below changes
+// data an older release depends on. Each one documents why it does not need
+// to be undone on a release downgrade.
+func init() {
+	// HardwareProfile migration as described in RHOAIENG-33158 and RHOAIENG-33159
+	// This includes creating HardwareProfile resources and updating annotations on Notebooks and InferenceServices
+	DefaultRegistry.Register(Migration{
+		Name:        "infra-hardware-profiles",
+		Description: "Create HardwareProfiles from AcceleratorProfiles and container sizes",
+		// Create-only and idempotent, the AcceleratorProfiles which are not
+		// migrated yet are converted on every startup. Nothing to revert: the
+		// AcceleratorProfiles are left in place and releases which predate the
+		// HardwareProfiles ignore them and the annotations.
+		Repeatable: true,
+		Precondition: func(ctx context.Context, env MigrationEnv) (bool, error) {
+			// Check if target infrastructure HardwareProfile CRD exists (indicates we should migrate)
+			hasInfraHWP, err := cluster.HasCRD(ctx, env.Client, gvk.HardwareProfile)
+			if err != nil {
+				return false, fmt.Errorf("failed to check HardwareProfile CRD: %w", err)
+			}
+			if !hasInfraHWP {
+				return false, nil
+			}
+			// Check if source AcceleratorProfile CRD exists (indicates we have data to migrate)
+			hasAccelProfile, err := cluster.HasCRD(ctx, env.Client, gvk.DashboardAcceleratorProfile)
+			if err != nil {
+				return false, fmt.Errorf("failed to check AcceleratorProfile CRD: %w", err)
+			}
+			return hasAccelProfile, nil
+		},
+		Run: func(ctx context.Context, env MigrationEnv) error {
+			return MigrateToInfraHardwareProfiles(ctx, env.Client, env.ApplicationNamespace, env.ManifestsBasePath)
+		},
+	})
+
+	// GatewayConfig ingressMode migration: preserve LoadBalancer mode for existing deployments
+	DefaultRegistry.Register(Migration{
+		Name:        "gatewayconfig-ingress-mode",
+		Description: "Preserve the LoadBalancer ingressMode of existing Gateway deployments",
+		// the GatewayConfig and its Service may not exist yet at startup, the
+		// ingressMode is checked again on every startup. Nothing to revert: it
+		// only persists the mode the Gateway was already deployed with, which
+		// is also the default of the releases without the field.
+		Repeatable: true,
+		Precondition: func(ctx context.Context, env MigrationEnv) (bool, error) {
+			// Check if GatewayConfig CRD exists (indicates feature is available)
+			hasGatewayConfig, err := cluster.HasCRD(ctx, env.Client, gvk.GatewayConfig)
+			if err != nil {
+				return false, fmt.Errorf("failed to check GatewayConfig CRD: %w", err)
+			}
+			return hasGatewayConfig, nil
+		},
+		Run: func(ctx context.Context, env MigrationEnv) error {
+			return MigrateGatewayConfigIngressMode(ctx, env.Client)
+		},
+	})
+}
+
 var defaultResourceLimits = map[string]string{
 	"maxMemory": "120Gi",
 	"minMemory": "8Gi",
@@ -73,7 +131,13 @@ var defaultResourceLimits = map[string]string{
 	"minCpu":    "1",
 }
 
-// TODO: remove function once we have a generic solution across all components.
+// CleanupExistingResource removes resources left behind by previous releases
+// and runs the pending one-time data migrations of DefaultRegistry.
+//
+// Resources of kinds a controller stops rendering are collected by the GC action
+// through its persisted kinds inventory (see gc.WithInventory), so new cleanups
+// should only be added here for resources deployed before the inventory existed
+// or not labelled as part of a controller.
 func CleanupExistingResource(ctx context.Context,
 	cli client.Client,
 	basePath string,
@@ -101,30 +165,12 @@ func CleanupExistingResource(ctx context.Context,
 	// cleanup deprecated RStudio BuildConfigs and ImageStreams from RHOAI 3.4 (RHAIENG-5327)
 	multiErr = multierror.Append(multiErr, cleanupDeprecatedRStudioResources(ctx, cli, applicationNS))
 
-	// HardwareProfile migration as described in RHOAIENG-33158 and RHOAIENG-33159
-	// This includes creating HardwareProfile resources and updating annotations on Notebooks and InferenceServices
-	// Check if target infrastructure HardwareProfile CRD exists (indicates we should migrate)
-	hasInfraHWP, err := cluster.HasCRD(ctx, cli, gvk.HardwareProfile)
-	if err != nil {
-		multiErr = multierror.Append(multiErr, fmt.Errorf("failed to check HardwareProfile CRD: %w", err))
-	} else if hasInfraHWP {
-		// Check if source AcceleratorProfile CRD exists (indicates we have data to migrate)
-		hasAccelProfile, err := cluster.HasCRD(ctx, cli, gvk.DashboardAcceleratorProfile)
-		if err != nil {
-			multiErr = multierror.Append(multiErr, fmt.Errorf("failed to check AcceleratorProfile CRD: %w", err))
-		} else if hasAccelProfile {
-			// Both CRDs exist, run migration (it's idempotent)
-			multiErr = multierror.Append(multiErr, MigrateToInfraHardwareProfiles(ctx, cli, applicationNS, basePath))
-		}
-	}
-
-	// GatewayConfig ingressMode migration: preserve LoadBalancer mode for existing deployments
-	// Check if GatewayConfig CRD exists (indicates feature is available)
-	hasGatewayConfig, err := cluster.HasCRD(ctx, cli, gvk.GatewayConfig)
+	// one-time data migrations, recorded in the migration ledger
+	env, err := NewMigrationEnv(ctx, cli, basePath)
 	if err != nil {
-		multiErr = multierror.Append(multiErr, fmt.Errorf("failed to check GatewayConfig CRD: %w", err))
-	} else if hasGatewayConfig {
-		multiErr = multierror.Append(multiErr, MigrateGatewayConfigIngressMode(ctx, cli))
+		multiErr = multierror.Append(multiErr, err)
+	} else {
+		multiErr = multierror.Append(multiErr, DefaultRegistry.Run(ctx, env))
 	}
 
 	return multiErr.ErrorOrNil()
@@ -238,12 +284,28 @@ func cleanupDeprecatedKueueVAPB(ctx context.Context, cli client.Client) error {
 }
 
 // cleanupLegacyOAuthClient removes the legacy "odh" OAuthClient left over from
-// RHOAI 3.3 upgrades. It only deletes the client if a redirect URI is an HTTPS
-// URL whose path exactly matches OAuthCallbackPath, confirming it was created by
-// the gateway controller and not by an unrelated user workload.
+// RHOAI 3.3 upgrades.
 func cleanupLegacyOAuthClient(ctx context.Context, cli client.Client) error {
 	log := logf.FromContext(ctx)
 
+	legacyClient, err := getLegacyOAuthClient(ctx, cli)
+	if err != nil || legacyClient == nil {
+		return err
+	}
+
+	if err := cli.Delete(ctx, legacyClient); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete legacy OAuthClient %q: %w", gateway.LegacyAuthClientID, err)
+	}
+
+	log.Info("Deleted legacy OAuthClient from previous version", "name", gateway.LegacyAuthClientID)
+	return nil
+}
+
+// getLegacyOAuthClient returns the legacy "odh" OAuthClient, or nil if it does
+// not exist. The client is only returned if a redirect URI is an HTTPS URL whose
+// path exactly matches OAuthCallbackPath, confirming it was created by the
+// gateway controller and not by an unrelated user workload.
+func getLegacyOAuthClient(ctx context.Context, cli client.Client) (*oauthv1.OAuthClient, error) {
 	legacyClient := &oauthv1.OAuthClient{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: gateway.LegacyAuthClientID,
@@ -253,30 +315,19 @@ func cleanupLegacyOAuthClient(ctx context.Context, cli client.Client) error {
 	if err := cli.Get(ctx, client.ObjectKeyFromObject(legacyClient), legacyClient); err != nil {
 		// IsNoMatchError: OAuthClient CRD not registered (OIDC clusters)
 		if k8serr.IsNotFound(err) || meta.IsNoMatchError(err) {
-			return nil
+			return nil, nil
 		}
-		return fmt.Errorf("failed to check for legacy OAuthClient %q: %w", gateway.LegacyAuthClientID, err)
+		return nil, fmt.Errorf("failed to check for legacy OAuthClient %q: %w", gateway.LegacyAuthClientID, err)
 	}
 
-	isGatewayClient := false
 	for _, uri := range legacyClient.RedirectURIs {
 		u, err := url.Parse(uri)
 		if err == nil && u.Scheme == "https" && u.Path == gateway.OAuthCallbackPath {
-			isGatewayClient = true
-			break
+			return legacyClient, nil
 		}
 	}
 
-	if !isGatewayClient {
-		return nil
-	}
-
-	if err := cli.Delete(ctx, legacyClient); client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to delete legacy OAuthClient %q: %w", gateway.LegacyAuthClientID, err)
-	}
-
-	log.Info("Deleted legacy OAuthClient from previous version", "name", gateway.LegacyAuthClientID)
-	return nil
+	return nil, nil
 }
 
 // MigrateToInfraHardwareProfiles performs one-time migration from AcceleratorProfiles to HardwareProfiles.
@@ -291,8 +342,8 @@ func cleanupLegacyOAuthClient(ctx context.Context, cli client.Client) error {
 //   - User modifications to HardwareProfiles persist across migration runs
 //   - Notebook and InferenceService annotations are updated if not already set
 //
-// This function is called on every operator startup via CleanupExistingResource.
-// The Create-only approach ensures that frequent operator restarts do not overwrite user changes.
+// This function is run by CleanupExistingResource on every startup. The Create-only approach
+// ensures that the repeated runs do not overwrite user changes.
 func MigrateToInfraHardwareProfiles(ctx context.Context, cli client.Client, applicationNS string, basePath string) error {
 	var multiErr *multierror.Error
 	log := logf.FromContext(ctx)
@@ -90,6 +90,13 @@ func AddOperatorFlagsAndEnvvars(envvarPrefix string) error {
 		return err
 	}
 
+	pflag.String("install-profile", "", "Install profile of the default DSCInitialization and DataScienceCluster: "+
+		"one of the built-in profiles (default, minimal, serving, training, full) or the name of a ConfigMap "+
+		"in the operator namespace holding a custom profile.")
+	if err := viper.BindEnv("install-profile", "INSTALL_PROFILE"); err != nil {
+		return err
+	}
+
 	pflag.String("default-manifests-path", "", "Base path for component manifests.")
 	if err := viper.BindEnv("default-manifests-path", "DEFAULT_MANIFESTS_PATH"); err != nil {
 		return err
@@ -106,6 +113,13 @@ func AddOperatorFlagsAndEnvvars(envvarPrefix string) error {
 		return err
 	}
 
+	pflag.String("deploy-conflict-policies", "", "Server-side apply conflict policies of the deployed resources, "+
+		"as a comma separated list of <field manager>=<policy> entries with policy one of Force, Yield or Fail, "+
+		"i.e. 'kube-controller-manager=Yield,*=Force'. The '*' entry applies to the other field managers, default to Force.")
+	if err := viper.BindEnv("deploy-conflict-policies", "DEPLOY_CONFLICT_POLICIES"); err != nil {
+		return err
+	}
+
 	if err := addResourceSuppressionFlags(); err != nil {
 		return err
 	}
@@ -148,3 +162,15 @@ func GetRHAIApplicationsNamespace() string {
 func GetRHAIVersion() string {
 	return strings.TrimSpace(viper.GetString("rhai-version"))
 }
+
+// GetDeployConflictPolicies returns the configured server-side apply conflict
+// policies, with surrounding whitespace trimmed.
+func GetDeployConflictPolicies() string {
+	return strings.TrimSpace(viper.GetString("deploy-conflict-policies"))
+}
+
+// GetInstallProfile returns the configured install profile,
+// with surrounding whitespace trimmed.
+func GetInstallProfile() string {
+	return strings.TrimSpace(viper.GetString("install-profile"))
+}
@@ -101,6 +101,8 @@ func New(opts ...ClientOpts) (client.Client, error) {
 			fakeMapper.Add(kt, meta.RESTScopeRoot)
 		case gvk.GatewayConfig:
 			fakeMapper.Add(kt, meta.RESTScopeRoot)
+		case gvk.UpgradeGate:
+			fakeMapper.Add(kt, meta.RESTScopeRoot)
 		default:
 			fakeMapper.Add(kt, meta.RESTScopeNamespace)
 		}
@@ -22,6 +22,8 @@ import (
 	ccmAwsV1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/aws/v1alpha1"
 	ccmAzureV1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/azure/v1alpha1"
 	ccmCoreweaveV1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/coreweave/v1alpha1"
+	ccmGenericV1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/generic/v1alpha1"
+	ccmGkeV1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/gke/v1alpha1"
 	componentApi "github.com/opendatahub-io/opendatahub-operator/v2/api/components/v1alpha1"
 	configv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/config/v1alpha1"
 	dscv1 "github.com/opendatahub-io/opendatahub-operator/v2/api/datasciencecluster/v1"
@@ -62,6 +64,8 @@ var (
 		ccmAzureV1alpha1.AddToScheme,
 		ccmCoreweaveV1alpha1.AddToScheme,
 		ccmAwsV1alpha1.AddToScheme,
+		ccmGkeV1alpha1.AddToScheme,
+		ccmGenericV1alpha1.AddToScheme,
 		imagev1.Install,
 		addTestTypesToScheme,
 	}
@@ -129,6 +129,9 @@ func TestCloudManager(t *testing.T) { //nolint:maintidx // sequential subtests s
 					jq.Match(`.status.conditions[] | select(.type == "CertManagerReady") | .status == "True"`),
 					jq.Match(`.status.conditions[] | select(.type == "LWSReady") | .status == "True"`),
 					jq.Match(`.status.conditions[] | select(.type == "SailOperatorReady") | .status == "True"`),
+					jq.Match(`.status.conditions[] | select(.type == "KueueReady") | .status == "True"`),
+					jq.Match(`.status.conditions[] | select(.type == "PrometheusOperatorReady") | .status == "True"`),
+					jq.Match(`.status.conditions[] | select(.type == "KEDAReady") | .status == "True"`),
 				))
 			})
 
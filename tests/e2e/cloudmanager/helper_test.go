@@ -82,6 +82,15 @@ func depsWithCustomNamespaces(policy ccmapi.ManagementPolicy) map[string]any {
 		"gatewayAPI": map[string]any{
 			"managementPolicy": p,
 		},
+		"kueue": map[string]any{
+			"managementPolicy": p,
+		},
+		"prometheusOperator": map[string]any{
+			"managementPolicy": p,
+		},
+		"keda": map[string]any{
+			"managementPolicy": p,
+		},
 	}
 }
 
@@ -6,6 +6,8 @@ import (
 	awsv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/aws/v1alpha1"
 	azurev1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/azure/v1alpha1"
 	coreweavev1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/coreweave/v1alpha1"
+	genericv1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/generic/v1alpha1"
+	gkev1alpha1 "github.com/opendatahub-io/opendatahub-operator/v2/api/cloudmanager/gke/v1alpha1"
 	"github.com/opendatahub-io/opendatahub-operator/v2/pkg/cluster/gvk"
 )
 
@@ -31,4 +33,14 @@ var providers = map[string]ProviderConfig{
 		GVK:          gvk.AWSKubernetesEngine,
 		InstanceName: awsv1alpha1.AWSKubernetesEngineInstanceName,
 	},
+	"gke": {
+		Name:         "gke",
+		GVK:          gvk.GKEKubernetesEngine,
+		InstanceName: gkev1alpha1.GKEKubernetesEngineInstanceName,
+	},
+	"generic": {
+		Name:         "generic",
+		GVK:          gvk.GenericKubernetesEngine,
+		InstanceName: genericv1alpha1.GenericKubernetesEngineInstanceName,
+	},
 }